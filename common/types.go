@@ -11,6 +11,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/cielu/go-solana/core"
+	"github.com/cielu/go-solana/pkg/encoding"
 	"github.com/mr-tron/base58"
 	"math/big"
 )
@@ -60,6 +61,7 @@ func StrToAddress(b string) Address {
 }
 
 // Base58ToAddress returns Address with byte values of b.
+// A malformed input gives the empty address, see ParseAddress
 func Base58ToAddress(b string) Address {
 	// decode base58
 	d, _ := base58.Decode(b)
@@ -75,6 +77,18 @@ func Base64ToAddress(b string) Address {
 	return BytesToAddress(d)
 }
 
+// ParseAddress decode the base58 address, the decoded length must be exactly AddressLength bytes.
+func ParseAddress(s string) (Address, error) {
+	d, err := encoding.DecodeBase58(s)
+	if err != nil {
+		return Address{}, fmt.Errorf("invalid address: %w", err)
+	}
+	if len(d) != AddressLength {
+		return Address{}, fmt.Errorf("invalid address length %d, expected %d", len(d), AddressLength)
+	}
+	return BytesToAddress(d), nil
+}
+
 // IsEmpty address is empty
 func (a Address) IsEmpty() bool {
 	return a == Address{}
@@ -203,6 +217,7 @@ func StrToHash(b string) Hash {
 }
 
 // Base58ToHash returns Hash with byte values of b.
+// A malformed input gives the empty hash, see ParseHash
 func Base58ToHash(b string) Hash {
 	// decode base58
 	d, _ := base58.Decode(b)
@@ -218,6 +233,23 @@ func Base64ToHash(b string) Hash {
 	return BytesToHash(d)
 }
 
+// ParseHash decode the base58 hash (e.g. a blockhash), the decoded length must be exactly HashLength bytes.
+func ParseHash(s string) (Hash, error) {
+	d, err := encoding.DecodeBase58(s)
+	if err != nil {
+		return Hash{}, fmt.Errorf("invalid hash: %w", err)
+	}
+	if len(d) != HashLength {
+		return Hash{}, fmt.Errorf("invalid hash length %d, expected %d", len(d), HashLength)
+	}
+	return BytesToHash(d), nil
+}
+
+// IsEmpty hash is empty
+func (h Hash) IsEmpty() bool {
+	return h == Hash{}
+}
+
 // Cmp compares two Hashes.
 func (h Hash) Cmp(other Hash) int {
 	return bytes.Compare(h[:], other[:])
@@ -341,7 +373,7 @@ func (t Base58) String() string {
 // ///// -------------------------------------------------///////
 // ///// -------------------------------------------------///////
 
-// SolData base58, base64 data
+// SolData base58, base64 or base64+zstd data, RawData is decoded and decompressed
 type SolData struct {
 	RawData  []byte
 	Encoding string
@@ -358,8 +390,8 @@ func (sd SolData) Base64() string {
 
 // String return base58 str
 func (sd SolData) String() string {
-	// base64
-	if sd.Encoding == "base64" {
+	// base64, the base64+zstd data is decompressed
+	if sd.Encoding == "base64" || sd.Encoding == "base64+zstd" {
 		return sd.Base64()
 	}
 	return sd.Base58()
@@ -430,6 +462,7 @@ func BytesToSignature(b []byte) (a Signature) {
 func BigToSignature(b *big.Int) Signature { return BytesToSignature(b.Bytes()) }
 
 // Base58ToSignature returns Signature with byte values of b.
+// A malformed input gives the empty signature, see ParseSignature
 func Base58ToSignature(b string) Signature {
 	// decode base58
 	d, _ := base58.Decode(b)
@@ -437,6 +470,19 @@ func Base58ToSignature(b string) Signature {
 	return BytesToSignature(d)
 }
 
+// ParseSignature decode the base58 transaction signature (txid),
+// the decoded length must be exactly SignatureLength bytes.
+func ParseSignature(s string) (Signature, error) {
+	d, err := encoding.DecodeBase58(s)
+	if err != nil {
+		return Signature{}, fmt.Errorf("invalid signature: %w", err)
+	}
+	if len(d) != SignatureLength {
+		return Signature{}, fmt.Errorf("invalid signature length %d, expected %d", len(d), SignatureLength)
+	}
+	return BytesToSignature(d), nil
+}
+
 // Cmp compares two addresses.
 func (s Signature) Cmp(other Signature) int {
 	return bytes.Compare(s[:], other[:])
@@ -521,6 +567,8 @@ func (s *Signature) UnmarshalGraphQL(input interface{}) error {
 	return err
 }
 
-func (s Signature) Sign(message []byte) []byte {
-	return ed25519.Sign(s.Bytes(), message)
+// VerifySignature reports whether s is a valid signature of message by pubkey.
+// Signing belongs to the private key, see crypto.Account.Sign
+func (s Signature) VerifySignature(pubkey Address, message []byte) bool {
+	return ed25519.Verify(pubkey.Bytes(), message, s.Bytes())
 }
@@ -1,9 +1,14 @@
 package common
 
 import (
+	"bytes"
 	"crypto/ed25519"
 	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
 	"testing"
+
+	"github.com/klauspost/compress/zstd"
 )
 
 func TestAddress(t *testing.T) {
@@ -49,3 +54,93 @@ func TestAddress(t *testing.T) {
 	// log addr1 and addr2
 	t.Logf("addr1: %s, addr2: %s", addr1, addr2)
 }
+
+func TestSignatureVerify(t *testing.T) {
+	pub, prv, _ := ed25519.GenerateKey(rand.Reader)
+	message := []byte("hello world")
+	var (
+		addr = BytesToAddress(pub)
+		sig  = BytesToSignature(ed25519.Sign(prv, message))
+	)
+	if !sig.VerifySignature(addr, message) {
+		t.Errorf("VerifySignature Err ==> Got false, Want: true")
+	}
+	// zero signature
+	if (Signature{}).VerifySignature(addr, message) {
+		t.Errorf("VerifySignature Err ==> empty signature verified")
+	}
+}
+
+func TestParseSignature(t *testing.T) {
+	const txid = "5VERv8NMvzbJMEkV8xnrLkEaWRtSz9CosKDYjCJjBRnbJLgp8uirBgmQpjKhoR4tjF3ZpRzrFmBV6UjKdiSZkQUW"
+	sig, err := ParseSignature(txid)
+	if err != nil {
+		t.Fatalf("ParseSignature Failed: %s", err)
+	}
+	if sig.String() != txid {
+		t.Errorf("ParseSignature Err ==> Got %s, Want: %s", sig, txid)
+	}
+	// truncated
+	if _, err = ParseSignature(txid[:40]); err == nil {
+		t.Errorf("ParseSignature with truncated signature should fail")
+	}
+	// invalid base58
+	if _, err = ParseSignature("0OIl"); err == nil {
+		t.Errorf("ParseSignature with invalid base58 should fail")
+	}
+}
+
+func TestSolDataBase64Zstd(t *testing.T) {
+	// a token account sized payload
+	raw := bytes.Repeat([]byte{1, 2, 3, 0, 0, 0, 0, 0}, 20)
+	raw = append(raw, 9, 9, 9, 9, 9)
+	encoder, err := zstd.NewWriter(nil)
+	if err != nil {
+		t.Fatalf("zstd NewWriter Failed: %s", err)
+	}
+	compressed := encoder.EncodeAll(raw, nil)
+	input, _ := json.Marshal([]string{base64.StdEncoding.EncodeToString(compressed), "base64+zstd"})
+
+	var data SolData
+	if err = json.Unmarshal(input, &data); err != nil {
+		t.Fatalf("UnmarshalJSON Failed: %s", err)
+	}
+	if !bytes.Equal(data.RawData, raw) {
+		t.Errorf("RawData Err ==> Got %v, Want: %v", data.RawData, raw)
+	}
+	if data.Encoding != "base64+zstd" {
+		t.Errorf("Encoding Err ==> Got %s, Want: %s", data.Encoding, "base64+zstd")
+	}
+	if data.String() != base64.StdEncoding.EncodeToString(raw) {
+		t.Errorf("String Err ==> Got %s, Want: %s", data.String(), base64.StdEncoding.EncodeToString(raw))
+	}
+	// corrupted payload
+	input, _ = json.Marshal([]string{base64.StdEncoding.EncodeToString(compressed[:len(compressed)-4]), "base64+zstd"})
+	if err = json.Unmarshal(input, &data); err == nil {
+		t.Errorf("UnmarshalJSON with corrupted payload should fail")
+	}
+}
+
+func TestParseAddressAndHash(t *testing.T) {
+	const address = "EfgnVEwyeeFLZyZ4nnnzZtqV6B3DhdtXFNsGSzdti9ZN"
+	addr, err := ParseAddress(address)
+	if err != nil || addr.String() != address {
+		t.Errorf("ParseAddress Err ==> Got %s %v, Want: %s", addr, err, address)
+	}
+	hash, err := ParseHash(address)
+	if err != nil || hash.String() != address {
+		t.Errorf("ParseHash Err ==> Got %s %v, Want: %s", hash, err, address)
+	}
+	// malformed inputs the Base58ToX constructors silently turn into the empty value
+	for _, malformed := range []string{"", "0OIl", address[:20], address + "11"} {
+		if _, err = ParseAddress(malformed); err == nil {
+			t.Errorf("ParseAddress(%q) Err ==> Got nil, Want: error", malformed)
+		}
+		if _, err = ParseHash(malformed); err == nil {
+			t.Errorf("ParseHash(%q) Err ==> Got nil, Want: error", malformed)
+		}
+	}
+	if !Base58ToAddress("0OIl").IsEmpty() {
+		t.Errorf("Base58ToAddress Err ==> Got non empty address for malformed input")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"github.com/klauspost/compress/zstd"
 	"github.com/mr-tron/base58"
 )
 
@@ -47,6 +48,11 @@ func UnmarshalDataByEncoding(input []byte) ([]byte, string, error) {
 		case "base64":
 			encoding = "base64"
 			input, _ = base64.StdEncoding.DecodeString(v[0].(string))
+		case "base64+zstd":
+			encoding = "base64+zstd"
+			if input, err = DecodeBase64Zstd(v[0].(string)); err != nil {
+				return nil, "", fmt.Errorf("UnmarshalDataByEncoding Err: %w", err)
+			}
 		default:
 			return nil, "", fmt.Errorf("UnmarshalDataByEncoding Err: %s", v[1])
 		}
@@ -54,6 +60,18 @@ func UnmarshalDataByEncoding(input []byte) ([]byte, string, error) {
 	return input, encoding, err
 }
 
+// zstdDecoder the shared zstd decoder, DecodeAll is safe for concurrent use
+var zstdDecoder, _ = zstd.NewReader(nil)
+
+// DecodeBase64Zstd decode the base64 str and decompress the zstd payload
+func DecodeBase64Zstd(input string) ([]byte, error) {
+	compressed, err := base64.StdEncoding.DecodeString(input)
+	if err != nil {
+		return nil, err
+	}
+	return zstdDecoder.DecodeAll(compressed, nil)
+}
+
 // UniqueAppend judge and append key
 func UniqueAppend[T comparable](slice []T, lookup T) []T {
 	// append unique key
@@ -77,3 +77,29 @@ func TestAccount(t *testing.T) {
 	}
 	fmt.Println("account6:", account6.Address)
 }
+
+func TestAccountSign(t *testing.T) {
+	account, err := GenerateAccount()
+	if err != nil {
+		t.Fatalf("GenerateAccount Failed: %s", err.Error())
+	}
+	other, err := GenerateAccount()
+	if err != nil {
+		t.Fatalf("GenerateAccount Failed: %s", err.Error())
+	}
+	message := []byte("hello world")
+	// sign with account
+	sig := common.BytesToSignature(account.Sign(message))
+	// verify with the account public key
+	if !sig.VerifySignature(account.Address, message) {
+		t.Errorf("signature verify failed. Address: %s, Signature: %s", account.Address, sig)
+	}
+	// verify with other public key
+	if sig.VerifySignature(other.Address, message) {
+		t.Errorf("signature verified with wrong address: %s", other.Address)
+	}
+	// verify tampered message
+	if sig.VerifySignature(account.Address, []byte("hello word")) {
+		t.Errorf("signature verified with tampered message")
+	}
+}
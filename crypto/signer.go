@@ -0,0 +1,40 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package crypto
+
+import (
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/core"
+)
+
+// Signer signs messages for a public key without exposing the private key,
+// e.g. an Account, a hardware wallet or a remote KMS signer
+type Signer interface {
+	// PublicKey Returns the address the signer signs for
+	PublicKey() common.Address
+	// SignMessage Returns the ed25519 signature of the message
+	SignMessage(message []byte) (common.Signature, error)
+}
+
+// PublicKey Returns the address of the account
+func (a Account) PublicKey() common.Address {
+	return a.Address
+}
+
+// SignMessage sign the message with account, implements Signer
+func (a Account) SignMessage(message []byte) (common.Signature, error) {
+	if len(a.PrivateKey) == 0 {
+		return common.Signature{}, core.ErrEmptyAccount
+	}
+	return common.BytesToSignature(a.Sign(message)), nil
+}
+
+// Signers Returns the accounts as signers
+func Signers(accounts []Account) []Signer {
+	signers := make([]Signer, len(accounts))
+	for i, account := range accounts {
+		signers[i] = account
+	}
+	return signers
+}
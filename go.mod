@@ -8,6 +8,7 @@ require (
 	github.com/deckarep/golang-set/v2 v2.6.0
 	github.com/fatih/color v1.9.0
 	github.com/gorilla/websocket v1.5.1
+	github.com/klauspost/compress v1.17.9
 	github.com/mr-tron/base58 v1.2.0
 	github.com/tyler-smith/go-bip39 v1.1.0
 )
@@ -6,6 +6,7 @@ import (
 	"io"
 	"math"
 	"reflect"
+	"strconv"
 	"strings"
 )
 
@@ -137,9 +138,40 @@ func TypeIDFromUint64(v uint64, bo binary.ByteOrder) TypeID {
 	return TypeIDFromBytes(out)
 }
 
+// OptionKind is the layout of the discriminant in front of an optional value.
+type OptionKind int
+
+const (
+	// OptionKindCOption is the 4 bytes (u32) discriminant used by the spl programs' COption<T>.
+	OptionKindCOption OptionKind = iota
+	// OptionKindBorsh is the 1 byte discriminant used by borsh Option<T>.
+	OptionKindBorsh
+)
+
+// LengthPrefix is the layout of the length in front of a slice.
+type LengthPrefix int
+
+const (
+	// LengthPrefixUVarInt is the default uvarint length.
+	LengthPrefixUVarInt LengthPrefix = iota
+	// LengthPrefixCompactU16 is the compact-u16 length of the transaction wire format, tag `bin:"compact16"`.
+	LengthPrefixCompactU16
+	// LengthPrefixU8 is a 1 byte length, tag `bin:"lenPrefix=u8"`.
+	LengthPrefixU8
+	// LengthPrefixU16 is a 2 bytes (u16) length, tag `bin:"lenPrefix=u16"`.
+	LengthPrefixU16
+	// LengthPrefixU32 is the 4 bytes (u32) length of borsh Vec<T>, tag `bin:"lenPrefix=u32"`.
+	LengthPrefixU32
+	// LengthPrefixU64 is an 8 bytes (u64) length, tag `bin:"lenPrefix=u64"`.
+	LengthPrefixU64
+)
+
 type option struct {
 	OptionalField bool
+	OptionKind    OptionKind
 	SizeOfSlice   *int
+	LengthPrefix  LengthPrefix
+	FixedLength   *int
 	Order         binary.ByteOrder
 }
 
@@ -168,6 +200,10 @@ func (o *option) setSizeOfSlice(size int) *option {
 	o.SizeOfSlice = &size
 	return o
 }
+func (o *option) hasFixedLength() bool {
+	return o.FixedLength != nil
+}
+
 func (o *option) setIsOptional(isOptional bool) *option {
 	o.OptionalField = isOptional
 	return o
@@ -202,6 +238,8 @@ func (e *Encoder) Written() int {
 	return e.count
 }
 
+// WriteBytes writes b, prefixed with its uvarint length when writeLength is true.
+// See WriteLengthPrefix for the other length layouts.
 func (e *Encoder) WriteBytes(b []byte, writeLength bool) error {
 	if writeLength {
 		if err := e.WriteLength(len(b)); err != nil {
@@ -242,6 +280,38 @@ func (e *Encoder) WriteBool(b bool) (err error) {
 	return e.WriteByte(out)
 }
 
+// WriteBoolSlice writes a borsh Vec<bool>: the u32 length, then one byte per value.
+func (e *Encoder) WriteBoolSlice(values []bool) (err error) {
+	if err = e.WriteUint32(uint32(len(values)), binary.LittleEndian); err != nil {
+		return err
+	}
+	return e.WriteBoolArray(values)
+}
+
+// WriteBoolArray writes a borsh [bool; N], one byte per value without length.
+func (e *Encoder) WriteBoolArray(values []bool) (err error) {
+	buf := make([]byte, len(values))
+	for i, v := range values {
+		if v {
+			buf[i] = 1
+		}
+	}
+	return e.WriteBytes(buf, false)
+}
+
+// WriteOption writes the discriminant of an optional value, the value itself
+// must be written after it when present.
+func (e *Encoder) WriteOption(present bool, kind OptionKind) (err error) {
+	if kind == OptionKindBorsh {
+		return e.WriteBool(present)
+	}
+	var out uint32
+	if present {
+		out = 1
+	}
+	return e.WriteUint32(out, binary.LittleEndian)
+}
+
 func (e *Encoder) WriteUint8(i uint8) (err error) {
 	return e.WriteByte(i)
 }
@@ -278,16 +348,19 @@ func (e *Encoder) WriteUint64(i uint64, order binary.ByteOrder) (err error) {
 
 func (e *Encoder) WriteUint128(i Uint128, order binary.ByteOrder) (err error) {
 	buf := make([]byte, TypeSize.Uint128)
-	order.PutUint64(buf, i.Lo)
-	order.PutUint64(buf[TypeSize.Uint64:], i.Hi)
+	if order == binary.LittleEndian {
+		order.PutUint64(buf, i.Lo)
+		order.PutUint64(buf[TypeSize.Uint64:], i.Hi)
+	} else {
+		// the high limb first, as ReadUint128 expects
+		order.PutUint64(buf, i.Hi)
+		order.PutUint64(buf[TypeSize.Uint64:], i.Lo)
+	}
 	return e.toWriter(buf)
 }
 
 func (e *Encoder) WriteInt128(i Int128, order binary.ByteOrder) (err error) {
-	buf := make([]byte, TypeSize.Uint128)
-	order.PutUint64(buf, i.Lo)
-	order.PutUint64(buf[TypeSize.Uint64:], i.Hi)
-	return e.toWriter(buf)
+	return e.WriteUint128(Uint128(i), order)
 }
 
 func (e *Encoder) WriteFloat32(f float32, order binary.ByteOrder) (err error) {
@@ -323,11 +396,46 @@ func (e *Encoder) WriteCompactU16Length(ln int) (err error) {
 	return e.toWriter(buf)
 }
 
+// WriteLengthPrefix writes the length of a slice with the prefix layout, the little endian order
+// is used for the fixed size lengths.
+func (e *Encoder) WriteLengthPrefix(length int, prefix LengthPrefix) (err error) {
+	switch prefix {
+	case LengthPrefixUVarInt:
+		return e.WriteUVarInt(length)
+	case LengthPrefixCompactU16:
+		if length > math.MaxUint16 {
+			return fmt.Errorf("length %d overflows compact-u16", length)
+		}
+		return e.WriteCompactU16Length(length)
+	case LengthPrefixU8:
+		if length > math.MaxUint8 {
+			return fmt.Errorf("length %d overflows u8", length)
+		}
+		return e.WriteUint8(uint8(length))
+	case LengthPrefixU16:
+		if length > math.MaxUint16 {
+			return fmt.Errorf("length %d overflows u16", length)
+		}
+		return e.WriteUint16(uint16(length), binary.LittleEndian)
+	case LengthPrefixU32:
+		if uint64(length) > math.MaxUint32 {
+			return fmt.Errorf("length %d overflows u32", length)
+		}
+		return e.WriteUint32(uint32(length), binary.LittleEndian)
+	case LengthPrefixU64:
+		return e.WriteUint64(uint64(length), binary.LittleEndian)
+	}
+	return fmt.Errorf("unknown length prefix %d", prefix)
+}
+
 type fieldTag struct {
 	SizeOf          string
+	LengthPrefix    LengthPrefix
+	FixedLength     *int
 	Skip            bool
 	Order           binary.ByteOrder
 	Optional        bool
+	OptionKind      OptionKind
 	BinaryExtension bool
 
 	IsBorshEnum bool
@@ -346,8 +454,22 @@ func parseFieldTag(tag reflect.StructTag) *fieldTag {
 			t.Order = binary.BigEndian
 		} else if s == "little" {
 			t.Order = binary.LittleEndian
-		} else if s == "optional" {
+		} else if s == "optional" || s == "optional=coption" {
+			t.Optional = true
+			t.OptionKind = OptionKindCOption
+		} else if s == "optional=borsh" {
 			t.Optional = true
+			t.OptionKind = OptionKindBorsh
+		} else if s == "compact16" || s == "lenPrefix=compact16" {
+			t.LengthPrefix = LengthPrefixCompactU16
+		} else if strings.HasPrefix(s, "lenPrefix=") {
+			t.LengthPrefix = parseLengthPrefix(strings.TrimPrefix(s, "lenPrefix="))
+		} else if strings.HasPrefix(s, "fixed=") {
+			n, err := strconv.Atoi(strings.TrimPrefix(s, "fixed="))
+			if err != nil || n < 0 {
+				panic(fmt.Sprintf("invalid `bin:%q` tag, the fixed length must be a positive integer", s))
+			}
+			t.FixedLength = &n
 		} else if s == "binary_extension" {
 			t.BinaryExtension = true
 		} else if s == "-" {
@@ -365,6 +487,22 @@ func parseFieldTag(tag reflect.StructTag) *fieldTag {
 	return t
 }
 
+func parseLengthPrefix(s string) LengthPrefix {
+	switch s {
+	case "uvarint":
+		return LengthPrefixUVarInt
+	case "u8":
+		return LengthPrefixU8
+	case "u16":
+		return LengthPrefixU16
+	case "u32":
+		return LengthPrefixU32
+	case "u64":
+		return LengthPrefixU64
+	}
+	panic(fmt.Sprintf("invalid `bin:\"lenPrefix=%s\"` tag, require one of uvarint, compact16, u8, u16, u32, u64", s))
+}
+
 func isZero(rv reflect.Value) (b bool) {
 	return rv.Kind() == 0
 }
@@ -398,9 +536,9 @@ func (e *Encoder) encodeBin(rv reflect.Value, opt *option) (err error) {
 
 	if opt.isOptional() {
 		if rv.IsZero() {
-			return e.WriteUint32(0, binary.LittleEndian)
+			return e.WriteOption(false, opt.OptionKind)
 		}
-		err := e.WriteUint32(1, binary.LittleEndian)
+		err := e.WriteOption(true, opt.OptionKind)
 		if err != nil {
 			return err
 		}
@@ -453,6 +591,9 @@ func (e *Encoder) encodeBin(rv reflect.Value, opt *option) (err error) {
 	switch rt.Kind() {
 	case reflect.Array:
 		l := rt.Len()
+		if opt.hasFixedLength() && *opt.FixedLength != l {
+			return fmt.Errorf("encode: fixed length %d of a %q", *opt.FixedLength, rt)
+		}
 		if rv.Type().Elem().Kind() == reflect.Uint8 {
 			// if it's a [n]byte, accumulate and write in one command:
 			arr := make([]byte, l)
@@ -473,9 +614,14 @@ func (e *Encoder) encodeBin(rv reflect.Value, opt *option) (err error) {
 		var l int
 		if opt.hasSizeOfSlice() {
 			l = opt.getSizeOfSlice()
+		} else if opt.hasFixedLength() {
+			l = *opt.FixedLength
+			if rv.Len() != l {
+				return fmt.Errorf("encode: slice of length %d, require the fixed length %d", rv.Len(), l)
+			}
 		} else {
 			l = rv.Len()
-			if err = e.WriteUVarInt(l); err != nil {
+			if err = e.WriteLengthPrefix(l, opt.LengthPrefix); err != nil {
 				return
 			}
 		}
@@ -538,6 +684,9 @@ func (e *Encoder) encodeStructBin(rt reflect.Type, rv reflect.Value) (err error)
 
 		option := &option{
 			OptionalField: fieldTag.Optional,
+			OptionKind:    fieldTag.OptionKind,
+			LengthPrefix:  fieldTag.LengthPrefix,
+			FixedLength:   fieldTag.FixedLength,
 			Order:         fieldTag.Order,
 		}
 
@@ -0,0 +1,49 @@
+package encodbin
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestBoolSliceRoundTrip(t *testing.T) {
+	votes := []bool{true, false, false, true, true}
+	buf := new(bytes.Buffer)
+	enc := NewBinEncoder(buf)
+	if err := enc.WriteBoolSlice(votes); err != nil {
+		t.Fatalf("WriteBoolSlice Failed: %s", err)
+	}
+	if err := enc.WriteBoolArray([]bool{false, true}); err != nil {
+		t.Fatalf("WriteBoolArray Failed: %s", err)
+	}
+	want := []byte{5, 0, 0, 0, 1, 0, 0, 1, 1, 0, 1}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Fatalf("encoded Err ==> Got %v, Want: %v", buf.Bytes(), want)
+	}
+
+	dec := NewBinDecoder(buf.Bytes())
+	got, err := dec.ReadBoolSlice()
+	if err != nil || !reflect.DeepEqual(got, votes) {
+		t.Errorf("ReadBoolSlice Err ==> Got %v, %v, Want: %v", got, err, votes)
+	}
+	arr, err := dec.ReadBoolArray(2)
+	if err != nil || !reflect.DeepEqual(arr, []bool{false, true}) {
+		t.Errorf("ReadBoolArray Err ==> Got %v, %v, Want: %v", arr, err, []bool{false, true})
+	}
+	// empty vec
+	buf.Reset()
+	if err = NewBinEncoder(buf).WriteBoolSlice(nil); err != nil || !bytes.Equal(buf.Bytes(), []byte{0, 0, 0, 0}) {
+		t.Errorf("WriteBoolSlice empty Err ==> Got %v, %v", buf.Bytes(), err)
+	}
+	// invalid bool byte
+	if _, err = NewBinDecoder([]byte{1, 0, 0, 0, 2}).ReadBoolSlice(); err == nil {
+		t.Errorf("ReadBoolSlice with invalid bool should fail")
+	}
+	// length over the remaining bytes
+	if _, err = NewBinDecoder([]byte{3, 0, 0, 0, 1}).ReadBoolSlice(); err == nil {
+		t.Errorf("ReadBoolSlice with short buffer should fail")
+	}
+	if _, err = NewBinDecoder([]byte{1}).ReadBoolArray(-1); err == nil {
+		t.Errorf("ReadBoolArray with negative n should fail")
+	}
+}
@@ -268,6 +268,57 @@ func (dec *Decoder) ReadBool() (out bool, err error) {
 	return
 }
 
+// ReadBoolSlice reads a borsh Vec<bool>: the u32 length, then one byte per value.
+func (dec *Decoder) ReadBoolSlice() (out []bool, err error) {
+	length, err := dec.ReadUint32(binary.LittleEndian)
+	if err != nil {
+		return nil, fmt.Errorf("readBoolSlice length, %s", err)
+	}
+	if uint64(length) > uint64(dec.Remaining()) {
+		return nil, fmt.Errorf("readBoolSlice, length %d exceeds remaining [%d]", length, dec.Remaining())
+	}
+	return dec.ReadBoolArray(int(length))
+}
+
+// ReadBoolArray reads a borsh [bool; n], one byte per value without length.
+// Bytes other than 0 and 1 are rejected as borsh does.
+func (dec *Decoder) ReadBoolArray(n int) (out []bool, err error) {
+	if n < 0 {
+		return nil, fmt.Errorf("readBoolArray, n not valid: %d", n)
+	}
+	if dec.Remaining() < n {
+		return nil, fmt.Errorf("bool array required [%d] bytes, remaining [%d]", n, dec.Remaining())
+	}
+	out = make([]bool, n)
+	for i := range out {
+		b, _ := dec.ReadByte()
+		if b > 1 {
+			return nil, fmt.Errorf("readBoolArray, invalid bool %d at %d", b, i)
+		}
+		out[i] = b == 1
+	}
+	return out, nil
+}
+
+// ReadOption reads the discriminant of an optional value, reports whether the value is present.
+func (dec *Decoder) ReadOption(kind OptionKind) (present bool, err error) {
+	var flag uint32
+	if kind == OptionKindBorsh {
+		var b uint8
+		b, err = dec.ReadUint8()
+		flag = uint32(b)
+	} else {
+		flag, err = dec.ReadUint32(binary.LittleEndian)
+	}
+	if err != nil {
+		return false, fmt.Errorf("readOption, %s", err)
+	}
+	if flag > 1 {
+		return false, fmt.Errorf("readOption, invalid discriminant %d", flag)
+	}
+	return flag == 1, nil
+}
+
 func (dec *Decoder) ReadUint8() (out uint8, err error) {
 	out, err = dec.ReadByte()
 	return
@@ -446,6 +497,40 @@ func (dec *Decoder) ReadCompactU16Length() (int, error) {
 	return val, err
 }
 
+// ReadLengthPrefix reads the length of a slice written with the prefix layout, see Encoder.WriteLengthPrefix.
+func (dec *Decoder) ReadLengthPrefix(prefix LengthPrefix) (length int, err error) {
+	var n uint64
+	switch prefix {
+	case LengthPrefixUVarInt:
+		return dec.ReadLength()
+	case LengthPrefixCompactU16:
+		return dec.ReadCompactU16Length()
+	case LengthPrefixU8:
+		var v uint8
+		v, err = dec.ReadUint8()
+		n = uint64(v)
+	case LengthPrefixU16:
+		var v uint16
+		v, err = dec.ReadUint16(binary.LittleEndian)
+		n = uint64(v)
+	case LengthPrefixU32:
+		var v uint32
+		v, err = dec.ReadUint32(binary.LittleEndian)
+		n = uint64(v)
+	case LengthPrefixU64:
+		n, err = dec.ReadUint64(binary.LittleEndian)
+	default:
+		return 0, fmt.Errorf("unknown length prefix %d", prefix)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if n > 0x7FFF_FFFF {
+		return 0, io.ErrUnexpectedEOF
+	}
+	return int(n), nil
+}
+
 func (dec *Decoder) SkipBytes(count uint) error {
 	if uint(dec.Remaining()) < count {
 		return fmt.Errorf("request to skip %d but only %d bytes remain", count, dec.Remaining())
@@ -774,13 +859,13 @@ func (dec *Decoder) decodeBin(rv reflect.Value, opt *option) (err error) {
 	unmarshaler, rv := indirect(rv, opt.isOptional())
 
 	if opt.isOptional() {
-		isPresent, e := dec.ReadUint32(binary.LittleEndian)
+		isPresent, e := dec.ReadOption(opt.OptionKind)
 		if e != nil {
 			err = fmt.Errorf("decode: %s isPresent, %s", rv.Type().String(), e)
 			return
 		}
 
-		if isPresent == 0 {
+		if !isPresent {
 			rv.Set(reflect.Zero(rv.Type()))
 			return
 		}
@@ -865,6 +950,9 @@ func (dec *Decoder) decodeBin(rv reflect.Value, opt *option) (err error) {
 	switch rt.Kind() {
 	case reflect.Array:
 		l := rt.Len()
+		if opt.hasFixedLength() && *opt.FixedLength != l {
+			return fmt.Errorf("decode: fixed length %d of a %q", *opt.FixedLength, rt)
+		}
 
 		switch k := rv.Type().Elem().Kind(); k {
 		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
@@ -883,8 +971,10 @@ func (dec *Decoder) decodeBin(rv reflect.Value, opt *option) (err error) {
 		var l int
 		if opt.hasSizeOfSlice() {
 			l = opt.getSizeOfSlice()
+		} else if opt.hasFixedLength() {
+			l = *opt.FixedLength
 		} else {
-			length, err := dec.ReadLength()
+			length, err := dec.ReadLengthPrefix(opt.LengthPrefix)
 			if err != nil {
 				return err
 			}
@@ -998,6 +1088,9 @@ func (dec *Decoder) decodeStructBin(rt reflect.Type, rv reflect.Value) (err erro
 
 		option := &option{
 			OptionalField: fieldTag.Optional,
+			OptionKind:    fieldTag.OptionKind,
+			LengthPrefix:  fieldTag.LengthPrefix,
+			FixedLength:   fieldTag.FixedLength,
 			Order:         fieldTag.Order,
 		}
 
@@ -0,0 +1,96 @@
+package encodbin
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+type lengthTagged struct {
+	Key      [32]byte `bin:"fixed=32"`
+	Accounts []uint8  `bin:"compact16"`
+	Data     []byte   `bin:"lenPrefix=u32"`
+	Seeds    []uint16 `bin:"lenPrefix=u8"`
+	Tail     []byte   `bin:"fixed=2"`
+}
+
+func TestLengthTags(t *testing.T) {
+	var v lengthTagged
+	for i := range v.Key {
+		v.Key[i] = byte(i)
+	}
+	v.Accounts = make([]uint8, 130)
+	for i := range v.Accounts {
+		v.Accounts[i] = byte(i)
+	}
+	v.Data = []byte{0xaa, 0xbb, 0xcc}
+	v.Seeds = []uint16{1, 0x0102}
+	v.Tail = []byte{7, 8}
+
+	want := append([]byte{}, v.Key[:]...)
+	// compact-u16 of 130
+	want = append(append(want, 0x82, 0x01), v.Accounts...)
+	want = append(want, 3, 0, 0, 0, 0xaa, 0xbb, 0xcc)
+	want = append(want, 2, 1, 0, 2, 1)
+	want = append(want, 7, 8)
+
+	got, err := MarshalBin(&v)
+	if err != nil {
+		t.Fatalf("MarshalBin Failed: %s", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("MarshalBin Err ==> Got %v, Want: %v", got, want)
+	}
+
+	var decoded lengthTagged
+	if err = UnmarshalBin(&decoded, got); err != nil {
+		t.Fatalf("UnmarshalBin Failed: %s", err)
+	}
+	if !reflect.DeepEqual(decoded, v) {
+		t.Errorf("UnmarshalBin Err ==> Got %+v, Want: %+v", decoded, v)
+	}
+
+	// the fixed length must match
+	v.Tail = []byte{7}
+	if _, err = MarshalBin(&v); err == nil {
+		t.Errorf("MarshalBin Err ==> Got nil, Want: fixed length error")
+	}
+	// the prefixed length must not overflow
+	v.Tail = []byte{7, 8}
+	v.Seeds = make([]uint16, 256)
+	if _, err = MarshalBin(&v); err == nil {
+		t.Errorf("MarshalBin Err ==> Got nil, Want: u8 overflow")
+	}
+	// truncated data
+	if err = UnmarshalBin(&decoded, want[:40]); err == nil {
+		t.Errorf("UnmarshalBin Err ==> Got nil, Want: truncated error")
+	}
+}
+
+func TestLengthPrefix(t *testing.T) {
+	tests := []struct {
+		prefix LengthPrefix
+		want   []byte
+	}{
+		{LengthPrefixUVarInt, []byte{0xac, 0x02}},
+		{LengthPrefixCompactU16, []byte{0xac, 0x02}},
+		{LengthPrefixU16, []byte{0x2c, 0x01}},
+		{LengthPrefixU32, []byte{0x2c, 0x01, 0, 0}},
+		{LengthPrefixU64, []byte{0x2c, 0x01, 0, 0, 0, 0, 0, 0}},
+	}
+	for _, tt := range tests {
+		buf := new(bytes.Buffer)
+		if err := NewBinEncoder(buf).WriteLengthPrefix(300, tt.prefix); err != nil {
+			t.Fatalf("WriteLengthPrefix(%d) Failed: %s", tt.prefix, err)
+		}
+		if !bytes.Equal(buf.Bytes(), tt.want) {
+			t.Errorf("WriteLengthPrefix(%d) Err ==> Got %v, Want: %v", tt.prefix, buf.Bytes(), tt.want)
+		}
+		if n, err := NewBinDecoder(buf.Bytes()).ReadLengthPrefix(tt.prefix); err != nil || n != 300 {
+			t.Errorf("ReadLengthPrefix(%d) Err ==> Got %d %v, Want: 300", tt.prefix, n, err)
+		}
+	}
+	if err := NewBinEncoder(new(bytes.Buffer)).WriteLengthPrefix(300, LengthPrefixU8); err == nil {
+		t.Errorf("WriteLengthPrefix Err ==> Got nil, Want: u8 overflow")
+	}
+}
@@ -0,0 +1,111 @@
+package encodbin
+
+import (
+	"bytes"
+	"testing"
+)
+
+type pubkey [32]byte
+
+type coptionState struct {
+	Authority *pubkey `bin:"optional"`
+	Amount    uint64
+}
+
+type borshOptionState struct {
+	Authority *pubkey `bin:"optional=borsh"`
+	Amount    uint64
+}
+
+func TestDecodeCOptionPubkey(t *testing.T) {
+	var key pubkey
+	for i := range key {
+		key[i] = byte(i + 1)
+	}
+	amount := []byte{0x2a, 0, 0, 0, 0, 0, 0, 0}
+
+	tests := []struct {
+		name string
+		blob []byte
+		want *pubkey
+		new  func() interface{}
+		get  func(v interface{}) (*pubkey, uint64)
+	}{
+		{
+			name: "coption present",
+			blob: append(append([]byte{1, 0, 0, 0}, key[:]...), amount...),
+			want: &key,
+			new:  func() interface{} { return new(coptionState) },
+			get: func(v interface{}) (*pubkey, uint64) {
+				return v.(*coptionState).Authority, v.(*coptionState).Amount
+			},
+		},
+		{
+			name: "coption absent",
+			blob: append([]byte{0, 0, 0, 0}, amount...),
+			new:  func() interface{} { return new(coptionState) },
+			get: func(v interface{}) (*pubkey, uint64) {
+				return v.(*coptionState).Authority, v.(*coptionState).Amount
+			},
+		},
+		{
+			name: "borsh present",
+			blob: append(append([]byte{1}, key[:]...), amount...),
+			want: &key,
+			new:  func() interface{} { return new(borshOptionState) },
+			get: func(v interface{}) (*pubkey, uint64) {
+				return v.(*borshOptionState).Authority, v.(*borshOptionState).Amount
+			},
+		},
+		{
+			name: "borsh absent",
+			blob: append([]byte{0}, amount...),
+			new:  func() interface{} { return new(borshOptionState) },
+			get: func(v interface{}) (*pubkey, uint64) {
+				return v.(*borshOptionState).Authority, v.(*borshOptionState).Amount
+			},
+		},
+	}
+
+	for _, test := range tests {
+		v := test.new()
+		if err := UnmarshalBin(v, test.blob); err != nil {
+			t.Errorf("%s: UnmarshalBin Failed: %s", test.name, err)
+			continue
+		}
+		got, gotAmount := test.get(v)
+		if (got == nil) != (test.want == nil) || (got != nil && *got != *test.want) {
+			t.Errorf("%s: Authority Err ==> Got %v, Want: %v", test.name, got, test.want)
+		}
+		if gotAmount != 42 {
+			t.Errorf("%s: Amount Err ==> Got %d, Want: %d", test.name, gotAmount, 42)
+		}
+		// round trip
+		out, err := MarshalBin(v)
+		if err != nil {
+			t.Errorf("%s: MarshalBin Failed: %s", test.name, err)
+			continue
+		}
+		if !bytes.Equal(out, test.blob) {
+			t.Errorf("%s: MarshalBin Err ==> Got %v, Want: %v", test.name, out, test.blob)
+		}
+	}
+}
+
+func TestReadOption(t *testing.T) {
+	// invalid discriminant
+	if _, err := NewBinDecoder([]byte{2}).ReadOption(OptionKindBorsh); err == nil {
+		t.Errorf("ReadOption with invalid discriminant should fail")
+	}
+	// short buffer
+	if _, err := NewBinDecoder([]byte{1, 0}).ReadOption(OptionKindCOption); err == nil {
+		t.Errorf("ReadOption with short buffer should fail")
+	}
+	buf := new(bytes.Buffer)
+	if err := NewBinEncoder(buf).WriteOption(true, OptionKindCOption); err != nil {
+		t.Fatalf("WriteOption Failed: %s", err)
+	}
+	if !bytes.Equal(buf.Bytes(), []byte{1, 0, 0, 0}) {
+		t.Errorf("WriteOption Err ==> Got %v, Want: %v", buf.Bytes(), []byte{1, 0, 0, 0})
+	}
+}
@@ -0,0 +1,225 @@
+package encodbin
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// Arithmetic on Uint128 works on the Lo/Hi limbs directly. Operations never wrap:
+// an overflow, underflow or division by zero returns an error and a zero value.
+// The result keeps the Endianness of the receiver.
+
+var (
+	ErrUint128Overflow     = errors.New("uint128 overflow")
+	ErrUint128Underflow    = errors.New("uint128 underflow")
+	ErrUint128DivideByZero = errors.New("uint128 division by zero")
+)
+
+// NewUint128 creates a Uint128 from its limbs, with the default byte order
+func NewUint128(hi, lo uint64) Uint128 {
+	return Uint128{Lo: lo, Hi: hi}
+}
+
+// IsZero reports whether i == 0
+func (i Uint128) IsZero() bool {
+	return i.Lo == 0 && i.Hi == 0
+}
+
+// Cmp compares i and v and returns -1, 0 or +1
+func (i Uint128) Cmp(v Uint128) int {
+	switch {
+	case i.Hi < v.Hi || (i.Hi == v.Hi && i.Lo < v.Lo):
+		return -1
+	case i.Hi == v.Hi && i.Lo == v.Lo:
+		return 0
+	}
+	return 1
+}
+
+// Add returns i + v
+func (i Uint128) Add(v Uint128) (Uint128, error) {
+	lo, carry := bits.Add64(i.Lo, v.Lo, 0)
+	hi, carry := bits.Add64(i.Hi, v.Hi, carry)
+	if carry != 0 {
+		return i.with(0, 0), ErrUint128Overflow
+	}
+	return i.with(hi, lo), nil
+}
+
+// Sub returns i - v
+func (i Uint128) Sub(v Uint128) (Uint128, error) {
+	lo, borrow := bits.Sub64(i.Lo, v.Lo, 0)
+	hi, borrow := bits.Sub64(i.Hi, v.Hi, borrow)
+	if borrow != 0 {
+		return i.with(0, 0), ErrUint128Underflow
+	}
+	return i.with(hi, lo), nil
+}
+
+// Mul returns i * v
+func (i Uint128) Mul(v Uint128) (Uint128, error) {
+	if i.Hi != 0 && v.Hi != 0 {
+		return i.with(0, 0), ErrUint128Overflow
+	}
+	hi, lo := bits.Mul64(i.Lo, v.Lo)
+	p0, cross1 := bits.Mul64(i.Hi, v.Lo)
+	p1, cross2 := bits.Mul64(i.Lo, v.Hi)
+	if p0 != 0 || p1 != 0 {
+		return i.with(0, 0), ErrUint128Overflow
+	}
+	hi, carry := bits.Add64(hi, cross1, 0)
+	if carry != 0 {
+		return i.with(0, 0), ErrUint128Overflow
+	}
+	hi, carry = bits.Add64(hi, cross2, 0)
+	if carry != 0 {
+		return i.with(0, 0), ErrUint128Overflow
+	}
+	return i.with(hi, lo), nil
+}
+
+// Div returns i / v, rounded down
+func (i Uint128) Div(v Uint128) (Uint128, error) {
+	q, _, err := i.QuoRem(v)
+	return q, err
+}
+
+// Mod returns i % v
+func (i Uint128) Mod(v Uint128) (Uint128, error) {
+	_, r, err := i.QuoRem(v)
+	return r, err
+}
+
+// QuoRem returns i / v and i % v
+func (i Uint128) QuoRem(v Uint128) (q, r Uint128, err error) {
+	if v.IsZero() {
+		return i.with(0, 0), i.with(0, 0), ErrUint128DivideByZero
+	}
+	if v.Hi == 0 {
+		// 128 by 64 bits division
+		var hi, lo, rem uint64
+		if i.Hi < v.Lo {
+			lo, rem = bits.Div64(i.Hi, i.Lo, v.Lo)
+		} else {
+			hi, rem = i.Hi/v.Lo, i.Hi%v.Lo
+			lo, rem = bits.Div64(rem, i.Lo, v.Lo)
+		}
+		return i.with(hi, lo), i.with(0, rem), nil
+	}
+	// normalize the divisor so that the estimated quotient is off by at most one
+	n := uint(bits.LeadingZeros64(v.Hi))
+	v1 := v.lsh(n)
+	u1 := i.rsh(1)
+	tq, _ := bits.Div64(u1.Hi, u1.Lo, v1.Hi)
+	tq >>= 63 - n
+	if tq != 0 {
+		tq--
+	}
+	// tq * v fits in 128 bits and is not greater than i
+	hi, lo := bits.Mul64(tq, v.Lo)
+	hi += tq * v.Hi
+	rLo, borrow := bits.Sub64(i.Lo, lo, 0)
+	rHi, _ := bits.Sub64(i.Hi, hi, borrow)
+	r = i.with(rHi, rLo)
+	if r.Cmp(v) >= 0 {
+		tq++
+		r, _ = r.Sub(v)
+	}
+	return i.with(0, tq), r, nil
+}
+
+// with returns a Uint128 with the given limbs and the byte order of i
+func (i Uint128) with(hi, lo uint64) Uint128 {
+	return Uint128{Lo: lo, Hi: hi, Endianness: i.Endianness}
+}
+
+func (i Uint128) lsh(n uint) Uint128 {
+	if n >= 64 {
+		return i.with(i.Lo<<(n-64), 0)
+	}
+	return i.with(i.Hi<<n|i.Lo>>(64-n), i.Lo<<n)
+}
+
+func (i Uint128) rsh(n uint) Uint128 {
+	if n >= 64 {
+		return i.with(0, i.Hi>>(n-64))
+	}
+	return i.with(i.Hi>>n, i.Lo>>n|i.Hi<<(64-n))
+}
+
+// Arithmetic on Int128 uses the two's complement representation of the limbs, Hi holds
+// the sign bit. As for Uint128, operations never wrap and an overflow returns ErrInt128Overflow.
+
+var ErrInt128Overflow = errors.New("int128 overflow")
+
+var (
+	minInt128 = new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 127))
+	maxInt128 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 127), big.NewInt(1))
+)
+
+// Int128FromInt64 creates an Int128 from v, with the default byte order
+func Int128FromInt64(v int64) Int128 {
+	return Int128{Lo: uint64(v), Hi: uint64(v >> 63)}
+}
+
+// Int128FromBigInt creates an Int128 from v, with the default byte order.
+// It fails if v is out of the signed 128 bits range
+func Int128FromBigInt(v *big.Int) (Int128, error) {
+	if v.Cmp(minInt128) < 0 || v.Cmp(maxInt128) > 0 {
+		return Int128{}, fmt.Errorf("%w: %s", ErrInt128Overflow, v)
+	}
+	// two's complement: v + 2^128 when negative
+	buf := new(big.Int).Add(v, new(big.Int).Lsh(big.NewInt(1), 128)).FillBytes(make([]byte, 17))
+	return Int128{Hi: binary.BigEndian.Uint64(buf[1:9]), Lo: binary.BigEndian.Uint64(buf[9:])}, nil
+}
+
+// IsNegative reports whether i < 0
+func (i Int128) IsNegative() bool {
+	return int64(i.Hi) < 0
+}
+
+// Cmp compares i and v and returns -1, 0 or +1
+func (i Int128) Cmp(v Int128) int {
+	switch {
+	case int64(i.Hi) < int64(v.Hi) || (i.Hi == v.Hi && i.Lo < v.Lo):
+		return -1
+	case i.Hi == v.Hi && i.Lo == v.Lo:
+		return 0
+	}
+	return 1
+}
+
+// Add returns i + v
+func (i Int128) Add(v Int128) (Int128, error) {
+	lo, carry := bits.Add64(i.Lo, v.Lo, 0)
+	hi, _ := bits.Add64(i.Hi, v.Hi, carry)
+	// the operands have the same sign and the result the other one
+	if (i.Hi^hi)&(v.Hi^hi)>>63 != 0 {
+		return i.with(0, 0), ErrInt128Overflow
+	}
+	return i.with(hi, lo), nil
+}
+
+// Sub returns i - v
+func (i Int128) Sub(v Int128) (Int128, error) {
+	lo, borrow := bits.Sub64(i.Lo, v.Lo, 0)
+	hi, _ := bits.Sub64(i.Hi, v.Hi, borrow)
+	// the operands have different signs and the result the sign of v
+	if (i.Hi^v.Hi)&(i.Hi^hi)>>63 != 0 {
+		return i.with(0, 0), ErrInt128Overflow
+	}
+	return i.with(hi, lo), nil
+}
+
+// Neg returns -i, it fails for the minimum Int128 whose opposite is out of range
+func (i Int128) Neg() (Int128, error) {
+	return i.with(0, 0).Sub(i)
+}
+
+// with returns an Int128 with the given limbs and the byte order of i
+func (i Int128) with(hi, lo uint64) Int128 {
+	return Int128{Lo: lo, Hi: hi, Endianness: i.Endianness}
+}
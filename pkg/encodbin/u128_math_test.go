@@ -0,0 +1,184 @@
+package encodbin
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func randUint128(r *rand.Rand) Uint128 {
+	v := Uint128{Lo: r.Uint64(), Hi: r.Uint64(), Endianness: binary.LittleEndian}
+	// mix small values to hit the 64 bits paths and the limb boundary
+	switch r.Intn(4) {
+	case 0:
+		v.Hi = 0
+	case 1:
+		v.Hi, v.Lo = r.Uint64()%4, math.MaxUint64-r.Uint64()%4
+	case 2:
+		v.Hi >>= uint(r.Intn(64))
+	}
+	return v
+}
+
+func TestUint128Arithmetic(t *testing.T) {
+	var (
+		r       = rand.New(rand.NewSource(1))
+		max128  = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+		checked = func(op string, a, b Uint128, got Uint128, err error, want *big.Int, wantErr error) {
+			if wantErr == nil && (want.Sign() < 0 || want.Cmp(max128) > 0) {
+				wantErr = ErrUint128Overflow
+				if want.Sign() < 0 {
+					wantErr = ErrUint128Underflow
+				}
+			}
+			if wantErr != nil {
+				if !errors.Is(err, wantErr) {
+					t.Errorf("%s %s %s Err ==> Got %v, Want: %v", a, op, b, err, wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("%s %s %s Failed: %s", a, op, b, err)
+				return
+			}
+			if got.BigInt().Cmp(want) != 0 {
+				t.Errorf("%s %s %s Err ==> Got %s, Want: %s", a, op, b, got, want)
+			}
+			if got.Endianness != a.Endianness {
+				t.Errorf("%s %s %s Endianness not preserved", a, op, b)
+			}
+		}
+	)
+	for n := 0; n < 5000; n++ {
+		a, b := randUint128(r), randUint128(r)
+		x, y := a.BigInt(), b.BigInt()
+
+		got, err := a.Add(b)
+		checked("+", a, b, got, err, new(big.Int).Add(x, y), nil)
+		got, err = a.Sub(b)
+		checked("-", a, b, got, err, new(big.Int).Sub(x, y), nil)
+		got, err = a.Mul(b)
+		checked("*", a, b, got, err, new(big.Int).Mul(x, y), nil)
+		if y.Sign() == 0 {
+			continue
+		}
+		got, err = a.Div(b)
+		checked("/", a, b, got, err, new(big.Int).Quo(x, y), nil)
+		got, err = a.Mod(b)
+		checked("%", a, b, got, err, new(big.Int).Rem(x, y), nil)
+		if a.Cmp(b) != x.Cmp(y) {
+			t.Errorf("Cmp(%s, %s) Err ==> Got %d, Want: %d", a, b, a.Cmp(b), x.Cmp(y))
+		}
+	}
+
+	// carry and borrow across the 64 bits boundary
+	maxLo := NewUint128(0, math.MaxUint64)
+	one := NewUint128(0, 1)
+	if sum, _ := maxLo.Add(one); sum.Hi != 1 || sum.Lo != 0 {
+		t.Errorf("Add carry Err ==> Got %+v", sum)
+	}
+	if diff, _ := NewUint128(1, 0).Sub(one); diff.Hi != 0 || diff.Lo != math.MaxUint64 {
+		t.Errorf("Sub borrow Err ==> Got %+v", diff)
+	}
+	if _, err := one.Div(Uint128{}); !errors.Is(err, ErrUint128DivideByZero) {
+		t.Errorf("Div Err ==> Got %v, Want: %v", err, ErrUint128DivideByZero)
+	}
+	if _, err := NewUint128(math.MaxUint64, math.MaxUint64).Add(one); !errors.Is(err, ErrUint128Overflow) {
+		t.Errorf("Add Err ==> Got %v, Want: %v", err, ErrUint128Overflow)
+	}
+}
+
+func TestInt128Arithmetic(t *testing.T) {
+	var (
+		one      = big.NewInt(1)
+		minInt64 = big.NewInt(math.MinInt64)
+		values   = []*big.Int{
+			big.NewInt(0), big.NewInt(1), big.NewInt(-1), big.NewInt(math.MaxInt64),
+			minInt64, new(big.Int).Sub(minInt64, one), new(big.Int).Add(minInt64, one),
+			new(big.Int).Lsh(one, 64), new(big.Int).Neg(new(big.Int).Lsh(one, 64)),
+			minInt128, maxInt128, new(big.Int).Add(minInt128, one), new(big.Int).Sub(maxInt128, one),
+		}
+		checked = func(op string, a, b Int128, got Int128, err error, want *big.Int) {
+			if want.Cmp(minInt128) < 0 || want.Cmp(maxInt128) > 0 {
+				if !errors.Is(err, ErrInt128Overflow) {
+					t.Errorf("%s %s %s Err ==> Got %v, Want: %v", a.BigInt(), op, b.BigInt(), err, ErrInt128Overflow)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("%s %s %s Failed: %s", a.BigInt(), op, b.BigInt(), err)
+				return
+			}
+			if got.BigInt().Cmp(want) != 0 {
+				t.Errorf("%s %s %s Err ==> Got %s, Want: %s", a.BigInt(), op, b.BigInt(), got.BigInt(), want)
+			}
+		}
+	)
+	for _, x := range values {
+		a, err := Int128FromBigInt(x)
+		if err != nil {
+			t.Fatalf("Int128FromBigInt(%s) Failed: %s", x, err)
+		}
+		if a.BigInt().Cmp(x) != 0 || a.IsNegative() != (x.Sign() < 0) {
+			t.Errorf("Int128FromBigInt Err ==> Got %s, Want: %s", a.BigInt(), x)
+		}
+		if x.IsInt64() && a != Int128FromInt64(x.Int64()) {
+			t.Errorf("Int128FromInt64 Err ==> Got %+v, Want: %+v", Int128FromInt64(x.Int64()), a)
+		}
+		got, err := a.Neg()
+		checked("neg", Int128{}, a, got, err, new(big.Int).Neg(x))
+		for _, y := range values {
+			b, _ := Int128FromBigInt(y)
+			got, err = a.Add(b)
+			checked("+", a, b, got, err, new(big.Int).Add(x, y))
+			got, err = a.Sub(b)
+			checked("-", a, b, got, err, new(big.Int).Sub(x, y))
+			if a.Cmp(b) != x.Cmp(y) {
+				t.Errorf("Cmp(%s, %s) Err ==> Got %d, Want: %d", x, y, a.Cmp(b), x.Cmp(y))
+			}
+		}
+	}
+	for _, v := range []*big.Int{new(big.Int).Sub(minInt128, one), new(big.Int).Add(maxInt128, one), new(big.Int).Lsh(one, 200)} {
+		if _, err := Int128FromBigInt(v); !errors.Is(err, ErrInt128Overflow) {
+			t.Errorf("Int128FromBigInt(%s) Err ==> Got %v, Want: %v", v, err, ErrInt128Overflow)
+		}
+	}
+}
+
+func TestInt128Encoding(t *testing.T) {
+	minusOne := bytes.Repeat([]byte{0xff}, 16)
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		for _, x := range []*big.Int{big.NewInt(-1), big.NewInt(math.MinInt64), big.NewInt(-2), minInt128, maxInt128} {
+			v, _ := Int128FromBigInt(x)
+			v.Endianness = order
+			buf := new(bytes.Buffer)
+			if err := v.MarshalWithEncoder(NewBinEncoder(buf)); err != nil {
+				t.Fatalf("MarshalWithEncoder Failed: %s", err)
+			}
+			if x.Cmp(big.NewInt(-1)) == 0 && !bytes.Equal(buf.Bytes(), minusOne) {
+				t.Errorf("MarshalWithEncoder(-1) Err ==> Got %x, Want: %x", buf.Bytes(), minusOne)
+			}
+			got := Int128{Endianness: order}
+			if err := got.UnmarshalWithDecoder(NewBinDecoder(buf.Bytes())); err != nil {
+				t.Fatalf("UnmarshalWithDecoder Failed: %s", err)
+			}
+			if got.BigInt().Cmp(x) != 0 {
+				t.Errorf("%v round trip Err ==> Got %s, Want: %s", order, got.BigInt(), x)
+			}
+		}
+	}
+	// the sign bit is the last byte in little endian and the first one in big endian
+	data := append(make([]byte, 15), 0x80)
+	le, _ := NewBinDecoder(data).ReadInt128(binary.LittleEndian)
+	if le.BigInt().Cmp(minInt128) != 0 {
+		t.Errorf("ReadInt128 little endian Err ==> Got %s, Want: %s", le.BigInt(), minInt128)
+	}
+	be, _ := NewBinDecoder(data).ReadInt128(binary.BigEndian)
+	if be.BigInt().Cmp(big.NewInt(128)) != 0 {
+		t.Errorf("ReadInt128 big endian Err ==> Got %s, Want: %d", be.BigInt(), 128)
+	}
+}
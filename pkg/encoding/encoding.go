@@ -0,0 +1,95 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+// Package encoding wraps the base58 and base64 codecs used for addresses, signatures and
+// account data, the decode functions always report malformed inputs
+package encoding
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/mr-tron/base58"
+)
+
+// ErrEmptyInput returned when decoding an empty string
+var ErrEmptyInput = errors.New("empty input")
+
+// EncodeBase58 encodes the bytes to a base58 string
+func EncodeBase58(b []byte) string {
+	return base58.Encode(b)
+}
+
+// DecodeBase58 decodes a base58 string
+func DecodeBase58(s string) ([]byte, error) {
+	if s == "" {
+		return nil, ErrEmptyInput
+	}
+	b, err := base58.Decode(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base58 %q: %w", s, err)
+	}
+	return b, nil
+}
+
+// EncodeBase64 encodes the bytes to a standard base64 string
+func EncodeBase64(b []byte) string {
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// DecodeBase64 decodes a standard base64 string
+func DecodeBase64(s string) ([]byte, error) {
+	if s == "" {
+		return nil, ErrEmptyInput
+	}
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64 %q: %w", s, err)
+	}
+	return b, nil
+}
+
+// EncodeBase58Batch encodes every element of the list to base58
+func EncodeBase58Batch(list [][]byte) []string {
+	out := make([]string, len(list))
+	for i, b := range list {
+		out[i] = EncodeBase58(b)
+	}
+	return out
+}
+
+// DecodeBase58Batch decodes every element of the list, it fails on the first malformed element
+func DecodeBase58Batch(list []string) ([][]byte, error) {
+	out := make([][]byte, len(list))
+	for i, s := range list {
+		b, err := DecodeBase58(s)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		out[i] = b
+	}
+	return out, nil
+}
+
+// EncodeBase64Batch encodes every element of the list to base64
+func EncodeBase64Batch(list [][]byte) []string {
+	out := make([]string, len(list))
+	for i, b := range list {
+		out[i] = EncodeBase64(b)
+	}
+	return out
+}
+
+// DecodeBase64Batch decodes every element of the list, it fails on the first malformed element
+func DecodeBase64Batch(list []string) ([][]byte, error) {
+	out := make([][]byte, len(list))
+	for i, s := range list {
+		b, err := DecodeBase64(s)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		out[i] = b
+	}
+	return out, nil
+}
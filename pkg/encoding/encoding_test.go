@@ -0,0 +1,68 @@
+package encoding
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestBase58(t *testing.T) {
+	const address = "EfgnVEwyeeFLZyZ4nnnzZtqV6B3DhdtXFNsGSzdti9ZN"
+	b, err := DecodeBase58(address)
+	if err != nil {
+		t.Fatalf("DecodeBase58 Failed: %s", err)
+	}
+	if len(b) != 32 || EncodeBase58(b) != address {
+		t.Errorf("Base58 Err ==> Got %s, Want: %s", EncodeBase58(b), address)
+	}
+	// leading zeros are encoded as '1'
+	if got := EncodeBase58([]byte{0, 0, 1}); got != "112" {
+		t.Errorf("EncodeBase58 Err ==> Got %s, Want: 112", got)
+	}
+	for _, malformed := range []string{"0OIl", "abc+", "EfgnVEwyeeFLZyZ4 nnnzZtqV6B3DhdtXFNsGSzdti9ZN"} {
+		if _, err = DecodeBase58(malformed); err == nil {
+			t.Errorf("DecodeBase58(%q) Err ==> Got nil, Want: invalid base58", malformed)
+		}
+	}
+	if _, err = DecodeBase58(""); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("DecodeBase58 Err ==> Got %v, Want: %s", err, ErrEmptyInput)
+	}
+}
+
+func TestBase64(t *testing.T) {
+	raw := []byte{1, 2, 3, 250, 251, 252}
+	b, err := DecodeBase64(EncodeBase64(raw))
+	if err != nil || !bytes.Equal(b, raw) {
+		t.Errorf("Base64 Err ==> Got %v %v, Want: %v", b, err, raw)
+	}
+	for _, malformed := range []string{"AQID+", "AQ=ID", "AQ==AQ==", "#"} {
+		if _, err = DecodeBase64(malformed); err == nil {
+			t.Errorf("DecodeBase64(%q) Err ==> Got nil, Want: invalid base64", malformed)
+		}
+	}
+	if _, err = DecodeBase64(""); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("DecodeBase64 Err ==> Got %v, Want: %s", err, ErrEmptyInput)
+	}
+}
+
+func TestBatch(t *testing.T) {
+	list := [][]byte{{1}, {2, 3}, {0, 4, 5, 6}}
+	decoded, err := DecodeBase58Batch(EncodeBase58Batch(list))
+	if err != nil || len(decoded) != len(list) {
+		t.Fatalf("Base58Batch Failed: %v", err)
+	}
+	for i := range list {
+		if !bytes.Equal(decoded[i], list[i]) {
+			t.Errorf("Base58Batch[%d] Err ==> Got %v, Want: %v", i, decoded[i], list[i])
+		}
+	}
+	if decoded, err = DecodeBase64Batch(EncodeBase64Batch(list)); err != nil || !bytes.Equal(decoded[2], list[2]) {
+		t.Errorf("Base64Batch Err ==> Got %v %v", decoded, err)
+	}
+	if _, err = DecodeBase58Batch([]string{"2", "0OIl"}); err == nil || err.Error()[:9] != "element 1" {
+		t.Errorf("DecodeBase58Batch Err ==> Got %v, Want: element 1 error", err)
+	}
+	if _, err = DecodeBase64Batch([]string{"AQ==", ""}); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("DecodeBase64Batch Err ==> Got %v, Want: %s", err, ErrEmptyInput)
+	}
+}
@@ -35,6 +35,8 @@ var (
 	ErrNoResult                  = errors.New("JSON-RPC response has no result")
 	ErrMissingBatchResponse      = errors.New("response batch did not contain a response to this call")
 	ErrSubscriptionQueueOverflow = errors.New("subscription queue overflow")
+	ErrSubscribeTimeout          = errors.New("subscribe request timed out")
+	ErrStreamNotSupported        = errors.New("streaming calls are only supported over HTTP")
 	errClientReconnected         = errors.New("client reconnected")
 	errDead                      = errors.New("connection lost")
 )
@@ -42,7 +44,7 @@ var (
 // Timeouts
 const (
 	defaultDialTimeout = 10 * time.Second // used if context has no deadline
-	subscribeTimeout   = 10 * time.Second // overall timeout eth_subscribe, rpc_modules calls
+	subscribeTimeout   = 10 * time.Second // overall timeout *Subscribe, rpc_modules calls, used if context has no deadline
 )
 
 const (
@@ -88,6 +90,9 @@ type Client struct {
 	// config fields
 	batchItemLimit       int
 	batchResponseMaxSize int
+	subscribeTimeout     time.Duration
+	callTimeout          time.Duration // used if the call context has no deadline, 0 = no timeout
+	wsReadLimit          *atomic.Int64 // nil for non websocket connections
 
 	// writeConn is used for writing to the connection on the caller's goroutine. It should
 	// only be accessed outside of dispatch, with the write lock held. The write lock is
@@ -175,12 +180,13 @@ func Dial(rawurl string) (*Client, error) {
 	return DialOptions(context.Background(), rawurl)
 }
 
-// DialContext creates a new RPC client, just like Dial.
+// DialContext creates a new RPC client, just like Dial. You can supply any of the
+// pre-defined client options to configure the underlying transport.
 //
 // The context is used to cancel or time out the initial connection establishment. It does
 // not affect subsequent interactions with the client.
-func DialContext(ctx context.Context, rawurl string) (*Client, error) {
-	return DialOptions(ctx, rawurl)
+func DialContext(ctx context.Context, rawurl string, options ...ClientOption) (*Client, error) {
+	return DialOptions(ctx, rawurl, options...)
 }
 
 // DialOptions creates a new RPC client for the given URL. You can supply any of the
@@ -243,6 +249,9 @@ func initClient(conn ServerCodec, services *serviceRegistry, cfg *clientConfig)
 		idgen:                cfg.idgen,
 		batchItemLimit:       cfg.batchItemLimit,
 		batchResponseMaxSize: cfg.batchResponseLimit,
+		subscribeTimeout:     cfg.subscribeTimeout,
+		callTimeout:          cfg.callTimeout,
+		wsReadLimit:          cfg.wsReadLimit,
 		writeConn:            conn,
 		close:                make(chan struct{}),
 		closing:              make(chan struct{}),
@@ -259,6 +268,9 @@ func initClient(conn ServerCodec, services *serviceRegistry, cfg *clientConfig)
 	if c.idgen == nil {
 		c.idgen = randomIDGenerator()
 	}
+	if c.subscribeTimeout == 0 {
+		c.subscribeTimeout = subscribeTimeout
+	}
 
 	// Launch the main loop.
 	if !isHTTP {
@@ -338,6 +350,8 @@ func (c *Client) CallContext(ctx context.Context, result interface{}, method str
 	if err != nil {
 		return err
 	}
+	ctx, cancel := c.withCallTimeout(ctx)
+	defer cancel()
 	op := &requestOp{
 		ids:  []json.RawMessage{msg.ID},
 		resp: make(chan []*jsonrpcMessage, 1),
@@ -371,6 +385,18 @@ func (c *Client) CallContext(ctx context.Context, result interface{}, method str
 	}
 }
 
+// withCallTimeout derives a context bounded by the default call timeout
+// when one is configured and ctx has no deadline.
+func (c *Client) withCallTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.callTimeout <= 0 {
+		return ctx, func() {}
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, c.callTimeout)
+}
+
 // BatchCall sends all given requests as a single batch and waits for the server
 // to return a response for all of them.
 //
@@ -393,6 +419,8 @@ func (c *Client) BatchCall(b []BatchElem) error {
 //
 // Note that batch calls may not be executed atomically on the server side.
 func (c *Client) BatchCallContext(ctx context.Context, b []BatchElem) error {
+	ctx, cancel := c.withCallTimeout(ctx)
+	defer cancel()
 	var (
 		msgs = make([]*jsonrpcMessage, len(b))
 		byID = make(map[string]int, len(b))
@@ -482,8 +510,12 @@ func (c *Client) Notify(ctx context.Context, method string, args ...interface{})
 // sent to the given channel. The element type of the channel must match the
 // expected type of content returned by the subscription.
 //
-// The context argument cancels the RPC request that sets up the subscription but has no
-// effect on the subscription after Subscribe has returned.
+// The context argument cancels the RPC request that sets up the subscription. Once Subscribe
+// has returned, canceling the context unsubscribes as Unsubscribe does: the subscription is
+// removed on the server, the forwarding goroutine exits and the Err channel is closed. The
+// notification channel is owned by the caller and is never closed. If the context has no deadline,
+// waiting for the subscription id is bounded by the subscribe timeout (see
+// WithSubscribeTimeout) and ErrSubscribeTimeout is returned when it expires.
 //
 // Slow subscribers will be dropped eventually. Client buffers up to 20000 notifications
 // before considering the subscriber dead. The subscription Err channel will receive
@@ -502,6 +534,14 @@ func (c *Client) Subscribe(ctx context.Context, namespace string, channel interf
 		return nil, ErrNotificationsUnsupported
 	}
 
+	// Bound the subscribe handshake if the caller did not.
+	timeoutCtx := ctx
+	if _, ok := ctx.Deadline(); !ok && c.subscribeTimeout > 0 {
+		var cancel context.CancelFunc
+		timeoutCtx, cancel = context.WithTimeout(ctx, c.subscribeTimeout)
+		defer cancel()
+	}
+
 	msg, err := c.newMessage(namespace+subscribeMethodSuffix, args...)
 
 	if err != nil {
@@ -515,15 +555,32 @@ func (c *Client) Subscribe(ctx context.Context, namespace string, channel interf
 
 	// Send the subscription request.
 	// The arrival and validity of the response is signaled on sub.quit.
-	if err := c.send(ctx, op, msg); err != nil {
+	if err := c.send(timeoutCtx, op, msg); err != nil {
 		return nil, err
 	}
-	if _, err := op.wait(ctx, c); err != nil {
+	if _, err := op.wait(timeoutCtx, c); err != nil {
+		// the subscribe timeout expired, not the caller's context
+		if ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+			return nil, fmt.Errorf("%w: %s", ErrSubscribeTimeout, msg.Method)
+		}
 		return nil, err
 	}
+	if ctx.Done() != nil {
+		go op.sub.unsubscribeOnDone(ctx)
+	}
 	return op.sub, nil
 }
 
+// SetWSReadLimit changes the maximum size in bytes of a message read from the websocket
+// connection, 0 means no limit. Messages over the limit close the connection and drop
+// the active subscriptions. The new limit applies from the next message and is kept
+// across reconnects. It has no effect on HTTP clients.
+func (c *Client) SetWSReadLimit(limit int64) {
+	if c.wsReadLimit != nil {
+		c.wsReadLimit.Store(limit)
+	}
+}
+
 // SupportsSubscriptions reports whether subscriptions are supported by the client
 // transport. When this returns false, Subscribe and related methods will return
 // ErrNotificationsUnsupported.
@@ -20,6 +20,8 @@ package rpc
 import (
 	"github.com/gorilla/websocket"
 	"net/http"
+	"sync/atomic"
+	"time"
 )
 
 // ClientOption is a configuration option for the RPC client.
@@ -29,18 +31,26 @@ type ClientOption interface {
 
 type clientConfig struct {
 	// HTTP settings
-	httpClient  *http.Client
-	httpHeaders http.Header
-	httpAuth    HTTPAuth
+	httpClient          *http.Client
+	httpTransport       http.RoundTripper
+	httpMaxIdleConns    int
+	httpIdleConnTimeout time.Duration
+	httpHeaders         http.Header
+	httpAuth            HTTPAuth
 
 	// WebSocket options
 	wsDialer           *websocket.Dialer
 	wsMessageSizeLimit *int64 // wsMessageSizeLimit nil = default, 0 = no limit
+	wsReadBufferSize   int
+	wsWriteBufferSize  int
+	wsReadLimit        *atomic.Int64 // shared with the Client, set by the websocket transport
 
 	// RPC handler options
 	idgen              func() ID
 	batchItemLimit     int
 	batchResponseLimit int
+	subscribeTimeout   time.Duration
+	callTimeout        time.Duration
 }
 
 func (cfg *clientConfig) initHeaders() {
@@ -60,20 +70,30 @@ func (fn optionFunc) applyOption(opt *clientConfig) {
 	fn(opt)
 }
 
-//// WithWebsocketDialer configures the websocket.Dialer used by the RPC client.
-//func WithWebsocketDialer(dialer websocket.Dialer) ClientOption {
-//	return optionFunc(func(cfg *clientConfig) {
-//		cfg.wsDialer = &dialer
-//	})
-//}
-//
-//// WithWebsocketMessageSizeLimit configures the websocket message size limit used by the RPC
-//// client. Passing a limit of 0 means no limit.
-//func WithWebsocketMessageSizeLimit(messageSizeLimit int64) ClientOption {
-//	return optionFunc(func(cfg *clientConfig) {
-//		cfg.wsMessageSizeLimit = &messageSizeLimit
-//	})
-//}
+// WithWebsocketDialer configures the websocket.Dialer used by the RPC client.
+func WithWebsocketDialer(dialer websocket.Dialer) ClientOption {
+	return optionFunc(func(cfg *clientConfig) {
+		cfg.wsDialer = &dialer
+	})
+}
+
+// WithWebsocketMessageSizeLimit configures the websocket message size limit used by the RPC
+// client. Passing a limit of 0 means no limit. The default is 32 MB, which fits full
+// block notifications.
+func WithWebsocketMessageSizeLimit(messageSizeLimit int64) ClientOption {
+	return optionFunc(func(cfg *clientConfig) {
+		cfg.wsMessageSizeLimit = &messageSizeLimit
+	})
+}
+
+// WithWebsocketBufferSizes configures the I/O buffer sizes of the default websocket
+// dialer, zero keeps the default of 1024 bytes. It has no effect with WithWebsocketDialer.
+func WithWebsocketBufferSizes(readBufferSize, writeBufferSize int) ClientOption {
+	return optionFunc(func(cfg *clientConfig) {
+		cfg.wsReadBufferSize = readBufferSize
+		cfg.wsWriteBufferSize = writeBufferSize
+	})
+}
 
 // WithHeader configures HTTP headers set by the RPC client. Headers set using this option
 // will be used for both HTTP and WebSocket connections.
@@ -95,13 +115,44 @@ func WithHeaders(headers http.Header) ClientOption {
 	})
 }
 
+// WithHTTPHeader configures an HTTP header set by the RPC client, typically an API key
+// required by the RPC provider. It is equivalent to WithHeader.
+func WithHTTPHeader(key, value string) ClientOption {
+	return WithHeader(key, value)
+}
+
 // WithHTTPClient configures the http.Client used by the RPC client.
+// When set, the transport options below are ignored.
 func WithHTTPClient(c *http.Client) ClientOption {
 	return optionFunc(func(cfg *clientConfig) {
 		cfg.httpClient = c
 	})
 }
 
+// WithHTTPTransport configures the transport used by the RPC client. Passing the same
+// transport to several clients lets them share the idle connection pool.
+func WithHTTPTransport(transport http.RoundTripper) ClientOption {
+	return optionFunc(func(cfg *clientConfig) {
+		cfg.httpTransport = transport
+	})
+}
+
+// WithMaxIdleConns changes the maximum number of idle (keep-alive) connections
+// kept by the RPC client, both in total and per host.
+func WithMaxIdleConns(n int) ClientOption {
+	return optionFunc(func(cfg *clientConfig) {
+		cfg.httpMaxIdleConns = n
+	})
+}
+
+// WithIdleConnTimeout changes how long an idle (keep-alive) connection remains
+// open before closing itself.
+func WithIdleConnTimeout(timeout time.Duration) ClientOption {
+	return optionFunc(func(cfg *clientConfig) {
+		cfg.httpIdleConnTimeout = timeout
+	})
+}
+
 // WithHTTPAuth configures HTTP request authentication. The given provider will be called
 // whenever a request is made. Note that only one authentication provider can be active at
 // any time.
@@ -142,3 +193,20 @@ func WithBatchResponseSizeLimit(sizeLimit int) ClientOption {
 		cfg.batchResponseLimit = sizeLimit
 	})
 }
+
+// WithSubscribeTimeout changes how long Subscribe waits for the server to confirm a
+// subscription when the given context has no deadline. The default is 10 seconds.
+func WithSubscribeTimeout(timeout time.Duration) ClientOption {
+	return optionFunc(func(cfg *clientConfig) {
+		cfg.subscribeTimeout = timeout
+	})
+}
+
+// WithDefaultCallTimeout bounds CallContext and BatchCallContext by the timeout when the
+// given context has no deadline, so a hung endpoint cannot block forever. A deadline set
+// on the context of a call always takes precedence. The default 0 means no timeout.
+func WithDefaultCallTimeout(timeout time.Duration) ClientOption {
+	return optionFunc(func(cfg *clientConfig) {
+		cfg.callTimeout = timeout
+	})
+}
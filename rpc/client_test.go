@@ -0,0 +1,329 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// newSilentWsServer accept websocket connections, read the requests and never reply
+func newSilentWsServer(t *testing.T) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade websocket failed: %s", err)
+			return
+		}
+		defer conn.Close()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+}
+
+// newNotifyWsServer confirms every subscription and then pushes a notification
+// whose result is a string of the given size
+func newNotifyWsServer(t *testing.T, size int) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade websocket failed: %s", err)
+			return
+		}
+		defer conn.Close()
+		for {
+			var req jsonrpcMessage
+			if err := conn.ReadJSON(&req); err != nil {
+				return
+			}
+			_ = conn.WriteJSON(map[string]interface{}{"jsonrpc": vsn, "id": req.ID, "result": 7})
+			_ = conn.WriteJSON(map[string]interface{}{
+				"jsonrpc": vsn,
+				"method":  "slotNotification",
+				"params":  map[string]interface{}{"subscription": 7, "result": strings.Repeat("a", size)},
+			})
+		}
+	}))
+}
+
+func TestSetWSReadLimit(t *testing.T) {
+	const size = 64 * 1024
+	srv := newNotifyWsServer(t, size)
+	defer srv.Close()
+
+	wsUrl := "ws" + strings.TrimPrefix(srv.URL, "http")
+	client, err := DialOptions(context.Background(), wsUrl, WithWebsocketBufferSizes(4096, 4096))
+	if err != nil {
+		t.Fatalf("Dial websocket failed: %s", err)
+	}
+	defer client.Close()
+
+	// under the limit
+	client.SetWSReadLimit(2 * size)
+	ch := make(chan json.RawMessage, 1)
+	sub, err := client.Subscribe(context.Background(), "slot", ch)
+	if err != nil {
+		t.Fatalf("Subscribe Failed: %s", err)
+	}
+	select {
+	case msg := <-ch:
+		if len(msg) != size+2 {
+			t.Errorf("notification size Err ==> Got %d, Want: %d", len(msg), size+2)
+		}
+	case err := <-sub.Err():
+		t.Fatalf("subscription Failed: %v", err)
+	case <-time.After(2 * time.Second):
+		t.Fatalf("notification under the limit was not received")
+	}
+	sub.Unsubscribe()
+
+	// over the limit
+	client.SetWSReadLimit(size / 2)
+	ch = make(chan json.RawMessage, 1)
+	sub, err = client.Subscribe(context.Background(), "slot", ch)
+	if err != nil {
+		t.Fatalf("Subscribe Failed: %s", err)
+	}
+	select {
+	case <-ch:
+		t.Errorf("notification over the limit should be dropped")
+	case err := <-sub.Err():
+		if err == nil {
+			t.Errorf("subscription Err ==> Got nil, Want: read limit error")
+		}
+	case <-time.After(2 * time.Second):
+		t.Errorf("subscription over the limit was not dropped")
+	}
+}
+
+func TestSubscribeTimeout(t *testing.T) {
+	srv := newSilentWsServer(t)
+	defer srv.Close()
+
+	wsUrl := "ws" + strings.TrimPrefix(srv.URL, "http")
+	client, err := DialOptions(context.Background(), wsUrl, WithSubscribeTimeout(200*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Dial websocket failed: %s", err)
+	}
+	defer client.Close()
+
+	start := time.Now()
+	_, err = client.Subscribe(context.Background(), "slot", make(chan interface{}))
+	if !errors.Is(err, ErrSubscribeTimeout) {
+		t.Fatalf("Subscribe Err ==> Got %v, Want: %v", err, ErrSubscribeTimeout)
+	}
+	if !strings.Contains(err.Error(), "slotSubscribe") {
+		t.Errorf("Subscribe Err should mention the method. Got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Subscribe timeout took too long: %s", elapsed)
+	}
+
+	// the caller's deadline wins
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = client.Subscribe(ctx, "slot", make(chan interface{}))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Subscribe Err ==> Got %v, Want: %v", err, context.DeadlineExceeded)
+	}
+}
+
+type countingTransport struct {
+	count int
+}
+
+func (ct *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ct.count++
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestHTTPHeaderOption(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if got := r.Header.Get("x-api-key"); got != "secret" {
+			t.Errorf("x-api-key header Err ==> Got %q, Want: %q", got, "secret")
+		}
+		_, _ = io.ReadAll(r.Body)
+		w.Header().Set("content-type", contentType)
+		_, _ = io.WriteString(w, `{"jsonrpc":"2.0","id":1,"result":"ok"}`)
+	}))
+	defer srv.Close()
+
+	transport := new(countingTransport)
+	client, err := DialContext(context.Background(), srv.URL, WithHTTPHeader("x-api-key", "secret"), WithHTTPTransport(transport))
+	if err != nil {
+		t.Fatalf("Dial http failed: %s", err)
+	}
+	defer client.Close()
+
+	for i := 0; i < 3; i++ {
+		var res string
+		if err = client.CallContext(context.Background(), &res, "getHealth"); err != nil {
+			t.Fatalf("CallContext Failed: %s", err)
+		}
+	}
+	if calls != 3 {
+		t.Errorf("server calls Err ==> Got %d, Want: %d", calls, 3)
+	}
+	if transport.count != 3 {
+		t.Errorf("transport calls Err ==> Got %d, Want: %d", transport.count, 3)
+	}
+}
+
+func TestDefaultCallTimeout(t *testing.T) {
+	// sleeping server, never replies in time
+	done := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer srv.Close()
+	defer close(done)
+
+	client, err := DialOptions(context.Background(), srv.URL, WithDefaultCallTimeout(200*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Dial http failed: %s", err)
+	}
+	defer client.Close()
+
+	start := time.Now()
+	err = client.CallContext(context.Background(), nil, "getSlot")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("CallContext Err ==> Got %v, Want: %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond || elapsed > 2*time.Second {
+		t.Errorf("CallContext timeout Err ==> Got %s, Want: 200ms", elapsed)
+	}
+
+	err = client.BatchCallContext(context.Background(), []BatchElem{{Method: "getSlot", Result: new(uint64)}})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("BatchCallContext Err ==> Got %v, Want: %v", err, context.DeadlineExceeded)
+	}
+
+	// the caller's deadline wins
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	err = client.CallContext(ctx, nil, "getSlot")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("CallContext Err ==> Got %v, Want: %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Errorf("CallContext deadline Err ==> Got %s, Want: 50ms", elapsed)
+	}
+}
+
+// newRecordingWsServer confirms every subscription, pushes a notification and sends the
+// requested methods to the methods channel
+func newRecordingWsServer(t *testing.T, methods chan<- string) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade websocket failed: %s", err)
+			return
+		}
+		defer conn.Close()
+		for {
+			var req jsonrpcMessage
+			if err := conn.ReadJSON(&req); err != nil {
+				return
+			}
+			methods <- req.Method
+			if strings.HasSuffix(req.Method, unsubscribeMethodSuffix) {
+				_ = conn.WriteJSON(map[string]interface{}{"jsonrpc": vsn, "id": req.ID, "result": true})
+				continue
+			}
+			_ = conn.WriteJSON(map[string]interface{}{"jsonrpc": vsn, "id": req.ID, "result": 7})
+			_ = conn.WriteJSON(map[string]interface{}{
+				"jsonrpc": vsn,
+				"method":  "slotNotification",
+				"params":  map[string]interface{}{"subscription": 7, "result": 1},
+			})
+		}
+	}))
+}
+
+func TestSubscribeContextCancel(t *testing.T) {
+	methods := make(chan string, 4)
+	srv := newRecordingWsServer(t, methods)
+	defer srv.Close()
+
+	client, err := DialContext(context.Background(), "ws"+strings.TrimPrefix(srv.URL, "http"))
+	if err != nil {
+		t.Fatalf("Dial websocket failed: %s", err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan json.RawMessage, 1)
+	sub, err := client.Subscribe(ctx, "slot", ch)
+	if err != nil {
+		t.Fatalf("Subscribe Failed: %s", err)
+	}
+	select {
+	case <-ch:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("notification was not received")
+	}
+	cancel()
+
+	select {
+	case _, ok := <-sub.Err():
+		if ok {
+			t.Errorf("subscription Err channel should be closed")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("subscription was not closed on context cancel")
+	}
+	for _, want := range []string{"slotSubscribe", "slotUnsubscribe"} {
+		select {
+		case method := <-methods:
+			if method != want {
+				t.Errorf("request method Err ==> Got %s, Want: %s", method, want)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("%s request was not received", want)
+		}
+	}
+	// the forwarding and context watching goroutines exit
+	deadline := time.Now().Add(2 * time.Second)
+	for subscriptionGoroutines() > 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := subscriptionGoroutines(); got > 0 {
+		t.Errorf("subscription goroutines Err ==> Got %d, Want: %d", got, 0)
+	}
+	// unsubscribing again is a no-op
+	sub.Unsubscribe()
+}
+
+// subscriptionGoroutines counts the goroutines running a ClientSubscription method
+func subscriptionGoroutines() int {
+	buf := make([]byte, 1<<20)
+	buf = buf[:runtime.Stack(buf, true)]
+	count := 0
+	for _, stack := range strings.Split(string(buf), "\n\n") {
+		if strings.Contains(stack, "(*ClientSubscription)") {
+			count++
+		}
+	}
+	return count
+}
@@ -152,7 +152,7 @@ func newClientTransportHTTP(endpoint string, cfg *clientConfig) reconnectFunc {
 
 	client := cfg.httpClient
 	if client == nil {
-		client = new(http.Client)
+		client = &http.Client{Transport: cfg.newHTTPTransport()}
 	}
 
 	hc := &httpConn{
@@ -168,6 +168,26 @@ func newClientTransportHTTP(endpoint string, cfg *clientConfig) reconnectFunc {
 	}
 }
 
+// newHTTPTransport returns the transport configured by the options,
+// nil means http.DefaultTransport
+func (cfg *clientConfig) newHTTPTransport() http.RoundTripper {
+	if cfg.httpTransport != nil {
+		return cfg.httpTransport
+	}
+	if cfg.httpMaxIdleConns == 0 && cfg.httpIdleConnTimeout == 0 {
+		return nil
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.httpMaxIdleConns > 0 {
+		transport.MaxIdleConns = cfg.httpMaxIdleConns
+		transport.MaxIdleConnsPerHost = cfg.httpMaxIdleConns
+	}
+	if cfg.httpIdleConnTimeout > 0 {
+		transport.IdleConnTimeout = cfg.httpIdleConnTimeout
+	}
+	return transport
+}
+
 func (c *Client) sendHTTP(ctx context.Context, op *requestOp, msg interface{}) error {
 	hc := c.writeConn.(*httpConn)
 	respBody, err := hc.doRequest(ctx, msg)
@@ -185,6 +205,22 @@ func (c *Client) sendHTTP(ctx context.Context, op *requestOp, msg interface{}) e
 	return nil
 }
 
+// CallContextStream performs a JSON-RPC call over HTTP and returns the raw body of the
+// response, so a large result can be decoded incrementally. The body is the whole
+// JSON-RPC response object, which may hold an error instead of the result. The caller
+// must close the body. The default call timeout does not apply, reading the body
+// is bounded by ctx only.
+func (c *Client) CallContextStream(ctx context.Context, method string, args ...interface{}) (io.ReadCloser, error) {
+	if !c.isHTTP {
+		return nil, ErrStreamNotSupported
+	}
+	msg, err := c.newMessage(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	return c.writeConn.(*httpConn).doRequest(ctx, msg)
+}
+
 func (c *Client) sendBatchHTTP(ctx context.Context, op *requestOp, msgs []*jsonrpcMessage) error {
 	hc := c.writeConn.(*httpConn)
 	respBody, err := hc.doRequest(ctx, msgs)
@@ -64,7 +64,7 @@ type jsonrpcMessage struct {
 	ID      json.RawMessage `json:"id,omitempty"`
 	Method  string          `json:"method,omitempty"`
 	Params  json.RawMessage `json:"params,omitempty"`
-	Error   *jsonError      `json:"error,omitempty"`
+	Error   *RPCError       `json:"error,omitempty"`
 	Result  json.RawMessage `json:"result,omitempty"`
 }
 
@@ -122,7 +122,7 @@ func (msg *jsonrpcMessage) response(result interface{}) *jsonrpcMessage {
 }
 
 func errorMessage(err error) *jsonrpcMessage {
-	msg := &jsonrpcMessage{Version: vsn, ID: null, Error: &jsonError{
+	msg := &jsonrpcMessage{Version: vsn, ID: null, Error: &RPCError{
 		Code:    errcodeDefault,
 		Message: err.Error(),
 	}}
@@ -132,30 +132,40 @@ func errorMessage(err error) *jsonrpcMessage {
 	}
 	de, ok := err.(DataError)
 	if ok {
-		msg.Error.Data = de.ErrorData()
+		msg.Error.Data, _ = json.Marshal(de.ErrorData())
 	}
 	return msg
 }
 
-type jsonError struct {
-	Code    int         `json:"code"`
-	Message string      `json:"message"`
-	Data    interface{} `json:"data,omitempty"`
+// RPCError is the error object of a JSON-RPC response. It is returned as is by
+// CallContext and BatchCallContext, so callers may inspect the code and the raw data.
+type RPCError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
 }
 
-func (err *jsonError) Error() string {
+func (err *RPCError) Error() string {
 	if err.Message == "" {
 		return fmt.Sprintf("json-rpc error %d", err.Code)
 	}
 	return err.Message
 }
 
-func (err *jsonError) ErrorCode() int {
+func (err *RPCError) ErrorCode() int {
 	return err.Code
 }
 
-func (err *jsonError) ErrorData() interface{} {
-	return err.Data
+// ErrorData returns the data payload decoded into a generic value, nil if absent.
+func (err *RPCError) ErrorData() interface{} {
+	if len(err.Data) == 0 {
+		return nil
+	}
+	var data interface{}
+	if json.Unmarshal(err.Data, &data) != nil {
+		return string(err.Data)
+	}
+	return data
 }
 
 // Conn is a subset of the methods of net.Conn which are sufficient for ServerCodec.
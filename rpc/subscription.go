@@ -274,6 +274,16 @@ func (sub *ClientSubscription) Unsubscribe() {
 	})
 }
 
+// unsubscribeOnDone unsubscribes when ctx is canceled. It returns as soon as the
+// subscription has ended, so it never outlives the forwarding loop.
+func (sub *ClientSubscription) unsubscribeOnDone(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+		sub.Unsubscribe()
+	case <-sub.unsubDone:
+	}
+}
+
 // deliver is called by the client's message dispatcher to send a notification value.
 func (sub *ClientSubscription) deliver(result json.RawMessage) (ok bool) {
 	select {
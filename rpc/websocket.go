@@ -24,6 +24,7 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	mapset "github.com/deckarep/golang-set/v2"
@@ -57,7 +58,9 @@ func (s *Server) WebsocketHandler(allowedOrigins []string) http.Handler {
 		if err != nil {
 			return
 		}
-		codec := newWebsocketCodec(conn, r.Host, r.Header, wsDefaultReadLimit)
+		readLimit := new(atomic.Int64)
+		readLimit.Store(wsDefaultReadLimit)
+		codec := newWebsocketCodec(conn, r.Host, r.Header, readLimit)
 		s.ServeCodec(codec, 0)
 	})
 }
@@ -219,6 +222,20 @@ func newClientTransportWS(endpoint string, cfg *clientConfig) (reconnectFunc, er
 			WriteBufferPool: wsBufferPool,
 			Proxy:           http.ProxyFromEnvironment,
 		}
+		if cfg.wsReadBufferSize > 0 {
+			dialer.ReadBufferSize = cfg.wsReadBufferSize
+		}
+		if cfg.wsWriteBufferSize > 0 {
+			dialer.WriteBufferSize = cfg.wsWriteBufferSize
+		}
+	}
+
+	// the read limit is shared with the client, so that it can be changed later
+	// and survives reconnects.
+	cfg.wsReadLimit = new(atomic.Int64)
+	cfg.wsReadLimit.Store(wsDefaultReadLimit)
+	if cfg.wsMessageSizeLimit != nil && *cfg.wsMessageSizeLimit >= 0 {
+		cfg.wsReadLimit.Store(*cfg.wsMessageSizeLimit)
 	}
 
 	dialURL, header, err := wsClientHeaders(endpoint, "")
@@ -244,11 +261,7 @@ func newClientTransportWS(endpoint string, cfg *clientConfig) (reconnectFunc, er
 			}
 			return nil, hErr
 		}
-		messageSizeLimit := int64(wsDefaultReadLimit)
-		if cfg.wsMessageSizeLimit != nil && *cfg.wsMessageSizeLimit >= 0 {
-			messageSizeLimit = *cfg.wsMessageSizeLimit
-		}
-		return newWebsocketCodec(conn, dialURL, header, messageSizeLimit), nil
+		return newWebsocketCodec(conn, dialURL, header, cfg.wsReadLimit), nil
 	}
 	return connect, nil
 }
@@ -280,13 +293,24 @@ type websocketCodec struct {
 	pongReceived chan struct{}
 }
 
-func newWebsocketCodec(conn *websocket.Conn, host string, req http.Header, readLimit int64) ServerCodec {
-	conn.SetReadLimit(readLimit)
+// newWebsocketCodec creates a codec on the websocket connection. The read limit is
+// loaded before reading each message, a change applies from the next message.
+func newWebsocketCodec(conn *websocket.Conn, host string, req http.Header, readLimit *atomic.Int64) ServerCodec {
+	currentLimit := readLimit.Load()
+	conn.SetReadLimit(currentLimit)
 	encode := func(v interface{}, isErrorResponse bool) error {
 		return conn.WriteJSON(v)
 	}
+	// decode runs on the read goroutine only, so the limit is updated there.
+	decode := func(v interface{}) error {
+		if limit := readLimit.Load(); limit != currentLimit {
+			currentLimit = limit
+			conn.SetReadLimit(limit)
+		}
+		return conn.ReadJSON(v)
+	}
 	wc := &websocketCodec{
-		jsonCodec:    NewFuncCodec(conn, encode, conn.ReadJSON).(*jsonCodec),
+		jsonCodec:    NewFuncCodec(conn, encode, decode).(*jsonCodec),
 		conn:         conn,
 		pingReset:    make(chan struct{}, 1),
 		pongReceived: make(chan struct{}),
@@ -6,15 +6,27 @@ package solclient
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/cielu/go-solana/common"
 	"github.com/cielu/go-solana/rpc"
 	"github.com/cielu/go-solana/types"
 	"math/big"
+	"sync"
+	"time"
 )
 
 // Client defines typed wrappers for the Ethereum RPC API.
 type Client struct {
 	c *rpc.Client
+	// rent exemption by data length, used by MinBalanceForMint and MinBalanceForTokenAccount
+	rentCache *RentExemptionCache
+	// retry of the reads with a minContextSlot, disabled when the timeout is 0
+	minSlotRetryTimeout  time.Duration
+	minSlotRetryInterval time.Duration
+	// decimals of the mints fetched by GetMintDecimals, mint address -> uint8
+	mintDecimals sync.Map
+	// interval of the signature status polls of ConfirmTransaction
+	confirmPollInterval time.Duration
 }
 
 // Dial connects a client to the given URL.
@@ -22,18 +34,39 @@ func Dial(rawurl string) (*Client, error) {
 	return DialContext(context.Background(), rawurl)
 }
 
-// DialContext connects a client to the given URL with context.
-func DialContext(ctx context.Context, rawurl string) (*Client, error) {
-	c, err := rpc.DialContext(ctx, rawurl)
+// DialContext connects a client to the given URL with context and rpc client options.
+func DialContext(ctx context.Context, rawurl string, options ...rpc.ClientOption) (*Client, error) {
+	c, err := rpc.DialContext(ctx, rawurl, options...)
 	if err != nil {
 		return nil, err
 	}
 	return NewClient(c), nil
 }
 
+// DialAndVerify connects a client to the given URL like DialContext, then checks with getVersion
+// that the endpoint is a working Solana RPC. The client is closed when the check fails.
+// getHealth is not used, a node behind the cluster still serves the requests
+func DialAndVerify(ctx context.Context, rawurl string, options ...rpc.ClientOption) (*Client, error) {
+	sc, err := DialContext(ctx, rawurl, options...)
+	if err != nil {
+		return nil, err
+	}
+	version, err := sc.GetVersion(ctx)
+	if err == nil && version.SolanaCore == "" {
+		err = errors.New("missing solana-core version")
+	}
+	if err != nil {
+		sc.Close()
+		return nil, fmt.Errorf("%s is not a Solana RPC endpoint: %w", rawurl, err)
+	}
+	return sc, nil
+}
+
 // NewClient creates a client that uses the given RPC client.
 func NewClient(c *rpc.Client) *Client {
-	return &Client{c}
+	sc := &Client{c: c}
+	sc.rentCache = NewRentExemptionCache(sc, DefaultRentExemptionTTL)
+	return sc
 }
 
 // SetDebug set solClient debug
@@ -41,6 +74,19 @@ func (sc *Client) SetDebug(isDebug bool) {
 	sc.c.IsDebug = isDebug
 }
 
+// SetWSReadLimit set the maximum websocket message size in bytes, 0 means no limit
+func (sc *Client) SetWSReadLimit(limit int64) {
+	sc.c.SetWSReadLimit(limit)
+}
+
+// SetMinContextSlotRetry opt in to retry GetAccountInfo and GetMultipleAccounts with a MinContextSlot
+// every interval while the node returns -32016 min context slot not reached, until the timeout.
+// A timeout of 0 disables the retry
+func (sc *Client) SetMinContextSlotRetry(timeout, interval time.Duration) {
+	sc.minSlotRetryTimeout = timeout
+	sc.minSlotRetryInterval = interval
+}
+
 // Close closes the underlying RPC connection.
 func (sc *Client) Close() {
 	sc.c.Close()
@@ -63,7 +109,8 @@ func getRpcCfg[T any](cfg []T) *T {
 
 // GetAccountInfo Returns all information associated with the account of provided Pubkey
 func (sc *Client) GetAccountInfo(ctx context.Context, account common.Address, cfg ...types.RpcAccountInfoCfg) (res types.AccountInfoWithCtx, err error) {
-	err = sc.c.CallContext(ctx, &res, "getAccountInfo", account, getRpcCfg(cfg))
+	c := getRpcCfg(cfg)
+	err = sc.callWithMinContextSlot(ctx, c != nil && c.MinContextSlot != nil, &res, "getAccountInfo", account, c)
 	return
 }
 
@@ -74,15 +121,19 @@ func (sc *Client) GetBalance(ctx context.Context, account common.Address, cfg ..
 }
 
 // GetBlock Returns identity and transaction information about a confirmed block in the ledger
+// The node defaults to json encoding, full transaction details and rewards.
+// With transactionDetails "signatures" only BlockInfo.Signatures is set,
+// with "none" neither the transactions nor the signatures are set.
 func (sc *Client) GetBlock(ctx context.Context, blockNum uint64, cfg ...types.RpcGetBlockContextCfg) (blockInfo types.BlockInfo, err error) {
 	c := getRpcCfg(cfg)
 	if c == nil {
 		c = &types.RpcGetBlockContextCfg{}
 	}
-	// set Encoding as base64
-	// if c.Encoding == "" {
-	// 	c.Encoding = types.EncodingBase64
-	// }
+	switch c.TransactionDetails {
+	case "", types.TxDetailLevelFull, types.TxDetailLevelAccounts, types.TxDetailLevelSignatures, types.TxDetailLevelNone:
+	default:
+		return blockInfo, errors.New("invalid transactionDetails. Require: [full|accounts|signatures|none]")
+	}
 	err = sc.c.CallContext(ctx, &blockInfo, "getBlock", blockNum, c)
 	return
 }
@@ -101,41 +152,43 @@ func (sc *Client) GetBlockHeight(ctx context.Context, cfg ...types.RpcCommitment
 
 // GetBlockProduction Returns recent block production information from the current or previous epoch.
 func (sc *Client) GetBlockProduction(ctx context.Context, cfg ...types.RpcGetBlockProduction) (res types.BlockProductionWithCtx, err error) {
-	err = sc.c.CallContext(ctx, &res, "getBlockProduction", getRpcCfg(cfg))
+	c := getRpcCfg(cfg)
+	if c != nil && c.Range != nil {
+		if err = c.Range.Validate(); err != nil {
+			return
+		}
+	}
+	err = sc.c.CallContext(ctx, &res, "getBlockProduction", c)
 	return
 }
 
+// GetValidatorBlockProduction Returns the leader slots and the blocks produced by the validator identity
+// in the slot range, the current epoch when slotRange is nil. Both are 0 when the identity had no leader slots
+func (sc *Client) GetValidatorBlockProduction(ctx context.Context, identity common.Address, slotRange *types.SlotRange) (leaderSlots, blocksProduced uint64, err error) {
+	res, err := sc.GetBlockProduction(ctx, types.RpcGetBlockProduction{Identity: &identity, Range: slotRange})
+	if err != nil {
+		return 0, 0, err
+	}
+	production := res.BlockProduction.ByIdentity[identity.String()]
+	return uint64(production[0]), uint64(production[1]), nil
+}
+
 // GetBlockTime Returns the estimated production time of a block.
 func (sc *Client) GetBlockTime(ctx context.Context, blockNum uint64) (res int64, err error) {
 	err = sc.c.CallContext(ctx, &res, "getBlockTime", blockNum)
 	return
 }
 
-// GetBlocks Returns a list of confirmed blocks between two slots
-func (sc *Client) GetBlocks(ctx context.Context, startSlot uint64, args ...interface{}) (res []uint64, err error) {
-	var (
-		tmpSlot uint64
-		endSlot *uint64
-		cfg     *types.RpcCommitmentCfg
-	)
-	for _, arg := range args {
-		// set endSlot & cfg
-		switch v := arg.(type) {
-		case int:
-			tmpSlot = uint64(v)
-		case uint64:
-			tmpSlot = v
-		case types.RpcCommitmentCfg:
-			cfg = &v
-		default:
-			return res, errors.New("invalid args. Require: [uint64|types.RpcCommitmentCfg]")
-		}
-	}
-	// setTmpSlot
-	if tmpSlot > startSlot && tmpSlot-startSlot < 500000 {
-		endSlot = &tmpSlot
+// MaxGetBlocksRange the maximum distance between the start and the end slot of getBlocks
+const MaxGetBlocksRange = 500000
+
+// GetBlocks Returns a list of confirmed blocks between two slots, inclusive.
+// The endSlot must not be before the startSlot nor more than MaxGetBlocksRange after it
+func (sc *Client) GetBlocks(ctx context.Context, startSlot, endSlot uint64, cfg ...types.RpcCommitmentCfg) (res []uint64, err error) {
+	if endSlot < startSlot || endSlot-startSlot > MaxGetBlocksRange {
+		return res, errors.New("invalid endSlot. Require: [startSlot <= endSlot <= startSlot+500000]")
 	}
-	err = sc.c.CallContext(ctx, &res, "getBlocks", startSlot, endSlot, cfg)
+	err = sc.c.CallContext(ctx, &res, "getBlocks", startSlot, endSlot, getRpcCfg(cfg))
 	return
 }
 
@@ -169,6 +222,15 @@ func (sc *Client) GetFeeForMessage(ctx context.Context, msg string, cfg ...types
 	return
 }
 
+// GetFeeForBuiltMessage Get the fee the network will charge for a built Message
+func (sc *Client) GetFeeForBuiltMessage(ctx context.Context, msg *types.Message, cfg ...types.RpcCommitmentWithMinSlotCfg) (res types.U64ValueWithCtx, err error) {
+	b64, err := msg.ToBase64()
+	if err != nil {
+		return res, err
+	}
+	return sc.GetFeeForMessage(ctx, b64, cfg...)
+}
+
 // GetFirstAvailableBlock Returns the slot of the lowest confirmed block that has not been purged from the ledger
 func (sc *Client) GetFirstAvailableBlock(ctx context.Context) (res uint64, err error) {
 	err = sc.c.CallContext(ctx, &res, "getFirstAvailableBlock")
@@ -278,6 +340,16 @@ func (sc *Client) GetLeaderSchedule(ctx context.Context, args ...interface{}) (r
 	return
 }
 
+// GetLeaderScheduleTyped Returns the leader schedule for an epoch keyed by Address --> args [slot:u64]
+// The schedule is nil when the epoch is not found
+func (sc *Client) GetLeaderScheduleTyped(ctx context.Context, args ...interface{}) (*types.LeaderSchedule, error) {
+	raw, err := sc.GetLeaderSchedule(ctx, args...)
+	if err != nil || raw == nil {
+		return nil, err
+	}
+	return types.NewLeaderSchedule(raw)
+}
+
 // GetMaxRetransmitSlot Get the max slot seen from retransmit stage.
 func (sc *Client) GetMaxRetransmitSlot(ctx context.Context) (res uint64, err error) {
 	err = sc.c.CallContext(ctx, &res, "getMaxRetransmitSlot")
@@ -326,7 +398,8 @@ func (sc *Client) GetMultipleAccounts(ctx context.Context, accounts []common.Add
 	if len(accounts) > 100 {
 		return res, errors.New("accounts maximum is 100)")
 	}
-	err = sc.c.CallContext(ctx, &res, "getMultipleAccounts", accounts, getRpcCfg(cfg))
+	c := getRpcCfg(cfg)
+	err = sc.callWithMinContextSlot(ctx, c != nil && c.MinContextSlot != nil, &res, "getMultipleAccounts", accounts, c)
 	return
 }
 
@@ -425,6 +498,8 @@ func (sc *Client) GetSlotLeaders(ctx context.Context, args ...uint64) (res []com
 }
 
 // GetStakeActivation Returns epoch activation information for a stake account
+//
+// Deprecated: getStakeActivation is being removed from rpc nodes, use ComputeStakeActivation.
 func (sc *Client) GetStakeActivation(ctx context.Context, account common.Address, cfg ...types.RpcCommitmentWithMinSlotCfg) (res types.StakeActivation, err error) {
 	err = sc.c.CallContext(ctx, &res, "getStakeActivation", account, getRpcCfg(cfg))
 	return
@@ -483,9 +558,17 @@ func (sc *Client) GetTokenSupply(ctx context.Context, splToken common.Address, c
 	return
 }
 
-// GetTransaction Returns transaction details for a confirmed transaction
+// GetTransaction Returns transaction details for a confirmed transaction, with FillBlockTime the null
+// blockTime is fetched with getBlockTime. A getBlockTime failure is ignored and leaves BlockTime nil
 func (sc *Client) GetTransaction(ctx context.Context, signature common.Signature, cfg ...types.RpcGetTransactionCfg) (res types.BlockTransaction, err error) {
 	err = sc.c.CallContext(ctx, &res, "getTransaction", signature, getRpcCfg(cfg))
+	if err != nil || res.Transaction == nil || res.BlockTime != nil || len(cfg) == 0 || !cfg[0].FillBlockTime {
+		return
+	}
+	blockTime, timeErr := sc.GetBlockTime(ctx, res.Slot)
+	if timeErr == nil && blockTime != 0 {
+		res.BlockTime = &blockTime
+	}
 	return
 }
 
@@ -509,8 +592,9 @@ func (sc *Client) GetVoteAccounts(ctx context.Context, cfg ...types.RpcVoteAccou
 
 // IsBlockHashValid Returns whether a blockHash is still valid or not
 func (sc *Client) IsBlockHashValid(ctx context.Context, hash common.Hash, cfg ...types.RpcCommitmentWithMinSlotCfg) (res bool, err error) {
-	err = sc.c.CallContext(ctx, &res, "isBlockhashValid", hash, getRpcCfg(cfg))
-	return
+	var valid types.BlockhashValidWithCtx
+	err = sc.c.CallContext(ctx, &valid, "isBlockhashValid", hash, getRpcCfg(cfg))
+	return valid.Valid, err
 }
 
 // MinimumLedgerSlot Returns the lowest slot that the node has information about in its ledger.
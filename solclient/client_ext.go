@@ -0,0 +1,797 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package solclient
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/rpc"
+	"github.com/cielu/go-solana/types"
+	"github.com/cielu/go-solana/types/base"
+	"github.com/cielu/go-solana/types/native"
+	"github.com/cielu/go-solana/types/token"
+	"github.com/cielu/go-solana/types/vote"
+	"github.com/mr-tron/base58"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// GetAnchorProgramAccounts Returns the anchor accounts owned by the program,
+// which match the 8 bytes discriminator at offset 0 and the extra filters
+func (sc *Client) GetAnchorProgramAccounts(ctx context.Context, program common.Address, discriminator [8]byte, extraFilters ...map[string]interface{}) (res []types.ProgramAccount, err error) {
+	cfg := types.RpcCombinedCfg{
+		Encoding: types.EncodingBase64,
+		Filter:   append(types.Filters{types.Memcmp(0, discriminator[:])}.Maps(), extraFilters...),
+	}
+	return sc.GetProgramAccounts(ctx, program, cfg)
+}
+
+// AccountsExist Returns whether each account exists, the account data is not transferred
+// since the query uses a zero length dataSlice. Accounts are queried in chunks of 100
+func (sc *Client) AccountsExist(ctx context.Context, accounts []common.Address) (map[common.Address]bool, error) {
+	var (
+		res = make(map[common.Address]bool, len(accounts))
+		cfg = types.RpcAccountInfoCfg{
+			Encoding:  types.EncodingBase64,
+			DataSlice: &types.DataSlice{Length: 0, Offset: 0},
+		}
+	)
+	for start := 0; start < len(accounts); start += 100 {
+		end := start + 100
+		if end > len(accounts) {
+			end = len(accounts)
+		}
+		chunk := accounts[start:end]
+		info, err := sc.GetMultipleAccounts(ctx, chunk, cfg)
+		if err != nil {
+			return nil, err
+		}
+		for i, account := range chunk {
+			res[account] = i < len(info.Accounts) && info.Accounts[i] != nil
+		}
+	}
+	return res, nil
+}
+
+// ComputeStakeActivation Returns epoch activation information for a stake account,
+// computed from the stake account, the current epoch and the StakeHistory sysvar
+// instead of the deprecated getStakeActivation method. epoch defaults to the current epoch
+func (sc *Client) ComputeStakeActivation(ctx context.Context, account common.Address, epoch ...uint64) (res types.StakeActivation, err error) {
+	cfg := types.RpcAccountInfoCfg{Encoding: types.EncodingBase64}
+	info, stakeAccount, err := sc.getStakeAccount(ctx, account)
+	if err != nil {
+		return
+	}
+	var targetEpoch uint64
+	if len(epoch) > 0 {
+		targetEpoch = epoch[0]
+	} else {
+		epochInfo, err := sc.GetEpochInfo(ctx)
+		if err != nil {
+			return res, err
+		}
+		targetEpoch = epochInfo.Epoch
+	}
+	// without the stake history the delegation is considered fully (de)activated
+	history := types.StakeHistory{}
+	historyInfo, err := sc.GetAccountInfo(ctx, base.SysVarStakeHistoryPubkey, cfg)
+	if err == nil && historyInfo.AccountInfo != nil {
+		if history, err = types.DecodeStakeHistory(historyInfo.AccountInfo.Data.RawData); err != nil {
+			return
+		}
+	}
+	var lamports uint64
+	if info.Lamports != nil {
+		lamports = info.Lamports.Uint64()
+	}
+	// the reduced warmup/cooldown rate is active on all public clusters
+	var newRateActivationEpoch uint64
+	return stakeAccount.Activation(lamports, targetEpoch, history, &newRateActivationEpoch)
+}
+
+// StakeIsEligibleForRewards reports whether the stake account earns the rewards of the current epoch,
+// see types.StakeAccount.IsEligibleForRewards
+func (sc *Client) StakeIsEligibleForRewards(ctx context.Context, stakeAccount common.Address) (bool, error) {
+	_, account, err := sc.getStakeAccount(ctx, stakeAccount)
+	if err != nil {
+		return false, err
+	}
+	epochInfo, err := sc.GetEpochInfo(ctx)
+	if err != nil {
+		return false, err
+	}
+	return account.IsEligibleForRewards(epochInfo.Epoch), nil
+}
+
+// getStakeAccount fetch and decode the stake program account
+func (sc *Client) getStakeAccount(ctx context.Context, account common.Address) (*types.AccountInfo, types.StakeAccount, error) {
+	var stakeAccount types.StakeAccount
+	info, err := sc.GetAccountInfo(ctx, account, types.RpcAccountInfoCfg{Encoding: types.EncodingBase64})
+	if err != nil {
+		return nil, stakeAccount, err
+	}
+	if info.AccountInfo == nil {
+		return nil, stakeAccount, fmt.Errorf("stake account %s not found", account)
+	}
+	if info.AccountInfo.Owner != base.StakeProgramID {
+		return nil, stakeAccount, fmt.Errorf("account %s is not a stake account", account)
+	}
+	stakeAccount, err = types.DecodeStakeAccount(info.AccountInfo.Data.RawData)
+	return info.AccountInfo, stakeAccount, err
+}
+
+// GetBlockTransactionsFor Returns the transactions of the block which mention the account,
+// in the account keys or in the addresses loaded from lookup tables
+func (sc *Client) GetBlockTransactionsFor(ctx context.Context, slot uint64, account common.Address) (res []types.BlockTransaction, err error) {
+	rewards := false
+	blockInfo, err := sc.GetBlock(ctx, slot, types.RpcGetBlockContextCfg{
+		Encoding:              types.EncodingBase64,
+		Rewards:               &rewards,
+		TransactionDetails:    types.TxDetailLevelFull,
+		MaxSupportedTxVersion: 0,
+	})
+	if err != nil {
+		return
+	}
+	for _, tx := range blockInfo.BlockTransaction {
+		if tx.Mentions(account) {
+			res = append(res, tx)
+		}
+	}
+	return
+}
+
+// GetSignaturesByTimeRange Returns the signatures of the account with a block time between from and to, inclusive,
+// newest first. getSignaturesForAddress is paginated backwards until the entries predate from.
+// Entries without a block time are skipped
+func (sc *Client) GetSignaturesByTimeRange(ctx context.Context, account common.Address, from, to time.Time) (res []types.SignatureInfo, err error) {
+	var (
+		limit    uint = 1000
+		cfg           = types.RpcSignaturesForAddressCfg{Limit: &limit}
+		fromUnix      = from.Unix()
+		toUnix        = to.Unix()
+	)
+	for {
+		page, err := sc.GetSignaturesForAddress(ctx, account, cfg)
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range page {
+			if info.BlockTime == 0 || info.BlockTime > toUnix {
+				continue
+			}
+			if info.BlockTime < fromUnix {
+				return res, nil
+			}
+			res = append(res, info)
+		}
+		if len(page) < int(limit) {
+			return res, nil
+		}
+		cfg.Before = page[len(page)-1].Signature.String()
+	}
+}
+
+// slotsBehindRegexp matches the getHealth error message of a node behind the cluster
+var slotsBehindRegexp = regexp.MustCompile(`behind by (\d+) slots`)
+
+// SlotsBehind returns the slot distance of an unhealthy node from the getHealth error,
+// read from the error data numSlotsBehind or parsed from the "Node is behind by N slots" message
+func SlotsBehind(err error) (uint64, bool) {
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := dataErr.ErrorData().(map[string]interface{}); ok {
+			if n, ok := data["numSlotsBehind"].(float64); ok && n >= 0 {
+				return uint64(n), true
+			}
+		}
+	}
+	if err == nil {
+		return 0, false
+	}
+	match := slotsBehindRegexp.FindStringSubmatch(err.Error())
+	if match == nil {
+		return 0, false
+	}
+	n, parseErr := strconv.ParseUint(match[1], 10, 64)
+	return n, parseErr == nil
+}
+
+// WaitForHealthy polls getHealth every pollInterval until the node reports "ok".
+// When the ctx expires first, it returns the last known slot distance of the node
+// and the ctx error, wrapping the last health check error
+func (sc *Client) WaitForHealthy(ctx context.Context, pollInterval time.Duration) (slotsBehind uint64, err error) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		health, healthErr := sc.GetHealth(ctx)
+		if healthErr == nil && health == "ok" {
+			return 0, nil
+		}
+		if healthErr == nil {
+			healthErr = fmt.Errorf("node health: %s", health)
+		}
+		if n, ok := SlotsBehind(healthErr); ok {
+			slotsBehind = n
+		}
+		select {
+		case <-ctx.Done():
+			return slotsBehind, fmt.Errorf("%w: last health check: %v", ctx.Err(), healthErr)
+		case <-ticker.C:
+		}
+	}
+}
+
+// defaultMinSlotRetryInterval the poll interval of the minContextSlot retry when none is set
+const defaultMinSlotRetryInterval = 200 * time.Millisecond
+
+// callWithMinContextSlot calls the method, when hasMinSlot and the retry is enabled by SetMinContextSlotRetry
+// the call is repeated while the node has not reached the minContextSlot, until the retry timeout
+func (sc *Client) callWithMinContextSlot(ctx context.Context, hasMinSlot bool, result interface{}, method string, args ...interface{}) error {
+	err := sc.c.CallContext(ctx, result, method, args...)
+	if !hasMinSlot || sc.minSlotRetryTimeout <= 0 || !IsMinContextSlotNotReached(err) {
+		return err
+	}
+	interval := sc.minSlotRetryInterval
+	if interval <= 0 {
+		interval = defaultMinSlotRetryInterval
+	}
+	timeout := time.NewTimer(sc.minSlotRetryTimeout)
+	defer timeout.Stop()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for IsMinContextSlotNotReached(err) {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %v", ctx.Err(), err)
+		case <-timeout.C:
+			return err
+		case <-ticker.C:
+		}
+		err = sc.c.CallContext(ctx, result, method, args...)
+	}
+	return err
+}
+
+// SupportsFeature Returns whether the node runs the feature set. The feature set is an identifier
+// of the activated features, as returned by getVersion and getClusterNodes, not an ordered version
+func (sc *Client) SupportsFeature(ctx context.Context, featureSet uint32) (bool, error) {
+	version, err := sc.GetVersion(ctx)
+	if err != nil {
+		return false, err
+	}
+	return version.FeatureSet == featureSet, nil
+}
+
+// SendRawTransaction Submits an already encoded signed transaction, e.g. the output of Transaction.ToBase64,
+// the encoding is base58 or base64 and overrides the encoding of the cfg
+func (sc *Client) SendRawTransaction(ctx context.Context, encoded string, encoding types.EnumEncoding, cfg ...types.RpcSendTxCfg) (res common.Signature, err error) {
+	switch encoding {
+	case types.EncodingBase58:
+		_, err = base58.Decode(encoded)
+	case types.EncodingBase64:
+		_, err = base64.StdEncoding.DecodeString(encoded)
+	default:
+		return res, errors.New("invalid encoding. Require: [base58|base64]")
+	}
+	if err != nil {
+		return res, fmt.Errorf("invalid %s transaction: %w", encoding, err)
+	}
+	c := types.RpcSendTxCfg{}
+	if rc := getRpcCfg(cfg); rc != nil {
+		c = *rc
+	}
+	c.Encoding = encoding
+	err = sc.c.CallContext(ctx, &res, "sendTransaction", encoded, c)
+	return
+}
+
+// BlocksRangeOpts options of GetBlocksRange
+type BlocksRangeOpts struct {
+	// Number of blocks fetched concurrently, 8 by default
+	Concurrency int
+	// Number of retries of a failed getBlock, 3 by default, negative disables the retries
+	Retries int
+	// Config of getBlock, its commitment is also used by getBlocks
+	Config types.RpcGetBlockContextCfg
+}
+
+// RangeBlock a block of GetBlocksRange, Block is nil when the slot was skipped
+// or the block is missing in long-term storage
+type RangeBlock struct {
+	Slot  uint64
+	Block *types.BlockInfo
+}
+
+// GetBlocksRange Returns the blocks between startSlot and endSlot, inclusive, ordered by slot.
+// The confirmed slots are listed by getBlocks, then the blocks are fetched concurrently,
+// each getBlock is retried on failure. Skipped slots are returned with a nil Block
+func (sc *Client) GetBlocksRange(ctx context.Context, startSlot, endSlot uint64, opts BlocksRangeOpts) ([]RangeBlock, error) {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 8
+	}
+	if opts.Retries < 0 {
+		opts.Retries = 0
+	} else if opts.Retries == 0 {
+		opts.Retries = 3
+	}
+	slots, err := sc.GetBlocks(ctx, startSlot, endSlot, types.RpcCommitmentCfg{Commitment: opts.Config.Commitment})
+	if err != nil {
+		return nil, err
+	}
+	res := make([]RangeBlock, len(slots))
+	for i, slot := range slots {
+		res[i].Slot = slot
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+		jobs     = make(chan int)
+	)
+	for i := 0; i < opts.Concurrency && i < len(res); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				block, err := sc.getBlockWithRetry(ctx, res[idx].Slot, opts)
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = fmt.Errorf("getBlock %d: %w", res[idx].Slot, err)
+						cancel()
+					})
+					continue
+				}
+				res[idx].Block = block
+			}
+		}()
+	}
+feed:
+	for idx := range res {
+		select {
+		case jobs <- idx:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// getBlockWithRetry fetches the block of the slot, retried with a linear backoff.
+// Returns nil without error when the slot was skipped
+func (sc *Client) getBlockWithRetry(ctx context.Context, slot uint64, opts BlocksRangeOpts) (*types.BlockInfo, error) {
+	for attempt := 0; ; attempt++ {
+		block, err := sc.GetBlock(ctx, slot, opts.Config)
+		if err == nil {
+			return &block, nil
+		}
+		if IsSlotSkipped(err) {
+			return nil, nil
+		}
+		if attempt >= opts.Retries {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Duration(attempt+1) * 100 * time.Millisecond):
+		}
+	}
+}
+
+// EstimateComputeUnits Returns the compute units consumed by the transaction, simulated without
+// verifying the signatures and with the recent blockhash replaced, so it does not need to be signed
+func (sc *Client) EstimateComputeUnits(ctx context.Context, tx *types.Transaction) (uint64, error) {
+	unsigned := *tx
+	unsigned.Signatures = make([]common.Signature, tx.Message.Header.NumRequiredSignatures)
+	if unsigned.Message.RecentBlockhash.IsEmpty() {
+		// any non-empty blockhash, it is replaced by the node
+		unsigned.Message.RecentBlockhash[0] = 1
+	}
+	encoded, err := unsigned.ToBase64()
+	if err != nil {
+		return 0, err
+	}
+	var res types.SimulateTxResultWithCtx
+	err = sc.c.CallContext(ctx, &res, "simulateTransaction", encoded, types.RpcSimulateTxCfg{
+		Encoding:               types.EncodingBase64,
+		ReplaceRecentBlockhash: true,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(res.Result.Err) > 0 && string(res.Result.Err) != "null" {
+		return 0, fmt.Errorf("simulation failed: %s, logs: %v", res.Result.Err, res.Result.Logs)
+	}
+	if res.Result.UnitsConsumed == nil {
+		return 0, errors.New("simulation returned no unitsConsumed")
+	}
+	return *res.Result.UnitsConsumed, nil
+}
+
+// GetRaydiumPoolReserves Returns the token amounts of the coin and pc vaults of a Raydium pool, fetched in a
+// single getMultipleAccounts call which only transfers the amount of the token accounts.
+// The reserves feed raydium.QuoteSwap
+func (sc *Client) GetRaydiumPoolReserves(ctx context.Context, coinVault, pcVault common.Address) (coin, pc uint64, err error) {
+	info, err := sc.GetMultipleAccounts(ctx, []common.Address{coinVault, pcVault}, types.RpcAccountInfoCfg{
+		Encoding: types.EncodingBase64,
+		// the amount of a token account, after the mint and the owner
+		DataSlice: &types.DataSlice{Offset: 64, Length: 8},
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	amounts := make([]uint64, 2)
+	for i, vault := range []common.Address{coinVault, pcVault} {
+		if i >= len(info.Accounts) || info.Accounts[i] == nil {
+			return 0, 0, fmt.Errorf("vault %s not found", vault)
+		}
+		account := info.Accounts[i]
+		if account.Owner != base.TokenProgramID && account.Owner != base.Token2022ProgramID {
+			return 0, 0, fmt.Errorf("vault %s is not a token account, owner: %s", vault, account.Owner)
+		}
+		if len(account.Data.RawData) != 8 {
+			return 0, 0, fmt.Errorf("invalid vault %s amount size: %d", vault, len(account.Data.RawData))
+		}
+		amounts[i] = binary.LittleEndian.Uint64(account.Data.RawData)
+	}
+	return amounts[0], amounts[1], nil
+}
+
+// GetMint Returns the decoded state of a token or Token-2022 mint, the Token-2022 extensions are ignored
+func (sc *Client) GetMint(ctx context.Context, mint common.Address) (*token.MintState, error) {
+	info, err := sc.GetAccountInfo(ctx, mint, types.RpcAccountInfoCfg{Encoding: types.EncodingBase64})
+	if err != nil {
+		return nil, err
+	}
+	account := info.AccountInfo
+	if account == nil {
+		return nil, fmt.Errorf("mint %s not found", mint)
+	}
+	if account.Owner != base.TokenProgramID && account.Owner != base.Token2022ProgramID {
+		return nil, fmt.Errorf("%s is not a mint, owner: %s", mint, account.Owner)
+	}
+	state, _, err := token.DecodeMint(account.Data.RawData)
+	return state, err
+}
+
+// GetVoteState Returns the decoded state of a vote account: node pubkey, authorized voters,
+// commission, votes, root slot and epoch credits
+func (sc *Client) GetVoteState(ctx context.Context, voteAccount common.Address) (*vote.VoteState, error) {
+	info, err := sc.GetAccountInfo(ctx, voteAccount, types.RpcAccountInfoCfg{Encoding: types.EncodingBase64})
+	if err != nil {
+		return nil, err
+	}
+	account := info.AccountInfo
+	if account == nil {
+		return nil, fmt.Errorf("vote account %s not found", voteAccount)
+	}
+	if account.Owner != base.VoteProgramID {
+		return nil, fmt.Errorf("%s is not a vote account, owner: %s", voteAccount, account.Owner)
+	}
+	state, err := vote.DecodeVoteState(account.Data.RawData)
+	if err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// GetMintDecimals Returns the decimals of a mint, as required by the checked token instructions.
+// The decimals never change, they are fetched once per mint then served from memory
+func (sc *Client) GetMintDecimals(ctx context.Context, mint common.Address) (uint8, error) {
+	if decimals, ok := sc.mintDecimals.Load(mint); ok {
+		return decimals.(uint8), nil
+	}
+	state, err := sc.GetMint(ctx, mint)
+	if err != nil {
+		return 0, err
+	}
+	if !state.IsInitialized {
+		return 0, fmt.Errorf("mint %s is not initialized", mint)
+	}
+	sc.mintDecimals.Store(mint, state.Decimals)
+	return state.Decimals, nil
+}
+
+// StreamProgramAccounts Returns the accounts owned by the program like GetProgramAccounts, but the response
+// is decoded incrementally and each account is sent to the accounts channel as soon as it is decoded,
+// so the whole result is never held in memory. It blocks until the response is consumed, an error
+// occurs or the ctx is done, then closes the accounts channel. The filters and the dataSlice
+// of the cfg apply, withContext is ignored. Only HTTP endpoints are supported
+func (sc *Client) StreamProgramAccounts(ctx context.Context, program common.Address, accounts chan<- types.ProgramAccount, cfg ...types.RpcCombinedCfg) error {
+	defer close(accounts)
+	var c *types.RpcCombinedCfg
+	if len(cfg) > 0 {
+		cp := cfg[0]
+		cp.WithContext = false
+		c = &cp
+	}
+	body, err := sc.c.CallContextStream(ctx, "getProgramAccounts", program, c)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	dec := json.NewDecoder(body)
+	if err = expectJSONDelim(dec, '{'); err != nil {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "result":
+			if err = streamProgramAccounts(ctx, dec, accounts); err != nil {
+				return err
+			}
+		case "error":
+			rpcErr := new(rpc.RPCError)
+			if err = dec.Decode(rpcErr); err != nil {
+				return err
+			}
+			return rpcErr
+		default:
+			var skip json.RawMessage
+			if err = dec.Decode(&skip); err != nil {
+				return err
+			}
+		}
+	}
+	return expectJSONDelim(dec, '}')
+}
+
+// streamProgramAccounts decodes the result array of getProgramAccounts element by element
+func streamProgramAccounts(ctx context.Context, dec *json.Decoder, accounts chan<- types.ProgramAccount) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("invalid getProgramAccounts result, got %v, want: [", tok)
+	}
+	for dec.More() {
+		var account types.ProgramAccount
+		if err = dec.Decode(&account); err != nil {
+			return err
+		}
+		select {
+		case accounts <- account:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return expectJSONDelim(dec, ']')
+}
+
+// expectJSONDelim reads the next token of the decoder, which must be the delim
+func expectJSONDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != delim {
+		return fmt.Errorf("invalid json, got %v, want: %s", tok, delim)
+	}
+	return nil
+}
+
+// BatchCallContext sends the requests as a single JSON-RPC batch, one HTTP round-trip, and waits
+// for the responses. The error of each request is set in its BatchElem, only the errors
+// of sending the batch are returned
+func (sc *Client) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	return sc.c.BatchCallContext(ctx, b)
+}
+
+// maxMultipleAccounts the maximum number of accounts of a getMultipleAccounts request
+const maxMultipleAccounts = 100
+
+// GetMultipleAccountInfosBatched Returns the information of any number of accounts, in the order of the
+// accounts. They are fetched by chunks of 100 with getMultipleAccounts, all sent in a single batch.
+// The information of a missing account is nil
+func (sc *Client) GetMultipleAccountInfosBatched(ctx context.Context, accounts []common.Address, cfg ...types.RpcAccountInfoCfg) ([]*types.AccountInfo, error) {
+	var (
+		batch   []rpc.BatchElem
+		results []*types.AccountsInfoWithCtx
+	)
+	for start := 0; start < len(accounts); start += maxMultipleAccounts {
+		end := start + maxMultipleAccounts
+		if end > len(accounts) {
+			end = len(accounts)
+		}
+		res := new(types.AccountsInfoWithCtx)
+		results = append(results, res)
+		batch = append(batch, rpc.BatchElem{
+			Method: "getMultipleAccounts",
+			Args:   []interface{}{accounts[start:end], getRpcCfg(cfg)},
+			Result: res,
+		})
+	}
+	if len(batch) == 0 {
+		return nil, nil
+	}
+	if err := sc.c.BatchCallContext(ctx, batch); err != nil {
+		return nil, err
+	}
+	infos := make([]*types.AccountInfo, 0, len(accounts))
+	for i, elem := range batch {
+		if elem.Error != nil {
+			return nil, elem.Error
+		}
+		chunk := len(elem.Args[0].([]common.Address))
+		if len(results[i].Accounts) != chunk {
+			return nil, fmt.Errorf("getMultipleAccounts returned %d accounts, Require: %d", len(results[i].Accounts), chunk)
+		}
+		infos = append(infos, results[i].Accounts...)
+	}
+	return infos, nil
+}
+
+// maxBlockTimesBatch the maximum number of getBlockTime requests of a batch
+const maxBlockTimesBatch = 100
+
+// GetBlockTimes Returns the production time of the blocks of the slots, requested in batches of 100.
+// The time of a block without a recorded time, of a skipped slot, or of a block not available
+// on the node, is the zero time
+func (sc *Client) GetBlockTimes(ctx context.Context, slots []uint64) (map[uint64]time.Time, error) {
+	times := make(map[uint64]time.Time, len(slots))
+	for start := 0; start < len(slots); start += maxBlockTimesBatch {
+		end := start + maxBlockTimesBatch
+		if end > len(slots) {
+			end = len(slots)
+		}
+		var (
+			chunk   = slots[start:end]
+			batch   = make([]rpc.BatchElem, len(chunk))
+			results = make([]*int64, len(chunk))
+		)
+		for i, slot := range chunk {
+			batch[i] = rpc.BatchElem{Method: "getBlockTime", Args: []interface{}{slot}, Result: &results[i]}
+		}
+		if err := sc.c.BatchCallContext(ctx, batch); err != nil {
+			return nil, err
+		}
+		for i, elem := range batch {
+			if elem.Error != nil {
+				if !IsSlotSkipped(elem.Error) && !hasErrorCode(elem.Error, ErrCodeBlockNotAvailable) {
+					return nil, fmt.Errorf("getBlockTime of slot %d: %w", chunk[i], elem.Error)
+				}
+				times[chunk[i]] = time.Time{}
+				continue
+			}
+			if results[i] == nil {
+				times[chunk[i]] = time.Time{}
+				continue
+			}
+			times[chunk[i]] = types.UnixTime(*results[i])
+		}
+	}
+	return times, nil
+}
+
+// EstimateTransactionFee Returns the fee of the transaction: the signature fee, from getFeeForMessage of the
+// message with a zero compute unit price, and the prioritization fee of its compute budget instructions
+func (sc *Client) EstimateTransactionFee(ctx context.Context, tx *types.Transaction, cfg ...types.RpcCommitmentWithMinSlotCfg) (*types.FeeBreakdown, error) {
+	fee := &types.FeeBreakdown{Signatures: tx.Message.Header.NumRequiredSignatures}
+	fee.ComputeUnitLimit, fee.ComputeUnitPrice = tx.Message.ComputeBudget()
+	fee.PriorityFee = types.PriorityFee(fee.ComputeUnitLimit, fee.ComputeUnitPrice)
+
+	res, err := sc.GetFeeForBuiltMessage(ctx, tx.Message.WithoutComputeUnitPrice(), cfg...)
+	if err != nil {
+		return nil, err
+	}
+	if res.Value == nil {
+		return nil, errors.New("unable to get the fee of the message, the blockhash is expired")
+	}
+	fee.BaseFee = *res.Value
+	if fee.Signatures > 0 {
+		fee.LamportsPerSignature = fee.BaseFee / uint64(fee.Signatures)
+	}
+	fee.Total = fee.BaseFee + fee.PriorityFee
+	return fee, nil
+}
+
+// CheckSenderBalance Returns whether the balance of the fee payer covers the fee of the transaction, see
+// EstimateTransactionFee, and the lamports it sends with the system program Transfer, CreateAccount and
+// CreateAccountWithSeed instructions, including the rent of the created accounts
+func (sc *Client) CheckSenderBalance(ctx context.Context, tx *types.Transaction) (sufficient bool, needed, have uint64, err error) {
+	if len(tx.Message.AccountKeys) == 0 {
+		return false, 0, 0, errors.New("invalid transaction. Require: a fee payer")
+	}
+	payer := tx.Message.AccountKeys[0]
+	fee, err := sc.EstimateTransactionFee(ctx, tx)
+	if err != nil {
+		return false, 0, 0, err
+	}
+	needed = fee.Total
+	for i, instruction := range tx.Message.Describe(nil).Instructions {
+		var (
+			accounts base.AccountMetaSlice
+			lamports *uint64
+		)
+		switch inst := instruction.Decoded.(type) {
+		case *native.Transfer:
+			accounts, lamports = inst.AccountMetaSlice, inst.Lamports
+		case *native.CreateAccount:
+			accounts, lamports = inst.AccountMetaSlice, inst.Lamports
+		case *native.CreateAccountWithSeed:
+			accounts, lamports = inst.AccountMetaSlice, inst.Lamports
+		default:
+			continue
+		}
+		// the funding account is the first account of the system instructions
+		if len(accounts) == 0 {
+			return false, 0, 0, fmt.Errorf("invalid system instruction %d. Require: a funding account", i)
+		}
+		if funding := accounts[0]; funding != nil && funding.PublicKey == payer && lamports != nil {
+			needed += *lamports
+		}
+	}
+	balance, err := sc.GetBalance(ctx, payer)
+	if err != nil {
+		return false, needed, 0, err
+	}
+	if balance.Balance != nil && balance.Balance.IsUint64() {
+		have = balance.Balance.Uint64()
+	}
+	return have >= needed, needed, have, nil
+}
+
+// SuggestPriorityFee Returns the compute unit price in micro-lamports at the percentile (0-100) of the recent
+// prioritization fees paid by the transactions locking the accounts, 0 when there are no recent fees
+func (sc *Client) SuggestPriorityFee(ctx context.Context, accounts []common.Address, percentile float64) (uint64, error) {
+	fees, err := sc.GetRecentPrioritizationFees(ctx, accounts)
+	if err != nil {
+		return 0, err
+	}
+	return types.RpcPrioritizationFees(fees).Percentile(percentile), nil
+}
+
+// GetRPCNodes Returns the cluster nodes advertising a JSON RPC endpoint, with a software
+// version starting with one of the prefixes when given, e.g. "1.18"
+func (sc *Client) GetRPCNodes(ctx context.Context, versionPrefixes ...string) ([]types.ClusterInformation, error) {
+	nodes, err := sc.GetClusterNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var res []types.ClusterInformation
+	for _, node := range nodes {
+		if _, _, ok := node.RPCEndpoint(); !ok {
+			continue
+		}
+		if len(versionPrefixes) == 0 {
+			res = append(res, node)
+			continue
+		}
+		for _, prefix := range versionPrefixes {
+			if node.HasVersionPrefix(prefix) {
+				res = append(res, node)
+				break
+			}
+		}
+	}
+	return res, nil
+}
@@ -143,7 +143,7 @@ func TestClient_GetBlocks(t *testing.T) {
 		c   = newClient()
 		ctx = context.Background()
 	)
-	res, err := c.GetBlocks(ctx, 256731099)
+	res, err := c.GetBlocks(ctx, 256731099, 256731199)
 	if err != nil {
 		t.Error("GetBlocks Failed: %w", err)
 	}
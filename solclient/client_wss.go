@@ -8,10 +8,13 @@ import (
 	"errors"
 	"github.com/cielu/go-solana/common"
 	"github.com/cielu/go-solana/types"
+	"sync"
+	"time"
 )
 
 // Subscription represents an event subscription where events are
-// delivered on a data channel.
+// delivered on a data channel. Canceling the context given to the subscribe
+// method unsubscribes, the data channel is left open as it belongs to the caller.
 type Subscription interface {
 	// Unsubscribe cancels the sending of events to the data channel
 	// and closes the error channel.
@@ -23,10 +26,21 @@ type Subscription interface {
 	Err() <-chan error
 }
 
-// AccountSubscribe Subscribe to an account to receive notifications when the lamports or data for a given account public key changes
+// AccountSubscribe Subscribe to an account to receive notifications when the lamports or data for a given account public key changes.
+// The notifications are base64 encoded at the confirmed commitment unless set by cfg
 func (sc *Client) AccountSubscribe(ctx context.Context, ch chan<- types.AccountNotifies, account common.Address, cfg ...types.RpcCommitmentWithEncodingCfg) (Subscription, error) {
+	c := types.RpcCommitmentWithEncodingCfg{}
+	if len(cfg) > 0 {
+		c = cfg[0]
+	}
+	if c.Commitment == "" {
+		c.Commitment = types.RpcCommitmentConfirmed
+	}
+	if c.Encoding == "" {
+		c.Encoding = types.EncodingBase64
+	}
 	// SolSubscribe
-	sub, err := sc.c.Subscribe(ctx, "account", ch, account, getRpcCfg(cfg))
+	sub, err := sc.c.Subscribe(ctx, "account", ch, account, c)
 	if err != nil {
 		return nil, err
 	}
@@ -101,3 +115,135 @@ func (sc *Client) SlotSubscribe(ctx context.Context, ch chan<- types.SlotNotifie
 	}
 	return sub, nil
 }
+
+// ResubscribeFunc establishes a subscription, e.g. calls AccountSubscribe with the channel
+// and the parameters to replay. The context is canceled when the subscription is unsubscribed.
+type ResubscribeFunc func(ctx context.Context) (Subscription, error)
+
+// Resubscribe keeps the subscription established by fn alive. When the subscription fails,
+// e.g. the websocket connection dropped, fn is called again: the rpc client reconnects on
+// the subscribe request and the notifications keep being delivered to the same channel.
+// Failed attempts are retried with a backoff up to backoffMax.
+//
+// The Err channel of the returned subscription is only closed by Unsubscribe, or when a
+// subscription ends without error.
+//
+//	sub := solclient.Resubscribe(10*time.Second, func(ctx context.Context) (solclient.Subscription, error) {
+//		return c.AccountSubscribe(ctx, ch, account)
+//	})
+func Resubscribe(backoffMax time.Duration, fn ResubscribeFunc) Subscription {
+	s := &resubscribeSub{
+		fn:         fn,
+		err:        make(chan error),
+		unsub:      make(chan struct{}),
+		backoffMax: backoffMax,
+	}
+	go s.loop()
+	return s
+}
+
+type resubscribeSub struct {
+	fn         ResubscribeFunc
+	err        chan error
+	unsub      chan struct{}
+	unsubOnce  sync.Once
+	lastTry    time.Time
+	backoffMax time.Duration
+	waitTime   time.Duration
+}
+
+func (s *resubscribeSub) Unsubscribe() {
+	s.unsubOnce.Do(func() {
+		select {
+		case s.unsub <- struct{}{}:
+		case <-s.err:
+		}
+		<-s.err
+	})
+}
+
+func (s *resubscribeSub) Err() <-chan error {
+	return s.err
+}
+
+func (s *resubscribeSub) loop() {
+	defer close(s.err)
+	var done bool
+	for !done {
+		sub, cancel := s.subscribe()
+		if sub == nil {
+			break
+		}
+		done = s.waitForError(sub)
+		sub.Unsubscribe()
+		cancel()
+	}
+}
+
+// subscribe calls fn until it succeeds, returns nil when unsubscribed. The returned
+// cancel func cancels the context of the subscription, once it has ended
+func (s *resubscribeSub) subscribe() (Subscription, context.CancelFunc) {
+	type result struct {
+		sub Subscription
+		err error
+	}
+	for {
+		s.lastTry = time.Now()
+		ctx, cancel := context.WithCancel(context.Background())
+		subscribed := make(chan result, 1)
+		go func() {
+			sub, err := s.fn(ctx)
+			subscribed <- result{sub, err}
+		}()
+		select {
+		case res := <-subscribed:
+			if res.err == nil {
+				return res.sub, cancel
+			}
+			cancel()
+			if s.backoffWait() {
+				return nil, nil
+			}
+		case <-s.unsub:
+			cancel()
+			// wait for fn to avoid leaking its subscription
+			if res := <-subscribed; res.err == nil {
+				res.sub.Unsubscribe()
+			}
+			return nil, nil
+		}
+	}
+}
+
+// waitForError reports whether the subscription is done
+func (s *resubscribeSub) waitForError(sub Subscription) bool {
+	select {
+	case err := <-sub.Err():
+		return err == nil
+	case <-s.unsub:
+		return true
+	}
+}
+
+// backoffWait waits before the next attempt, reports whether it was unsubscribed
+func (s *resubscribeSub) backoffWait() bool {
+	if time.Since(s.lastTry) > s.backoffMax {
+		s.waitTime = s.backoffMax / 10
+	} else {
+		s.waitTime *= 2
+		if s.waitTime > s.backoffMax {
+			s.waitTime = s.backoffMax
+		}
+	}
+	if s.waitTime <= 0 {
+		s.waitTime = 10 * time.Millisecond
+	}
+	t := time.NewTimer(s.waitTime)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return false
+	case <-s.unsub:
+		return true
+	}
+}
@@ -0,0 +1,302 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package solclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/crypto"
+	"github.com/cielu/go-solana/types"
+)
+
+// defaultConfirmPollInterval the interval of the signature status polls of ConfirmTransaction
+const defaultConfirmPollInterval = 500 * time.Millisecond
+
+// ErrBlockhashExpired returned when the block height exceeds the last valid block height
+// of the transaction blockhash before the transaction is found
+var ErrBlockhashExpired = errors.New("transaction blockhash expired")
+
+// ConfirmationState the state of a sent transaction observed by ConfirmTransaction
+type ConfirmationState struct {
+	// Status of the signature, nil until the transaction is found
+	Status *types.SignatureStatus
+	// Current block height, 0 when LastValidBlockHeight is unknown
+	BlockHeight uint64
+	// Last valid block height of the transaction blockhash, 0 when unknown
+	LastValidBlockHeight uint64
+}
+
+// ConfirmationStrategy decides when the confirmation of a transaction is done, an error stops it
+type ConfirmationStrategy interface {
+	Done(state ConfirmationState) (bool, error)
+}
+
+// ConfirmationStrategyFunc a function implementing ConfirmationStrategy
+type ConfirmationStrategyFunc func(state ConfirmationState) (bool, error)
+
+// Done calls f(state)
+func (f ConfirmationStrategyFunc) Done(state ConfirmationState) (bool, error) {
+	return f(state)
+}
+
+// commitmentLevel orders the confirmation statuses, processed < confirmed < finalized
+func commitmentLevel(commitment types.EnumRpcCommitment) int {
+	switch commitment {
+	case types.RpcCommitmentProcessed:
+		return 1
+	case types.RpcCommitmentConfirmed:
+		return 2
+	case types.RpcCommitmentFinalized:
+		return 3
+	}
+	return 0
+}
+
+// UntilCommitment done once the transaction reached the commitment, e.g. types.RpcCommitmentConfirmed
+func UntilCommitment(commitment types.EnumRpcCommitment) ConfirmationStrategy {
+	return ConfirmationStrategyFunc(func(state ConfirmationState) (bool, error) {
+		if state.Status == nil {
+			return false, nil
+		}
+		return commitmentLevel(types.EnumRpcCommitment(state.Status.ConfirmationStatus)) >= commitmentLevel(commitment), nil
+	})
+}
+
+// UntilConfirmations done once the block of the transaction has n confirmations, or is finalized
+func UntilConfirmations(n uint64) ConfirmationStrategy {
+	return ConfirmationStrategyFunc(func(state ConfirmationState) (bool, error) {
+		if state.Status == nil {
+			return false, nil
+		}
+		// null confirmations once rooted
+		if state.Status.Confirmations == nil {
+			return true, nil
+		}
+		return *state.Status.Confirmations >= n, nil
+	})
+}
+
+// UntilFinalizedOrExpired done once the transaction is finalized, it fails with ErrBlockhashExpired when
+// the blockhash expires before the transaction is found. Requires the last valid block height
+func UntilFinalizedOrExpired() ConfirmationStrategy {
+	finalized := UntilCommitment(types.RpcCommitmentFinalized)
+	return ConfirmationStrategyFunc(func(state ConfirmationState) (bool, error) {
+		if state.Status == nil && state.LastValidBlockHeight > 0 && state.BlockHeight > state.LastValidBlockHeight {
+			return false, ErrBlockhashExpired
+		}
+		return finalized.Done(state)
+	})
+}
+
+// SetConfirmPollInterval sets the interval of the signature status polls of ConfirmTransaction
+func (sc *Client) SetConfirmPollInterval(interval time.Duration) {
+	sc.confirmPollInterval = interval
+}
+
+// ConfirmTransaction polls the signature status until the strategy is done, the transaction
+// failed or the context is done. The block height is polled too when lastValidBlockHeight
+// is given. Returns the last signature status, the error of a failed instruction wraps
+// its *types.InstructionError
+func (sc *Client) ConfirmTransaction(ctx context.Context, signature common.Signature, lastValidBlockHeight uint64, strategy ConfirmationStrategy) (*types.SignatureStatus, error) {
+	interval := sc.confirmPollInterval
+	if interval <= 0 {
+		interval = defaultConfirmPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	state := ConfirmationState{LastValidBlockHeight: lastValidBlockHeight}
+	for {
+		res, err := sc.GetSignatureStatuses(ctx, []common.Signature{signature})
+		if err != nil {
+			return state.Status, err
+		}
+		if len(res.SignatureStatus) > 0 {
+			state.Status = res.SignatureStatus[0]
+		}
+		if state.Status != nil && len(state.Status.Err) > 0 && string(state.Status.Err) != "null" {
+			if insErr, ok := types.ParseInstructionError(state.Status.Err); ok {
+				return state.Status, fmt.Errorf("transaction %s failed: %w", signature, insErr)
+			}
+			return state.Status, fmt.Errorf("transaction %s failed: %s", signature, state.Status.Err)
+		}
+		if lastValidBlockHeight > 0 {
+			if state.BlockHeight, err = sc.GetBlockHeight(ctx); err != nil {
+				return state.Status, err
+			}
+		}
+		done, err := strategy.Done(state)
+		if err != nil || done {
+			return state.Status, err
+		}
+		select {
+		case <-ctx.Done():
+			return state.Status, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// SendAndConfirmTransaction sends the signed transaction then confirms it with the strategy,
+// see ConfirmTransaction. Returns the transaction signature
+func (sc *Client) SendAndConfirmTransaction(ctx context.Context, signedTx common.Base58, lastValidBlockHeight uint64, strategy ConfirmationStrategy, cfg ...types.RpcSendTxCfg) (common.Signature, error) {
+	signature, err := sc.SendTransaction(ctx, signedTx, cfg...)
+	if err != nil {
+		return signature, err
+	}
+	_, err = sc.ConfirmTransaction(ctx, signature, lastValidBlockHeight, strategy)
+	return signature, err
+}
+
+// maxSendRebuilds the number of times SendAllAndConfirm rebuilds a transaction with an expired blockhash
+const maxSendRebuilds = 3
+
+// SendResult the result of a transaction of SendAllAndConfirm
+type SendResult struct {
+	// Signature of the last sent transaction
+	Signature common.Signature
+	// Status of the transaction, nil until it is found
+	Status *types.SignatureStatus
+	// The number of times the transaction was rebuilt with a new blockhash
+	Rebuilds int
+	// Error of the transaction, nil once it reached the commitment
+	Err error
+}
+
+// SendAllAndConfirm sends the signed transactions, at most maxInFlight at once, and confirms each until the
+// commitment. A transaction whose blockhash expired before it landed is rebuilt in place with the latest
+// blockhash, signed again with the signers and resent, up to 3 times. Without the signers it fails with
+// ErrBlockhashExpired. The results are in the order of the transactions, the error is only returned for
+// invalid arguments or when the context is done
+func (sc *Client) SendAllAndConfirm(ctx context.Context, txs []*types.Transaction, maxInFlight int, commitment types.EnumRpcCommitment, signers ...crypto.Signer) ([]SendResult, error) {
+	if maxInFlight <= 0 {
+		return nil, errors.New("invalid maxInFlight. Require: > 0")
+	}
+	if commitmentLevel(commitment) == 0 {
+		return nil, errors.New("invalid commitment. Require: [processed|confirmed|finalized]")
+	}
+	var (
+		wg      sync.WaitGroup
+		jobs    = make(chan int)
+		sent    = make([]bool, len(txs))
+		results = make([]SendResult, len(txs))
+	)
+	for i := 0; i < maxInFlight && i < len(txs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				results[idx] = sc.sendAndConfirmRebuilding(ctx, txs[idx], commitment, signers)
+			}
+		}()
+	}
+feed:
+	for idx := range txs {
+		select {
+		case jobs <- idx:
+			sent[idx] = true
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		for idx := range results {
+			if !sent[idx] {
+				results[idx].Err = err
+			}
+		}
+		return results, err
+	}
+	return results, nil
+}
+
+// sendAndConfirmRebuilding sends and confirms the transaction, rebuilt with the latest blockhash when it expired
+func (sc *Client) sendAndConfirmRebuilding(ctx context.Context, tx *types.Transaction, commitment types.EnumRpcCommitment, signers []crypto.Signer) (res SendResult) {
+	var lastValidBlockHeight uint64
+	for {
+		raw, err := tx.MarshalBinary()
+		if err != nil {
+			res.Err = err
+			return
+		}
+		res.Signature, err = sc.SendTransaction(ctx, raw, types.RpcSendTxCfg{PreflightCommitment: string(commitment)})
+		switch {
+		case err == nil:
+			strategy := sc.untilCommitmentOrExpired(ctx, tx.Message.RecentBlockhash, commitment)
+			res.Status, err = sc.ConfirmTransaction(ctx, res.Signature, lastValidBlockHeight, strategy)
+			// the transaction may have landed since its status was polled
+			if errors.Is(err, ErrBlockhashExpired) {
+				if statuses, e := sc.GetSignatureStatuses(ctx, []common.Signature{res.Signature}); e == nil && len(statuses.SignatureStatus) > 0 && statuses.SignatureStatus[0] != nil {
+					res.Status, err = sc.ConfirmTransaction(ctx, res.Signature, 0, UntilCommitment(commitment))
+				}
+			}
+		case IsBlockhashNotFound(err):
+			// rejected by the preflight, the transaction was not sent
+			err = ErrBlockhashExpired
+		}
+		if !errors.Is(err, ErrBlockhashExpired) || len(signers) == 0 || res.Rebuilds == maxSendRebuilds {
+			res.Err = err
+			return
+		}
+		latest, err := sc.GetLatestBlockhash(ctx, types.RpcCommitmentWithMinSlotCfg{Commitment: commitment})
+		if err != nil {
+			res.Err = err
+			return
+		}
+		tx.Message.RecentBlockhash = latest.LastBlock.Blockhash
+		lastValidBlockHeight = latest.LastBlock.LastValidBlockHeight
+		tx.Signatures = nil
+		if _, err = tx.SignWith(signers); err != nil {
+			res.Err = err
+			return
+		}
+		res.Rebuilds++
+	}
+}
+
+// maxInvalidBlockhashPolls the number of polls a blockhash never seen valid is given before it is
+// considered expired, a blockhash fetched at processed may not be known yet at confirmed
+const maxInvalidBlockhashPolls = 10
+
+// untilCommitmentOrExpired done once the transaction reached the commitment, it fails with ErrBlockhashExpired
+// when the blockhash expires before the transaction is found: past the last valid block height when it is
+// known, else once isBlockhashValid turns false after the blockhash was seen valid, or stays false
+// for maxInvalidBlockhashPolls polls
+func (sc *Client) untilCommitmentOrExpired(ctx context.Context, blockhash common.Hash, commitment types.EnumRpcCommitment) ConfirmationStrategy {
+	var (
+		until        = UntilCommitment(commitment)
+		seenValid    bool
+		invalidPolls int
+	)
+	return ConfirmationStrategyFunc(func(state ConfirmationState) (bool, error) {
+		if state.Status != nil {
+			return until.Done(state)
+		}
+		if state.LastValidBlockHeight > 0 {
+			if state.BlockHeight > state.LastValidBlockHeight {
+				return false, ErrBlockhashExpired
+			}
+			return false, nil
+		}
+		valid, err := sc.IsBlockHashValid(ctx, blockhash, types.RpcCommitmentWithMinSlotCfg{Commitment: types.RpcCommitmentConfirmed})
+		if err != nil {
+			return false, err
+		}
+		if !valid {
+			invalidPolls++
+			if seenValid || invalidPolls >= maxInvalidBlockhashPolls {
+				return false, ErrBlockhashExpired
+			}
+		}
+		seenValid = seenValid || valid
+		return false, nil
+	})
+}
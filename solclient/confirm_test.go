@@ -0,0 +1,240 @@
+package solclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/crypto"
+	"github.com/cielu/go-solana/types"
+	"github.com/cielu/go-solana/types/native"
+)
+
+// mockStatus a getSignatureStatuses value, nil when the signature is not found
+func mockStatus(status string, confirmations interface{}, txErr interface{}) map[string]interface{} {
+	return map[string]interface{}{"slot": 100, "confirmations": confirmations, "err": txErr, "confirmationStatus": status}
+}
+
+// newStatusSequenceClient serves the statuses and block heights in sequence, the last ones repeat
+func newStatusSequenceClient(t *testing.T, statuses []interface{}, heights []uint64) (*Client, func() int) {
+	var (
+		mu    sync.Mutex
+		polls int
+	)
+	c := newMockClient(t, func(req mockRequest) (interface{}, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch req.Method {
+		case "getSignatureStatuses":
+			status := statuses[len(statuses)-1]
+			if polls < len(statuses) {
+				status = statuses[polls]
+			}
+			polls++
+			return map[string]interface{}{"context": map[string]interface{}{"slot": 100}, "value": []interface{}{status}}, nil
+		case "getBlockHeight":
+			index := polls - 1
+			if index >= len(heights) {
+				index = len(heights) - 1
+			}
+			return heights[index], nil
+		case "sendTransaction":
+			return common.Signature{1}.String(), nil
+		}
+		return nil, &mockError{Code: -32601, Message: "Method not found"}
+	})
+	c.SetConfirmPollInterval(time.Millisecond)
+	return c, func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return polls
+	}
+}
+
+func TestConfirmationStrategies(t *testing.T) {
+	sequence := []interface{}{
+		nil,
+		mockStatus("processed", 0, nil),
+		mockStatus("confirmed", 1, nil),
+		mockStatus("confirmed", 5, nil),
+		mockStatus("finalized", nil, nil),
+	}
+	tests := []struct {
+		name     string
+		strategy ConfirmationStrategy
+		polls    int
+		status   string
+	}{
+		{"processed", UntilCommitment(types.RpcCommitmentProcessed), 2, "processed"},
+		{"confirmed", UntilCommitment(types.RpcCommitmentConfirmed), 3, "confirmed"},
+		{"finalized", UntilCommitment(types.RpcCommitmentFinalized), 5, "finalized"},
+		{"confirmations", UntilConfirmations(3), 4, "confirmed"},
+		{"rooted confirmations", UntilConfirmations(32), 5, "finalized"},
+		{"finalized or expired", UntilFinalizedOrExpired(), 5, "finalized"},
+	}
+	for _, tt := range tests {
+		c, polls := newStatusSequenceClient(t, sequence, []uint64{1000})
+		status, err := c.ConfirmTransaction(context.Background(), common.Signature{1}, 1100, tt.strategy)
+		if err != nil {
+			t.Fatalf("%s: ConfirmTransaction Failed: %s", tt.name, err)
+		}
+		if polls() != tt.polls || status.ConfirmationStatus != tt.status {
+			t.Errorf("%s: ConfirmTransaction Err ==> Got %d polls %s, Want: %d %s", tt.name, polls(), status.ConfirmationStatus, tt.polls, tt.status)
+		}
+	}
+}
+
+func TestConfirmTransactionExpired(t *testing.T) {
+	// not found while the block height passes the last valid block height
+	c, polls := newStatusSequenceClient(t, []interface{}{nil}, []uint64{1098, 1100, 1101})
+	_, err := c.ConfirmTransaction(context.Background(), common.Signature{1}, 1100, UntilFinalizedOrExpired())
+	if !errors.Is(err, ErrBlockhashExpired) || polls() != 3 {
+		t.Errorf("ConfirmTransaction Err ==> Got %v after %d polls, Want: %s after 3", err, polls(), ErrBlockhashExpired)
+	}
+	// a landed transaction keeps waiting for finalization after the blockhash expired
+	c, _ = newStatusSequenceClient(t, []interface{}{mockStatus("confirmed", 1, nil), mockStatus("finalized", nil, nil)}, []uint64{1200})
+	if status, err := c.ConfirmTransaction(context.Background(), common.Signature{1}, 1100, UntilFinalizedOrExpired()); err != nil || status.ConfirmationStatus != "finalized" {
+		t.Errorf("ConfirmTransaction Err ==> Got %v, Want: finalized", err)
+	}
+	// the other strategies wait until the context is done
+	c, _ = newStatusSequenceClient(t, []interface{}{nil}, []uint64{1200})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err = c.ConfirmTransaction(ctx, common.Signature{1}, 1100, UntilCommitment(types.RpcCommitmentConfirmed)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("ConfirmTransaction Err ==> Got %v, Want: %s", err, context.DeadlineExceeded)
+	}
+}
+
+func TestSendAndConfirmTransaction(t *testing.T) {
+	// the transaction failed
+	c, polls := newStatusSequenceClient(t, []interface{}{nil, mockStatus("processed", 0, map[string]interface{}{"InstructionError": []interface{}{0, "InvalidAccountData"}})}, []uint64{1000})
+	signature, err := c.SendAndConfirmTransaction(context.Background(), common.Base58{1, 2, 3}, 0, UntilCommitment(types.RpcCommitmentConfirmed))
+	if err == nil || signature != (common.Signature{1}) || polls() != 2 {
+		t.Errorf("SendAndConfirmTransaction Err ==> Got %s %v after %d polls, Want: failed transaction", signature, err, polls())
+	}
+	var insErr *types.InstructionError
+	if !errors.As(err, &insErr) || insErr.Index != 0 || insErr.Name() != "InvalidAccountData" {
+		t.Errorf("InstructionError Err ==> Got %v, Want: instruction 0 InvalidAccountData", err)
+	}
+	if got, ok := TxInstructionError(err); !ok || got != insErr {
+		t.Errorf("TxInstructionError Err ==> Got %v, %v, Want: %v", got, ok, insErr)
+	}
+	// a custom strategy
+	seen := 0
+	strategy := ConfirmationStrategyFunc(func(state ConfirmationState) (bool, error) {
+		seen++
+		if state.BlockHeight != 0 || state.LastValidBlockHeight != 0 {
+			t.Errorf("ConfirmationState Err ==> Got %+v, Want: no block height", state)
+		}
+		return state.Status != nil, nil
+	})
+	c, _ = newStatusSequenceClient(t, []interface{}{nil, nil, mockStatus("processed", 0, nil)}, []uint64{1000})
+	if _, err = c.SendAndConfirmTransaction(context.Background(), common.Base58{1, 2, 3}, 0, strategy); err != nil || seen != 3 {
+		t.Errorf("SendAndConfirmTransaction Err ==> Got %v after %d polls, Want: 3", err, seen)
+	}
+}
+
+func TestSendAllAndConfirm(t *testing.T) {
+	var (
+		mu         sync.Mutex
+		latest     = common.Hash{9}
+		blockhashs = map[common.Signature]common.Hash{}
+		validPolls = map[common.Hash]int{}
+	)
+	c := newMockClient(t, func(req mockRequest) (interface{}, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		var params []json.RawMessage
+		_ = json.Unmarshal(req.Params, &params)
+		switch req.Method {
+		case "sendTransaction":
+			var (
+				raw string
+				tx  types.Transaction
+			)
+			_ = json.Unmarshal(params[0], &raw)
+			if err := tx.UnmarshalBase58(raw); err != nil {
+				return nil, err
+			}
+			// the blockhash {3} is unknown to the preflight
+			if tx.Message.RecentBlockhash == (common.Hash{3}) {
+				return nil, &mockError{Code: -32002, Message: "Transaction simulation failed: Blockhash not found", Data: map[string]interface{}{"err": "BlockhashNotFound", "logs": []string{}}}
+			}
+			blockhashs[tx.Signatures[0]] = tx.Message.RecentBlockhash
+			return tx.Signatures[0].String(), nil
+		case "getSignatureStatuses":
+			var signatures []common.Signature
+			_ = json.Unmarshal(params[0], &signatures)
+			// the blockhashes {4} and {5} never land
+			var status interface{} = mockStatus("confirmed", 1, nil)
+			if blockhashs[signatures[0]] == (common.Hash{4}) || blockhashs[signatures[0]] == (common.Hash{5}) {
+				status = nil
+			}
+			return map[string]interface{}{"context": map[string]interface{}{"slot": 100}, "value": []interface{}{status}}, nil
+		case "isBlockhashValid":
+			var blockhash common.Hash
+			_ = json.Unmarshal(params[0], &blockhash)
+			validPolls[blockhash]++
+			// the blockhash {5} is never valid
+			valid := validPolls[blockhash] < 3 && blockhash != common.Hash{5}
+			return map[string]interface{}{"context": map[string]interface{}{"slot": 100}, "value": valid}, nil
+		case "getLatestBlockhash":
+			return map[string]interface{}{"context": map[string]interface{}{"slot": 100}, "value": map[string]interface{}{"blockhash": latest.String(), "lastValidBlockHeight": 1100}}, nil
+		case "getBlockHeight":
+			return 1000, nil
+		}
+		return nil, &mockError{Code: -32601, Message: "Method not found"}
+	})
+	c.SetConfirmPollInterval(time.Millisecond)
+
+	payer, err := crypto.GenerateAccount()
+	if err != nil {
+		t.Fatalf("GenerateAccount Failed: %s", err)
+	}
+	newTx := func(blockhash common.Hash) *types.Transaction {
+		tx, err := types.NewTransaction([]types.Instruction{native.NewTransferInstruction(payer.Address, common.Address{1}, 1).Build()}, blockhash, payer.Address)
+		if err != nil {
+			t.Fatalf("NewTransaction Failed: %s", err)
+		}
+		if _, err = tx.Sign([]crypto.Account{payer}); err != nil {
+			t.Fatalf("Sign Failed: %s", err)
+		}
+		return tx
+	}
+	txs := []*types.Transaction{newTx(common.Hash{1}), newTx(common.Hash{2}), newTx(common.Hash{3}), newTx(common.Hash{4}), newTx(common.Hash{5})}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	results, err := c.SendAllAndConfirm(ctx, txs, 2, types.RpcCommitmentConfirmed, payer)
+	if err != nil {
+		t.Fatalf("SendAllAndConfirm Failed: %s", err)
+	}
+	wantRebuilds := []int{0, 0, 1, 1, 1}
+	for i, res := range results {
+		if res.Err != nil || res.Status == nil || res.Status.ConfirmationStatus != "confirmed" {
+			t.Errorf("SendAllAndConfirm[%d] Err ==> Got %+v, Want: confirmed", i, res)
+		}
+		if res.Rebuilds != wantRebuilds[i] || res.Signature != txs[i].Signatures[0] {
+			t.Errorf("SendAllAndConfirm[%d] Err ==> Got %d rebuilds %s, Want: %d %s", i, res.Rebuilds, res.Signature, wantRebuilds[i], txs[i].Signatures[0])
+		}
+	}
+	for _, tx := range txs[2:] {
+		if tx.Message.RecentBlockhash != latest {
+			t.Errorf("SendAllAndConfirm Err ==> Got blockhash %s, Want: %s", tx.Message.RecentBlockhash, latest)
+		}
+	}
+	// the expired transactions are not rebuilt without the signers
+	results, err = c.SendAllAndConfirm(context.Background(), []*types.Transaction{newTx(common.Hash{1}), newTx(common.Hash{3})}, 1, types.RpcCommitmentConfirmed)
+	if err != nil {
+		t.Fatalf("SendAllAndConfirm Failed: %s", err)
+	}
+	if results[0].Err != nil || !errors.Is(results[1].Err, ErrBlockhashExpired) || results[1].Rebuilds != 0 {
+		t.Errorf("SendAllAndConfirm Err ==> Got %v %v, Want: nil %s", results[0].Err, results[1].Err, ErrBlockhashExpired)
+	}
+	if _, err = c.SendAllAndConfirm(context.Background(), txs, 0, types.RpcCommitmentConfirmed); err == nil {
+		t.Errorf("SendAllAndConfirm Err ==> Got nil, Want: invalid maxInFlight")
+	}
+}
@@ -0,0 +1,114 @@
+package solclient
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/cielu/go-solana/rpc"
+	"github.com/cielu/go-solana/types"
+)
+
+// JSON-RPC error codes returned by the solana rpc
+const (
+	ErrCodeBlockCleanedUp                  = -32001
+	ErrCodeTxSimulationFailed              = -32002
+	ErrCodeTxSignatureVerificationFailure  = -32003
+	ErrCodeBlockNotAvailable               = -32004
+	ErrCodeNodeUnhealthy                   = -32005
+	ErrCodeTxPrecompileVerificationFailure = -32006
+	ErrCodeSlotSkipped                     = -32007
+	ErrCodeNoSnapshot                      = -32008
+	ErrCodeLongTermStorageSlotSkipped      = -32009
+	ErrCodeKeyExcludedFromSecondaryIndex   = -32010
+	ErrCodeTxHistoryNotAvailable           = -32011
+	ErrCodeScanError                       = -32012
+	ErrCodeTxSignatureLenMismatch          = -32013
+	ErrCodeBlockStatusNotAvailableYet      = -32014
+	ErrCodeUnsupportedTransactionVersion   = -32015
+	ErrCodeMinContextSlotNotReached        = -32016
+	ErrCodeInvalidParams                   = -32602
+)
+
+// ErrorCode returns the JSON-RPC error code of err, ok is false when err is not an rpc error
+func ErrorCode(err error) (int, bool) {
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) {
+		return 0, false
+	}
+	return rpcErr.ErrorCode(), true
+}
+
+func hasErrorCode(err error, codes ...int) bool {
+	code, ok := ErrorCode(err)
+	if !ok {
+		return false
+	}
+	for _, c := range codes {
+		if code == c {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNodeBehind reports whether err is the -32005 error of a node behind the cluster,
+// SlotsBehind returns the slot distance
+func IsNodeBehind(err error) bool {
+	return hasErrorCode(err, ErrCodeNodeUnhealthy)
+}
+
+// IsSlotSkipped reports whether there is no block for the requested slot:
+// -32007 slot skipped or -32009 slot missing in long-term storage
+func IsSlotSkipped(err error) bool {
+	return hasErrorCode(err, ErrCodeSlotSkipped, ErrCodeLongTermStorageSlotSkipped)
+}
+
+// IsTxSimulationFailed reports whether err is the -32002 preflight simulation failure of sendTransaction
+func IsTxSimulationFailed(err error) bool {
+	return hasErrorCode(err, ErrCodeTxSimulationFailed)
+}
+
+// IsBlockhashNotFound reports whether err is the -32002 preflight failure of a transaction
+// whose blockhash is unknown or expired, the transaction was not sent
+func IsBlockhashNotFound(err error) bool {
+	res, ok := TxSimulationResult(err)
+	return ok && string(res.Err) == `"BlockhashNotFound"`
+}
+
+// IsMinContextSlotNotReached reports whether err is the -32016 error of a node not yet at the minContextSlot
+func IsMinContextSlotNotReached(err error) bool {
+	return hasErrorCode(err, ErrCodeMinContextSlotNotReached)
+}
+
+// IsInvalidParams reports whether err is the -32602 invalid params error
+func IsInvalidParams(err error) bool {
+	return hasErrorCode(err, ErrCodeInvalidParams)
+}
+
+// TxSimulationResult returns the simulation result carried by a -32002 error data:
+// the transaction error, the logs and the consumed compute units
+func TxSimulationResult(err error) (*types.SimulateTxResult, bool) {
+	var rpcErr *rpc.RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != ErrCodeTxSimulationFailed || len(rpcErr.Data) == 0 {
+		return nil, false
+	}
+	var res types.SimulateTxResult
+	if json.Unmarshal(rpcErr.Data, &res) != nil {
+		return nil, false
+	}
+	return &res, true
+}
+
+// TxInstructionError returns the failed instruction of a -32002 simulation failure
+// or of a transaction failed on chain, as returned by ConfirmTransaction
+func TxInstructionError(err error) (*types.InstructionError, bool) {
+	var insErr *types.InstructionError
+	if errors.As(err, &insErr) {
+		return insErr, true
+	}
+	res, ok := TxSimulationResult(err)
+	if !ok {
+		return nil, false
+	}
+	return types.ParseInstructionError(res.Err)
+}
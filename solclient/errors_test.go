@@ -0,0 +1,100 @@
+package solclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/cielu/go-solana/rpc"
+)
+
+// captured error responses of the solana rpc
+var capturedRPCErrors = map[string]string{
+	"getHealth": `{"code":-32005,"message":"Node is behind by 42 slots","data":{"numSlotsBehind":42}}`,
+	"getBlock":  `{"code":-32007,"message":"Slot 1000 was skipped, or missing due to ledger jump to recent snapshot"}`,
+	"getSlot":   `{"code":-32602,"message":"Invalid params: invalid type: string \"a\", expected u64."}`,
+	"sendTransaction": `{"code":-32002,"message":"Transaction simulation failed: Error processing Instruction 1: custom program error: 0x1",` +
+		`"data":{"accounts":null,"err":{"InstructionError":[1,{"Custom":1}]},"logs":["Program 11111111111111111111111111111111 invoke [1]",` +
+		`"Transfer: insufficient lamports 0, need 1000","Program 11111111111111111111111111111111 failed: custom program error: 0x1"],` +
+		`"returnData":null,"unitsConsumed":150}}`,
+	"simulateTransaction": `{"code":-32002,"message":"Transaction simulation failed: Blockhash not found","data":{"err":"BlockhashNotFound","logs":[],"unitsConsumed":0}}`,
+}
+
+func callCaptured(t *testing.T, method string) error {
+	sc := newMockClient(t, func(req mockRequest) (interface{}, error) {
+		var me mockError
+		if err := json.Unmarshal([]byte(capturedRPCErrors[req.Method]), &me); err != nil {
+			t.Fatalf("decode captured error Failed: %s", err)
+		}
+		return nil, &me
+	})
+	var res json.RawMessage
+	return sc.c.CallContext(context.Background(), &res, method)
+}
+
+func TestRPCError(t *testing.T) {
+	err := callCaptured(t, "getHealth")
+	var rpcErr *rpc.RPCError
+	if !errors.As(err, &rpcErr) {
+		t.Fatalf("RPCError Err ==> Got %T, Want: *rpc.RPCError", err)
+	}
+	if rpcErr.Code != ErrCodeNodeUnhealthy || rpcErr.Message != "Node is behind by 42 slots" {
+		t.Errorf("RPCError Err ==> Got %d %s, Want: %d", rpcErr.Code, rpcErr.Message, ErrCodeNodeUnhealthy)
+	}
+	if string(rpcErr.Data) != `{"numSlotsBehind":42}` {
+		t.Errorf("RPCError.Data Err ==> Got %s", rpcErr.Data)
+	}
+	if !IsNodeBehind(err) || IsSlotSkipped(err) || IsTxSimulationFailed(err) {
+		t.Errorf("IsNodeBehind Err ==> Got false, Want: true")
+	}
+	if n, ok := SlotsBehind(err); !ok || n != 42 {
+		t.Errorf("SlotsBehind Err ==> Got %d, Want: 42", n)
+	}
+
+	if err = callCaptured(t, "getBlock"); !IsSlotSkipped(err) || IsNodeBehind(err) {
+		t.Errorf("IsSlotSkipped Err ==> Got false, Want: true")
+	}
+	if err = callCaptured(t, "getSlot"); !IsInvalidParams(err) {
+		t.Errorf("IsInvalidParams Err ==> Got false, Want: true")
+	}
+	if IsNodeBehind(nil) || IsSlotSkipped(errors.New("Slot skipped")) {
+		t.Errorf("non rpc error matched")
+	}
+}
+
+func TestTxSimulationFailed(t *testing.T) {
+	err := callCaptured(t, "sendTransaction")
+	if !IsTxSimulationFailed(err) {
+		t.Fatalf("IsTxSimulationFailed Err ==> Got false, Want: true")
+	}
+	res, ok := TxSimulationResult(err)
+	if !ok {
+		t.Fatalf("TxSimulationResult Failed: %s", err)
+	}
+	if len(res.Logs) != 3 || res.UnitsConsumed == nil || *res.UnitsConsumed != 150 {
+		t.Errorf("TxSimulationResult Err ==> Got %+v", res)
+	}
+	insErr, ok := TxInstructionError(err)
+	if !ok {
+		t.Fatalf("TxInstructionError Failed: %s", res.Err)
+	}
+	code, ok := insErr.CustomCode()
+	if insErr.Index != 1 || !ok || code != 1 || insErr.Name() != "Custom" {
+		t.Errorf("TxInstructionError Err ==> Got %d %s, Want: 1 Custom(1)", insErr.Index, insErr.Err)
+	}
+	if insErr.Error() != "instruction 1 failed: custom program error: 0x1" {
+		t.Errorf("InstructionError.Error Err ==> Got %s", insErr.Error())
+	}
+
+	err = callCaptured(t, "simulateTransaction")
+	if res, ok = TxSimulationResult(err); !ok || string(res.Err) != `"BlockhashNotFound"` {
+		t.Errorf("TxSimulationResult Err ==> Got %v", res)
+	}
+	if _, ok = TxInstructionError(err); ok {
+		t.Errorf("TxInstructionError Err ==> Got true, Want: false")
+	}
+	if !IsBlockhashNotFound(err) || IsBlockhashNotFound(callCaptured(t, "sendTransaction")) {
+		t.Errorf("IsBlockhashNotFound Err ==> Got false, Want: true")
+	}
+}
@@ -0,0 +1,94 @@
+package solclient
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cielu/go-solana/rpc"
+)
+
+type mockRequest struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+type mockError struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+func (e *mockError) Error() string { return e.Message }
+
+type mockResponse struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *mockError      `json:"error,omitempty"`
+}
+
+// mockHandler returns the result of the request, or a *mockError
+type mockHandler func(req mockRequest) (interface{}, error)
+
+func newMockServer(t *testing.T, handle mockHandler) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("read request body failed: %s", err)
+			return
+		}
+		respond := func(req mockRequest) mockResponse {
+			resp := mockResponse{Version: "2.0", ID: req.ID}
+			res, err := handle(req)
+			if err != nil {
+				if me, ok := err.(*mockError); ok {
+					resp.Error = me
+				} else {
+					resp.Error = &mockError{Code: -32603, Message: err.Error()}
+				}
+				return resp
+			}
+			if res == nil {
+				res = json.RawMessage("null")
+			}
+			resp.Result = res
+			return resp
+		}
+		w.Header().Set("Content-Type", "application/json")
+		// batch request
+		if len(body) > 0 && body[0] == '[' {
+			var reqs []mockRequest
+			if err = json.Unmarshal(body, &reqs); err != nil {
+				t.Errorf("decode batch request failed: %s", err)
+				return
+			}
+			resps := make([]mockResponse, len(reqs))
+			for i, req := range reqs {
+				resps[i] = respond(req)
+			}
+			json.NewEncoder(w).Encode(resps)
+			return
+		}
+		var req mockRequest
+		if err = json.Unmarshal(body, &req); err != nil {
+			t.Errorf("decode request failed: %s", err)
+			return
+		}
+		json.NewEncoder(w).Encode(respond(req))
+	}))
+}
+
+// newMockClient dial a client against a mock rpc server
+func newMockClient(t *testing.T, handle mockHandler) *Client {
+	srv := newMockServer(t, handle)
+	t.Cleanup(srv.Close)
+	c, err := rpc.Dial(srv.URL)
+	if err != nil {
+		t.Fatalf("Dial mock server failed: %s", err)
+	}
+	return NewClient(c)
+}
@@ -0,0 +1,106 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package solclient
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cielu/go-solana/types/token"
+)
+
+const (
+	// DefaultRentExemptionTTL how long the client keeps the rent exemptions, the rent rarely changes
+	DefaultRentExemptionTTL = 10 * time.Minute
+)
+
+// RentExemptionCache memoizes getMinimumBalanceForRentExemption by data length for a TTL,
+// it is safe for concurrent use
+type RentExemptionCache struct {
+	sc      *Client
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[uint64]rentExemption
+	pending map[uint64]*rentRequest
+}
+
+type rentExemption struct {
+	lamports uint64
+	expires  time.Time
+}
+
+// rentRequest a getMinimumBalanceForRentExemption in flight, done is closed once it completes.
+// canceled reports the request ended because the context of its sender was done
+type rentRequest struct {
+	done     chan struct{}
+	lamports uint64
+	err      error
+	canceled bool
+}
+
+// NewRentExemptionCache creates a cache of the rent exemptions fetched by the client
+func NewRentExemptionCache(sc *Client, ttl time.Duration) *RentExemptionCache {
+	return &RentExemptionCache{
+		sc:      sc,
+		ttl:     ttl,
+		entries: make(map[uint64]rentExemption),
+		pending: make(map[uint64]*rentRequest),
+	}
+}
+
+// MinimumBalance Returns the minimum balance of an account of dataLen bytes to be rent exempt,
+// the rpc is called when the data length is not cached or expired. Concurrent calls wait
+// for the pending request instead of sending their own, the cache is not locked during the rpc.
+// When the pending request is canceled by its sender, the waiters send it again
+func (rc *RentExemptionCache) MinimumBalance(ctx context.Context, dataLen uint64) (uint64, error) {
+	for {
+		rc.mu.Lock()
+		if entry, ok := rc.entries[dataLen]; ok && time.Now().Before(entry.expires) {
+			rc.mu.Unlock()
+			return entry.lamports, nil
+		}
+		req, ok := rc.pending[dataLen]
+		if !ok {
+			req = &rentRequest{done: make(chan struct{})}
+			rc.pending[dataLen] = req
+			rc.mu.Unlock()
+			return rc.fetch(ctx, dataLen, req)
+		}
+		rc.mu.Unlock()
+		select {
+		case <-req.done:
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+		if !req.canceled || ctx.Err() != nil {
+			return req.lamports, req.err
+		}
+	}
+}
+
+// fetch sends the pending request of dataLen and caches its result
+func (rc *RentExemptionCache) fetch(ctx context.Context, dataLen uint64, req *rentRequest) (uint64, error) {
+	req.lamports, req.err = rc.sc.GetMinimumBalanceForRentExemption(ctx, dataLen)
+	req.canceled = req.err != nil && ctx.Err() != nil
+
+	rc.mu.Lock()
+	delete(rc.pending, dataLen)
+	if req.err == nil {
+		rc.entries[dataLen] = rentExemption{lamports: req.lamports, expires: time.Now().Add(rc.ttl)}
+	}
+	rc.mu.Unlock()
+	close(req.done)
+	return req.lamports, req.err
+}
+
+// MinBalanceForMint Returns the minimum balance of a token mint to be rent exempt, cached by the client
+func (sc *Client) MinBalanceForMint(ctx context.Context) (uint64, error) {
+	return sc.rentCache.MinimumBalance(ctx, token.MINT_SIZE)
+}
+
+// MinBalanceForTokenAccount Returns the minimum balance of a token account to be rent exempt, cached by the client
+func (sc *Client) MinBalanceForTokenAccount(ctx context.Context) (uint64, error) {
+	return sc.rentCache.MinimumBalance(ctx, token.ACCOUNT_SIZE)
+}
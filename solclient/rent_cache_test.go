@@ -0,0 +1,130 @@
+package solclient
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRentExemptionCache(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		calls   = make(map[uint64]int)
+		release = make(chan struct{})
+		stuck   = make(chan struct{})
+	)
+	c := newMockClient(t, func(req mockRequest) (interface{}, error) {
+		var params []uint64
+		if err := json.Unmarshal(req.Params, &params); err != nil || len(params) == 0 {
+			t.Errorf("params Err ==> Got %s", req.Params)
+			return nil, nil
+		}
+		mu.Lock()
+		calls[params[0]]++
+		first := calls[params[0]] == 1
+		mu.Unlock()
+		switch {
+		case params[0] == 300:
+			<-release
+		case params[0] == 500 && first:
+			<-stuck
+		}
+		return (params[0] + 128) * 6960, nil
+	})
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if lamports, err := c.MinBalanceForMint(ctx); err != nil || lamports != 1461600 {
+				t.Errorf("MinBalanceForMint Err ==> Got %d, %v, Want: %d", lamports, err, 1461600)
+			}
+			if lamports, err := c.MinBalanceForTokenAccount(ctx); err != nil || lamports != 2039280 {
+				t.Errorf("MinBalanceForTokenAccount Err ==> Got %d, %v, Want: %d", lamports, err, 2039280)
+			}
+		}()
+	}
+	wg.Wait()
+	if calls[82] != 1 || calls[165] != 1 {
+		t.Errorf("calls Err ==> Got %v, Want one per size", calls)
+	}
+
+	// expired entries are fetched again
+	cache := NewRentExemptionCache(c, 50*time.Millisecond)
+	for i := 0; i < 3; i++ {
+		if _, err := cache.MinimumBalance(ctx, 200); err != nil {
+			t.Fatalf("MinimumBalance Failed: %s", err)
+		}
+	}
+	time.Sleep(60 * time.Millisecond)
+	if _, err := cache.MinimumBalance(ctx, 200); err != nil {
+		t.Fatalf("MinimumBalance Failed: %s", err)
+	}
+	if calls[200] != 2 {
+		t.Errorf("calls after TTL Err ==> Got %d, Want: %d", calls[200], 2)
+	}
+
+	// a pending request does not block the other data lengths
+	pending := make(chan error, 1)
+	go func() {
+		_, err := c.rentCache.MinimumBalance(ctx, 300)
+		pending <- err
+	}()
+	for sent := false; !sent; time.Sleep(time.Millisecond) {
+		mu.Lock()
+		sent = calls[300] == 1
+		mu.Unlock()
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, err := c.rentCache.MinimumBalance(ctx, 400); err != nil {
+			t.Errorf("MinimumBalance Failed: %s", err)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Errorf("MinimumBalance blocked by the pending request of another data length")
+	}
+	close(release)
+	if err := <-pending; err != nil {
+		t.Errorf("MinimumBalance Failed: %s", err)
+	}
+
+	// a waiter sends the request again when its sender cancels it
+	defer close(stuck)
+	sendCtx, cancel := context.WithCancel(ctx)
+	sent := make(chan error, 1)
+	go func() {
+		_, err := c.rentCache.MinimumBalance(sendCtx, 500)
+		sent <- err
+	}()
+	for sending := false; !sending; time.Sleep(time.Millisecond) {
+		mu.Lock()
+		sending = calls[500] == 1
+		mu.Unlock()
+	}
+	waited := make(chan struct{})
+	go func() {
+		defer close(waited)
+		if lamports, err := c.rentCache.MinimumBalance(ctx, 500); err != nil || lamports != 4370880 {
+			t.Errorf("MinimumBalance Err ==> Got %d, %v, Want: %d", lamports, err, 4370880)
+		}
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	if err := <-sent; err == nil {
+		t.Errorf("MinimumBalance Err ==> Got nil, Want: context canceled")
+	}
+	<-waited
+	mu.Lock()
+	defer mu.Unlock()
+	if calls[500] != 2 {
+		t.Errorf("calls after cancel Err ==> Got %d, Want: %d", calls[500], 2)
+	}
+}
@@ -0,0 +1,87 @@
+package solclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cielu/go-solana/types"
+	"github.com/gorilla/websocket"
+)
+
+// newDroppingWsServer serves slotSubscribe, sends perConn notifications and drops the connection
+func newDroppingWsServer(t *testing.T, perConn int, conns *int32) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	var slot uint64
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade websocket failed: %s", err)
+			return
+		}
+		defer conn.Close()
+		atomic.AddInt32(conns, 1)
+		var req mockRequest
+		if err = conn.ReadJSON(&req); err != nil {
+			return
+		}
+		if req.Method != "slotSubscribe" {
+			t.Errorf("request method Err ==> Got %s, Want: %s", req.Method, "slotSubscribe")
+			return
+		}
+		_ = conn.WriteJSON(mockResponse{Version: "2.0", ID: req.ID, Result: 7})
+		for i := 0; i < perConn; i++ {
+			_ = conn.WriteJSON(map[string]interface{}{
+				"jsonrpc": "2.0",
+				"method":  "slotNotification",
+				"params": map[string]interface{}{
+					"subscription": 7,
+					"result":       types.SlotNotifies{Slot: atomic.AddUint64(&slot, 1)},
+				},
+			})
+		}
+	}))
+}
+
+func TestResubscribe(t *testing.T) {
+	var conns int32
+	srv := newDroppingWsServer(t, 2, &conns)
+	defer srv.Close()
+
+	c, err := DialContext(context.Background(), "ws"+strings.TrimPrefix(srv.URL, "http"))
+	if err != nil {
+		t.Fatalf("Dial websocket failed: %s", err)
+	}
+
+	ch := make(chan types.SlotNotifies)
+	sub := Resubscribe(100*time.Millisecond, func(ctx context.Context) (Subscription, error) {
+		return c.SlotSubscribe(ctx, ch)
+	})
+	defer sub.Unsubscribe()
+
+	seen := make(map[uint64]bool)
+	timeout := time.After(5 * time.Second)
+	for len(seen) < 6 {
+		select {
+		case n := <-ch:
+			seen[n.Slot] = true
+		case err := <-sub.Err():
+			t.Fatalf("subscription Failed: %v", err)
+		case <-timeout:
+			t.Fatalf("notifications Err ==> Got %d, Want: %d", len(seen), 6)
+		}
+	}
+	if got := atomic.LoadInt32(&conns); got < 3 {
+		t.Errorf("connections Err ==> Got %d, Want at least: %d", got, 3)
+	}
+	// closing the client ends the subscription without error
+	c.Close()
+	sub.Unsubscribe()
+	if _, ok := <-sub.Err(); ok {
+		t.Errorf("Err channel should be closed after Unsubscribe")
+	}
+}
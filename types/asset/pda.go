@@ -0,0 +1,21 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+// Package asset provides helpers for the asset executor program.
+package asset
+
+import (
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/types/base"
+)
+
+// AssetAccountSeed the seed prefix of the asset account PDA
+const AssetAccountSeed = "asset-account"
+
+// FindAssetAccount Returns the asset account of the administrator and its bump seed,
+// derived from the seeds ["asset-account", administrator] of the asset executor program
+func FindAssetAccount(administrator common.Address) (common.Address, uint8) {
+	// the bump search can not fail with two seeds
+	addr, bump, _ := base.FindProgramAddress([][]byte{[]byte(AssetAccountSeed), administrator.Bytes()}, base.AssetExecutorProgramID)
+	return addr, bump
+}
@@ -0,0 +1,18 @@
+package asset
+
+import (
+	"testing"
+
+	"github.com/cielu/go-solana/common"
+)
+
+func TestFindAssetAccount(t *testing.T) {
+	var (
+		administrator = common.StrToAddress("EfgnVEwyeeFLZyZ4nnnzZtqV6B3DhdtXFNsGSzdti9ZN")
+		want          = common.StrToAddress("5kBRux7wiA3p5SAHMAA12K2fbfegueLBNcZ4emwbnpbZ")
+	)
+	account, bump := FindAssetAccount(administrator)
+	if account != want || bump != 255 {
+		t.Errorf("FindAssetAccount Err ==> Got %s %d, Want: %s 255", account, bump, want)
+	}
+}
@@ -0,0 +1,13 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package asset
+
+import "github.com/cielu/go-solana/types/raydium"
+
+// QuoteRaydiumSwap Returns the pc amount out of a swap of amountIn coin against the reserves of a
+// Raydium AMM v4 pool, e.g. to set the minimum amount out of a swap. Swapping pc to coin, pass the
+// pc reserve as coinReserve and the coin reserve as pcReserve. See raydium.QuoteSwap
+func QuoteRaydiumSwap(coinReserve, pcReserve, amountIn, feeNumerator, feeDenominator uint64) uint64 {
+	return raydium.QuoteSwap(coinReserve, pcReserve, amountIn, feeNumerator, feeDenominator)
+}
@@ -0,0 +1,17 @@
+package asset
+
+import "testing"
+
+func TestQuoteRaydiumSwap(t *testing.T) {
+	// coin to pc, with the 0.25% fee of the AMM v4 pools
+	if got := QuoteRaydiumSwap(1_000_000_000, 2_000_000_000, 1_000_000, 25, 10000); got != 1993011 {
+		t.Errorf("QuoteRaydiumSwap Err ==> Got %d, Want: %d", got, 1993011)
+	}
+	// pc to coin, the reserves swapped
+	if got := QuoteRaydiumSwap(2_000_000_000, 1_000_000_000, 2_000_000, 25, 10000); got != 996505 {
+		t.Errorf("QuoteRaydiumSwap Err ==> Got %d, Want: %d", got, 996505)
+	}
+	if got := QuoteRaydiumSwap(0, 100, 100, 25, 10000); got != 0 {
+		t.Errorf("QuoteRaydiumSwap of an empty pool Err ==> Got %d, Want: 0", got)
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package asset
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/crypto"
+	"github.com/cielu/go-solana/types"
+)
+
+// TxSender fetches the latest blockhash and sends signed transactions, implemented by solclient.Client
+type TxSender interface {
+	GetLatestBlockhash(ctx context.Context, cfg ...types.RpcCommitmentWithMinSlotCfg) (types.LastBlockWithCtx, error)
+	SendTransaction(ctx context.Context, signedTx common.Base58, cfg ...types.RpcSendTxCfg) (common.Signature, error)
+}
+
+// SendInstruction builds a transaction of the instruction with the latest blockhash,
+// the signer pays the fee and signs it, then it is sent. Returns the transaction signature
+func SendInstruction(ctx context.Context, client TxSender, signer crypto.Account, ix types.Instruction) (common.Signature, error) {
+	latest, err := client.GetLatestBlockhash(ctx)
+	if err != nil {
+		return common.Signature{}, fmt.Errorf("unable to get latest blockhash: %w", err)
+	}
+	tx, err := types.NewTransaction([]types.Instruction{ix}, latest.LastBlock.Blockhash, signer.Address)
+	if err != nil {
+		return common.Signature{}, err
+	}
+	signedTx, err := tx.Sign([]crypto.Account{signer})
+	if err != nil {
+		return common.Signature{}, err
+	}
+	return client.SendTransaction(ctx, signedTx)
+}
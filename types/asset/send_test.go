@@ -0,0 +1,65 @@
+package asset
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/crypto"
+	"github.com/cielu/go-solana/types"
+	"github.com/cielu/go-solana/types/native"
+)
+
+type mockSender struct {
+	blockhash common.Hash
+	sent      []types.Transaction
+	err       error
+}
+
+func (m *mockSender) GetLatestBlockhash(ctx context.Context, cfg ...types.RpcCommitmentWithMinSlotCfg) (types.LastBlockWithCtx, error) {
+	return types.LastBlockWithCtx{LastBlock: types.LastBlock{Blockhash: m.blockhash, LastValidBlockHeight: 100}}, m.err
+}
+
+func (m *mockSender) SendTransaction(ctx context.Context, signedTx common.Base58, cfg ...types.RpcSendTxCfg) (common.Signature, error) {
+	var tx types.Transaction
+	if err := tx.UnmarshalBase58(signedTx.String()); err != nil {
+		return common.Signature{}, err
+	}
+	m.sent = append(m.sent, tx)
+	return tx.Signatures[0], nil
+}
+
+func TestSendInstruction(t *testing.T) {
+	signer, err := crypto.GenerateAccount()
+	if err != nil {
+		t.Fatalf("GenerateAccount Failed: %s", err)
+	}
+	var (
+		recipient = common.StrToAddress("6XViKPqw7t47tZz8UJR1bJFVzxjnQbuKtN2TBgnfZmo4")
+		sender    = &mockSender{blockhash: common.Base58ToHash("EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N")}
+		ix        = native.NewTransferInstruction(signer.Address, recipient, 1e6).Build()
+	)
+	sig, err := SendInstruction(context.Background(), sender, signer, ix)
+	if err != nil {
+		t.Fatalf("SendInstruction Failed: %s", err)
+	}
+	if len(sender.sent) != 1 {
+		t.Fatalf("SendTransaction Err ==> Got %d transactions, Want: 1", len(sender.sent))
+	}
+	tx := sender.sent[0]
+	if tx.Message.RecentBlockhash != sender.blockhash || tx.Message.AccountKeys[0] != signer.Address {
+		t.Errorf("Message Err ==> Got %s %s", tx.Message.RecentBlockhash, tx.Message.AccountKeys[0])
+	}
+	if len(tx.Signatures) != 1 || tx.Signatures[0] != sig {
+		t.Errorf("Signature Err ==> Got %v, Want: %s", tx.Signatures, sig)
+	}
+	if _, err = tx.VerifySignatures(); err != nil {
+		t.Errorf("VerifySignatures Failed: %s", err)
+	}
+
+	sender.err = errors.New("connection refused")
+	if _, err = SendInstruction(context.Background(), sender, signer, ix); !errors.Is(err, sender.err) {
+		t.Errorf("SendInstruction Err ==> Got %v, Want: %v", err, sender.err)
+	}
+}
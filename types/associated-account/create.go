@@ -75,20 +75,27 @@ func (inst *Create) SetTokenProgramID(tokenProgramID common.Address) *Create {
 	return inst
 }
 
-func (inst Create) Build() *Instruction {
+func (inst Create) Build(opts ...base.BuildOption) *Instruction {
+	inst.AccountMetaSlice = createAccounts(inst.Payer, inst.Wallet, inst.Mint, inst.TokenProgramID)
 
-	// Find the associatedTokenAddress;
-	associatedTokenAddress, _, _ := base.FindAssociatedTokenAddress(
-		inst.Wallet,
-		inst.Mint,
-	)
-	if inst.TokenProgramID == base.Token2022ProgramID {
-		associatedTokenAddress, _, _ = base.FindAssociatedTokenAddress(inst.Wallet, inst.Mint, base.Token2022ProgramID)
+	return withBuildOptions(&Instruction{BaseVariant: encodbin.BaseVariant{
+		Impl:   inst,
+		TypeID: encodbin.NoTypeIDDefaultID,
+	}}, opts)
+}
+
+// createAccounts builds the accounts shared by Create and CreateIdempotent,
+// the token program defaults to the classic SPL token program.
+func createAccounts(payer, wallet, mint, tokenProgramID common.Address) []*base.AccountMeta {
+	if tokenProgramID.IsEmpty() {
+		tokenProgramID = base.TokenProgramID
 	}
+	// Find the associatedTokenAddress;
+	associatedTokenAddress, _, _ := base.FindAssociatedTokenAddress(wallet, mint, tokenProgramID)
 
-	keys := []*base.AccountMeta{
+	return []*base.AccountMeta{
 		{
-			PublicKey:  inst.Payer,
+			PublicKey:  payer,
 			IsSigner:   true,
 			IsWritable: true,
 		},
@@ -98,12 +105,12 @@ func (inst Create) Build() *Instruction {
 			IsWritable: true,
 		},
 		{
-			PublicKey:  inst.Wallet,
+			PublicKey:  wallet,
 			IsSigner:   false,
 			IsWritable: false,
 		},
 		{
-			PublicKey:  inst.Mint,
+			PublicKey:  mint,
 			IsSigner:   false,
 			IsWritable: false,
 		},
@@ -113,7 +120,7 @@ func (inst Create) Build() *Instruction {
 			IsWritable: false,
 		},
 		{
-			PublicKey:  inst.TokenProgramID,
+			PublicKey:  tokenProgramID,
 			IsSigner:   false,
 			IsWritable: false,
 		},
@@ -123,27 +130,27 @@ func (inst Create) Build() *Instruction {
 			IsWritable: false,
 		},
 	}
-
-	inst.AccountMetaSlice = keys
-
-	return &Instruction{BaseVariant: encodbin.BaseVariant{
-		Impl:   inst,
-		TypeID: encodbin.NoTypeIDDefaultID,
-	}}
 }
 
 func (inst Create) MarshalWithEncoder(encoder *encodbin.Encoder) error {
 	return encoder.WriteBytes([]byte{}, false)
 }
 
+// NewCreateInstruction declares a new Create instruction,
+// tokenProgramID is optional and defaults to the classic SPL token program.
 func NewCreateInstruction(
 	payer common.Address,
 	walletAddress common.Address,
 	splTokenMintAddress common.Address,
+	tokenProgramID ...common.Address,
 ) *Create {
+	programID := base.TokenProgramID
+	if len(tokenProgramID) > 0 {
+		programID = tokenProgramID[0]
+	}
 	return NewCreateInstructionBuilder().
 		SetPayer(payer).
 		SetWallet(walletAddress).
 		SetMint(splTokenMintAddress).
-		SetTokenProgramID(base.TokenProgramID)
+		SetTokenProgramID(programID)
 }
@@ -0,0 +1,88 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package associatedaccount
+
+import (
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/types/base"
+)
+
+// CreateIdempotent creates an associated token account for the given wallet
+// address and token mint, it does not fail when the account already exists
+// and is owned by the wallet.
+type CreateIdempotent struct {
+	Payer          common.Address `bin:"-" borsh_skip:"true"`
+	Wallet         common.Address `bin:"-" borsh_skip:"true"`
+	Mint           common.Address `bin:"-" borsh_skip:"true"`
+	TokenProgramID common.Address `bin:"-" borsh_skip:"true"`
+
+	// Same accounts as Create:
+	// [0] = [WRITE, SIGNER] Payer
+	// [1] = [WRITE] AssociatedTokenAccount
+	// [2] = [] Wallet
+	// [3] = [] TokenMint
+	// [4] = [] SystemProgram
+	// [5] = [] TokenProgram
+	// [6] = [] SysVarRent
+	base.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewCreateIdempotentInstructionBuilder creates a new `CreateIdempotent` instruction builder.
+func NewCreateIdempotentInstructionBuilder() *CreateIdempotent {
+	nd := &CreateIdempotent{}
+	return nd
+}
+
+func (inst *CreateIdempotent) SetPayer(payer common.Address) *CreateIdempotent {
+	inst.Payer = payer
+	return inst
+}
+
+func (inst *CreateIdempotent) SetWallet(wallet common.Address) *CreateIdempotent {
+	inst.Wallet = wallet
+	return inst
+}
+
+func (inst *CreateIdempotent) SetMint(mint common.Address) *CreateIdempotent {
+	inst.Mint = mint
+	return inst
+}
+
+func (inst *CreateIdempotent) SetTokenProgramID(tokenProgramID common.Address) *CreateIdempotent {
+	inst.TokenProgramID = tokenProgramID
+	return inst
+}
+
+func (inst CreateIdempotent) Build(opts ...base.BuildOption) *Instruction {
+	inst.AccountMetaSlice = createAccounts(inst.Payer, inst.Wallet, inst.Mint, inst.TokenProgramID)
+
+	return withBuildOptions(&Instruction{BaseVariant: encodbin.BaseVariant{
+		Impl:   inst,
+		TypeID: encodbin.TypeIDFromUint8(Instruction_CreateIdempotent),
+	}}, opts)
+}
+
+func (inst CreateIdempotent) MarshalWithEncoder(encoder *encodbin.Encoder) error {
+	return encoder.WriteUint8(Instruction_CreateIdempotent)
+}
+
+// NewCreateIdempotentInstruction declares a new CreateIdempotent instruction,
+// tokenProgramID is optional and defaults to the classic SPL token program.
+func NewCreateIdempotentInstruction(
+	payer common.Address,
+	walletAddress common.Address,
+	splTokenMintAddress common.Address,
+	tokenProgramID ...common.Address,
+) *CreateIdempotent {
+	programID := base.TokenProgramID
+	if len(tokenProgramID) > 0 {
+		programID = tokenProgramID[0]
+	}
+	return NewCreateIdempotentInstructionBuilder().
+		SetPayer(payer).
+		SetWallet(walletAddress).
+		SetMint(splTokenMintAddress).
+		SetTokenProgramID(programID)
+}
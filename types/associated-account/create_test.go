@@ -0,0 +1,68 @@
+package associatedaccount
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/types/base"
+)
+
+func TestCreateIdempotent(t *testing.T) {
+	var (
+		payer  = common.Base58ToAddress("9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM")
+		wallet = common.Base58ToAddress("B8UwBUUnKwCyKuGMbFKWaG7exYdDk2ozZrPg72NyVbfj")
+		mint   = common.Base58ToAddress("7o36UsWR1JQLpZ9PE2gn9L4SQ69CNNiWAXd4Jt7rqz9Z")
+		ata    = common.Base58ToAddress("DShWnroshVbeUp28oopA3Pu7oFPDBtC1DBmPECXXAQ9n")
+	)
+	ata2022, _, err := base.FindAssociatedTokenAddress(wallet, mint, base.Token2022ProgramID)
+	if err != nil {
+		t.Fatalf("FindAssociatedTokenAddress Failed: %s", err)
+	}
+	if ata2022 == ata {
+		t.Fatalf("Token-2022 ATA should differ from the classic one")
+	}
+
+	tests := []struct {
+		name         string
+		inst         *Instruction
+		data         []byte
+		ata          common.Address
+		tokenProgram common.Address
+	}{
+		{"create", NewCreateInstruction(payer, wallet, mint).Build(), []byte{}, ata, base.TokenProgramID},
+		{"idempotent", NewCreateIdempotentInstruction(payer, wallet, mint).Build(), []byte{1}, ata, base.TokenProgramID},
+		{"idempotent 2022", NewCreateIdempotentInstruction(payer, wallet, mint, base.Token2022ProgramID).Build(), []byte{1}, ata2022, base.Token2022ProgramID},
+	}
+	for _, test := range tests {
+		if test.inst.ProgramID() != base.SPLAssociatedTokenAccountProgramID {
+			t.Errorf("%s: ProgramID Err ==> Got %s, Want: %s", test.name, test.inst.ProgramID(), base.SPLAssociatedTokenAccountProgramID)
+		}
+		data, err := test.inst.Data()
+		if err != nil {
+			t.Errorf("%s: Data Failed: %s", test.name, err)
+		}
+		if !bytes.Equal(data, test.data) {
+			t.Errorf("%s: Data Err ==> Got %v, Want: %v", test.name, data, test.data)
+		}
+		want := []*base.AccountMeta{
+			base.Meta(payer).WRITE().SIGNER(),
+			base.Meta(test.ata).WRITE(),
+			base.Meta(wallet),
+			base.Meta(mint),
+			base.Meta(base.SystemProgramID),
+			base.Meta(test.tokenProgram),
+			base.Meta(base.SysVarRentPubkey),
+		}
+		accounts := test.inst.Accounts()
+		if len(accounts) != len(want) {
+			t.Errorf("%s: Accounts length Err ==> Got %d, Want: %d", test.name, len(accounts), len(want))
+			continue
+		}
+		for i := range accounts {
+			if *accounts[i] != *want[i] {
+				t.Errorf("%s: Accounts[%d] Err ==> Got %v, Want: %v", test.name, i, accounts[i], want[i])
+			}
+		}
+	}
+}
@@ -15,16 +15,37 @@
 package associatedaccount
 
 import (
+	"bytes"
+	"fmt"
+
 	"github.com/cielu/go-solana/common"
 	"github.com/cielu/go-solana/pkg/encodbin"
 	"github.com/cielu/go-solana/types/base"
 )
 
+const (
+	// Instruction_Create creates an associated token account, fails if it already exists.
+	Instruction_Create uint8 = iota
+	// Instruction_CreateIdempotent creates an associated token account if it does not exist yet.
+	Instruction_CreateIdempotent
+)
+
 type Instruction struct {
 	encodbin.BaseVariant
+	// programID overrides base.SPLAssociatedTokenAccountProgramID when set, see base.WithProgramID
+	programID common.Address
+}
+
+// withBuildOptions applies the options of Build to the instruction
+func withBuildOptions(inst *Instruction, opts []base.BuildOption) *Instruction {
+	inst.programID = base.NewBuildOptions(opts...).ProgramID
+	return inst
 }
 
 func (inst *Instruction) ProgramID() common.Address {
+	if !inst.programID.IsEmpty() {
+		return inst.programID
+	}
 	return base.SPLAssociatedTokenAccountProgramID
 }
 
@@ -33,7 +54,11 @@ func (inst *Instruction) Accounts() (out []*base.AccountMeta) {
 }
 
 func (inst *Instruction) Data() ([]byte, error) {
-	return []byte{}, nil
+	buf := new(bytes.Buffer)
+	if err := encodbin.NewBinEncoder(buf).Encode(inst); err != nil {
+		return nil, fmt.Errorf("unable to encode instruction: %w", err)
+	}
+	return buf.Bytes(), nil
 }
 
 func (inst *Instruction) MarshalWithEncoder(encoder *encodbin.Encoder) error {
@@ -1,6 +1,28 @@
 package base
 
-import "github.com/cielu/go-solana/common"
+import (
+	"errors"
+
+	"github.com/cielu/go-solana/common"
+)
+
+// ErrMissingAccount matched by errors.Is on the error of an instruction Validate
+// when a required account is not set
+var ErrMissingAccount = errors.New("account is not set")
+
+// MissingAccountError the error of an instruction Validate naming the required account not set
+type MissingAccountError struct {
+	Account string
+}
+
+func (e *MissingAccountError) Error() string {
+	return "accounts." + e.Account + " is not set"
+}
+
+// Is reports whether target is ErrMissingAccount
+func (e *MissingAccountError) Is(target error) bool {
+	return target == ErrMissingAccount
+}
 
 type AccountsSettable interface {
 	SetAccounts(accounts []*AccountMeta) error
@@ -0,0 +1,29 @@
+package base
+
+import "github.com/cielu/go-solana/common"
+
+// BuildOption an option of the instruction builders' Build
+type BuildOption func(opts *BuildOptions)
+
+// BuildOptions the options of the instruction builders' Build
+type BuildOptions struct {
+	// ProgramID overrides the program the instruction targets, e.g. a proxy forwarding
+	// to the token program. The default program of the instruction when empty
+	ProgramID common.Address
+}
+
+// WithProgramID builds the instruction against the program instead of its default program
+func WithProgramID(programID common.Address) BuildOption {
+	return func(opts *BuildOptions) {
+		opts.ProgramID = programID
+	}
+}
+
+// NewBuildOptions applies the options in order
+func NewBuildOptions(opts ...BuildOption) BuildOptions {
+	var options BuildOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
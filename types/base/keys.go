@@ -3,32 +3,44 @@ package base
 import (
 	"crypto/sha256"
 	"errors"
+	"fmt"
 	"github.com/cielu/go-solana/common"
 	"math"
+	"runtime"
+	"sync"
 )
 
 const (
 	// Maximum length of derived pubkey seed.
 	MaxSeedLength = 32
-	// Maximum number of seeds.
+	// Maximum number of seeds (including the bump seed).
 	MaxSeeds = 16
 	// Number of bytes in a signature.
 )
 
 const PDA_MARKER = "ProgramDerivedAddress"
 
-var ErrMaxSeedLengthExceeded = errors.New("Max seed length exceeded")
+var (
+	ErrMaxSeedLengthExceeded = errors.New("Max seed length exceeded")
+	ErrMaxSeedsExceeded      = errors.New("Max seeds exceeded")
+	ErrInvalidSeeds          = errors.New("invalid seeds; address must fall off the curve")
+	ErrNoValidProgramAddress = errors.New("unable to find a valid program address")
+)
 
-// Create a program address.
+// CreateProgramAddress Create a program address with the seeds (the bump seed included).
+// Use it instead of FindProgramAddress when the bump is already known.
+// At most MaxSeeds(16) seeds are accepted and each seed is at most MaxSeedLength(32) bytes,
+// otherwise ErrMaxSeedsExceeded / ErrMaxSeedLengthExceeded is returned.
+// ErrInvalidSeeds is returned if the derived address falls on the ed25519 curve.
 // Ported from https://github.com/solana-labs/solana/blob/216983c50e0a618facc39aa07472ba6d23f1b33a/sdk/program/src/pubkey.rs#L204
 func CreateProgramAddress(seeds [][]byte, programID common.Address) (common.Address, error) {
 	if len(seeds) > MaxSeeds {
-		return common.Address{}, ErrMaxSeedLengthExceeded
+		return common.Address{}, fmt.Errorf("%w: got %d seeds, max %d", ErrMaxSeedsExceeded, len(seeds), MaxSeeds)
 	}
 
-	for _, seed := range seeds {
+	for i, seed := range seeds {
 		if len(seed) > MaxSeedLength {
-			return common.Address{}, ErrMaxSeedLengthExceeded
+			return common.Address{}, fmt.Errorf("%w: seeds[%d] has %d bytes, max %d", ErrMaxSeedLengthExceeded, i, len(seed), MaxSeedLength)
 		}
 	}
 
@@ -42,7 +54,7 @@ func CreateProgramAddress(seeds [][]byte, programID common.Address) (common.Addr
 	hash := sha256.Sum256(buf)
 
 	if IsOnCurve(hash[:]) {
-		return common.Address{}, errors.New("invalid seeds; address must fall off the curve")
+		return common.Address{}, ErrInvalidSeeds
 	}
 
 	return common.BytesToAddress(hash[:]), nil
@@ -134,23 +146,89 @@ func IsOnCurve(b []byte) bool {
 
 // Find a valid program address and its corresponding bump seed.
 func FindProgramAddress(seed [][]byte, programID common.Address) (common.Address, uint8, error) {
-	var address common.Address
-	var err error
-	bumpSeed := uint8(math.MaxUint8)
-	for bumpSeed != 0 {
-		address, err = CreateProgramAddress(append(seed, []byte{byte(bumpSeed)}), programID)
+	return FindProgramAddressWithBumpRange(seed, programID, math.MaxUint8, 1)
+}
+
+// FindProgramAddressWithBumpRange Find a valid program address, searching the bump seed
+// from maxBump down to minBump (both inclusive).
+func FindProgramAddressWithBumpRange(seed [][]byte, programID common.Address, maxBump, minBump uint8) (common.Address, uint8, error) {
+	if len(seed) >= MaxSeeds {
+		return common.Address{}, 0, fmt.Errorf("%w: got %d seeds, max %d without the bump seed", ErrMaxSeedsExceeded, len(seed), MaxSeeds-1)
+	}
+	// copy seeds, avoid appending the bump seed into the caller's slice
+	seeds := make([][]byte, len(seed), len(seed)+1)
+	copy(seeds, seed)
+	for bumpSeed := int(maxBump); bumpSeed >= int(minBump); bumpSeed-- {
+		address, err := CreateProgramAddress(append(seeds, []byte{byte(bumpSeed)}), programID)
 		if err == nil {
-			return address, bumpSeed, nil
+			return address, uint8(bumpSeed), nil
+		}
+		if !errors.Is(err, ErrInvalidSeeds) {
+			return common.Address{}, 0, err
 		}
-		bumpSeed--
 	}
-	return common.Address{}, bumpSeed, errors.New("unable to find a valid program address")
+	return common.Address{}, 0, ErrNoValidProgramAddress
+}
+
+// CreateWithSeed derive the address of an account created with the system
+// CreateAccountWithSeed instruction: sha256(base + seed + owner).
+// The system program rejects seeds longer than MaxSeedLength(32) bytes.
+func CreateWithSeed(base common.Address, seed string, owner common.Address) common.Address {
+	buf := make([]byte, 0, 64+len(seed))
+	buf = append(buf, base[:]...)
+	buf = append(buf, seed...)
+	buf = append(buf, owner[:]...)
+	hash := sha256.Sum256(buf)
+	return common.BytesToAddress(hash[:])
 }
 
 func FindAssociatedTokenAddress(wallet common.Address, mint common.Address, options ...common.Address) (common.Address, uint8, error) {
 	return FindAssociatedTokenAddressAndBumpSeed(wallet, mint, SPLAssociatedTokenAccountProgramID, options...)
 }
 
+// FindAssociatedTokenAddressWithProgram find the associated token account of the wallet for a mint
+// of the token program, TokenProgramID or Token2022ProgramID
+func FindAssociatedTokenAddressWithProgram(wallet common.Address, mint common.Address, tokenProgramID common.Address) (common.Address, uint8, error) {
+	return FindProgramAddress([][]byte{wallet[:], tokenProgramID[:], mint[:]}, SPLAssociatedTokenAccountProgramID)
+}
+
+// WalletMint a wallet and the mint of its associated token account
+type WalletMint struct {
+	Wallet common.Address
+	Mint   common.Address
+}
+
+// FindAssociatedTokenAddresses find the associated token accounts of many (wallet, mint) pairs,
+// in the order of pairs. The derivations run in parallel, one worker per CPU.
+// As FindAssociatedTokenAddress, options may select the Token2022ProgramID
+func FindAssociatedTokenAddresses(pairs []WalletMint, options ...common.Address) ([]common.Address, error) {
+	var (
+		addresses = make([]common.Address, len(pairs))
+		errs      = make([]error, len(pairs))
+		wg        sync.WaitGroup
+		workers   = runtime.GOMAXPROCS(0)
+	)
+	if workers > len(pairs) {
+		workers = len(pairs)
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < len(pairs); i += workers {
+				addresses[i], _, errs[i] = FindAssociatedTokenAddress(pairs[i].Wallet, pairs[i].Mint, options...)
+			}
+		}(w)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("associated token address of wallet %s mint %s: %w", pairs[i].Wallet, pairs[i].Mint, err)
+		}
+	}
+	return addresses, nil
+}
+
 func FindAssociatedTokenAddressAndBumpSeed(walletAddress common.Address, splTokenMintAddress common.Address, programID common.Address, options ...common.Address) (common.Address, uint8, error) {
 	tokenProgramID := TokenProgramID
 	if len(options) > 0 && options[0] == Token2022ProgramID {
@@ -0,0 +1,150 @@
+package base
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cielu/go-solana/common"
+)
+
+func TestCreateProgramAddress(t *testing.T) {
+	var (
+		programID = common.Base58ToAddress("BPFLoader1111111111111111111111111111111111")
+		publicKey = common.Base58ToAddress("SeedPubey1111111111111111111111111111111111")
+	)
+
+	tests := []struct {
+		seeds [][]byte
+		want  string
+	}{
+		{seeds: [][]byte{[]byte(""), {1}}, want: "3gF2KMe9KiC6FNVBmfg9i267aMPvK37FewCip4eGBFcT"},
+		{seeds: [][]byte{[]byte("☉")}, want: "7ytmC1nT1xY4RfxCV2ZgyA7UakC93do5ZdyhdF3EtPj7"},
+		{seeds: [][]byte{[]byte("Talking"), []byte("Squirrels")}, want: "HwRVBufQ4haG5XSgpspwKtNd3PC9GM9m1196uJW36vds"},
+		{seeds: [][]byte{publicKey[:]}, want: "GUs5qLUfsEHkcMB9T38vjr18ypEhRuNWiePW2LoK4E3K"},
+	}
+
+	for _, test := range tests {
+		addr, err := CreateProgramAddress(test.seeds, programID)
+		if err != nil {
+			t.Errorf("CreateProgramAddress Failed: %s", err.Error())
+			continue
+		}
+		if addr.String() != test.want {
+			t.Errorf("CreateProgramAddress Err ==> Got %s, Want: %s", addr, test.want)
+		}
+	}
+
+	// seed too long
+	_, err := CreateProgramAddress([][]byte{make([]byte, MaxSeedLength+1)}, programID)
+	if !errors.Is(err, ErrMaxSeedLengthExceeded) {
+		t.Errorf("CreateProgramAddress Err ==> Got %v, Want: %v", err, ErrMaxSeedLengthExceeded)
+	}
+	// too many seeds
+	_, err = CreateProgramAddress(make([][]byte, MaxSeeds+1), programID)
+	if !errors.Is(err, ErrMaxSeedsExceeded) {
+		t.Errorf("CreateProgramAddress Err ==> Got %v, Want: %v", err, ErrMaxSeedsExceeded)
+	}
+}
+
+func TestFindProgramAddress(t *testing.T) {
+	var (
+		programID = common.Base58ToAddress("BPFLoader1111111111111111111111111111111111")
+		seeds     = [][]byte{[]byte("")}
+	)
+	addr, bump, err := FindProgramAddress(seeds, programID)
+	if err != nil {
+		t.Fatalf("FindProgramAddress Failed: %s", err.Error())
+	}
+	// known bump derive the same address
+	want, err := CreateProgramAddress([][]byte{[]byte(""), {bump}}, programID)
+	if err != nil {
+		t.Fatalf("CreateProgramAddress Failed: %s", err.Error())
+	}
+	if addr != want {
+		t.Errorf("FindProgramAddress Err ==> Got %s, Want: %s", addr, want)
+	}
+	// the search range excluding the found bump
+	addr2, bump2, err := FindProgramAddressWithBumpRange(seeds, programID, bump-1, 0)
+	if err == nil && (bump2 >= bump || addr2 == addr) {
+		t.Errorf("FindProgramAddressWithBumpRange Err ==> Got bump %d, Want less than: %d", bump2, bump)
+	}
+	// empty range
+	if _, _, err = FindProgramAddressWithBumpRange(seeds, programID, 0, 1); !errors.Is(err, ErrNoValidProgramAddress) {
+		t.Errorf("FindProgramAddressWithBumpRange Err ==> Got %v, Want: %v", err, ErrNoValidProgramAddress)
+	}
+	// known associated token account
+	ata, _, err := FindAssociatedTokenAddress(
+		common.Base58ToAddress("B8UwBUUnKwCyKuGMbFKWaG7exYdDk2ozZrPg72NyVbfj"),
+		common.Base58ToAddress("7o36UsWR1JQLpZ9PE2gn9L4SQ69CNNiWAXd4Jt7rqz9Z"),
+	)
+	if err != nil {
+		t.Fatalf("FindAssociatedTokenAddress Failed: %s", err.Error())
+	}
+	if ata.String() != "DShWnroshVbeUp28oopA3Pu7oFPDBtC1DBmPECXXAQ9n" {
+		t.Errorf("FindAssociatedTokenAddress Err ==> Got %s, Want: %s", ata, "DShWnroshVbeUp28oopA3Pu7oFPDBtC1DBmPECXXAQ9n")
+	}
+}
+
+func TestFindAssociatedTokenAddressWithProgram(t *testing.T) {
+	var (
+		wallet = common.Base58ToAddress("B8UwBUUnKwCyKuGMbFKWaG7exYdDk2ozZrPg72NyVbfj")
+		mint   = common.Base58ToAddress("7o36UsWR1JQLpZ9PE2gn9L4SQ69CNNiWAXd4Jt7rqz9Z")
+	)
+	tests := []struct {
+		tokenProgramID common.Address
+		want           string
+		bump           uint8
+	}{
+		{TokenProgramID, "DShWnroshVbeUp28oopA3Pu7oFPDBtC1DBmPECXXAQ9n", 253},
+		{Token2022ProgramID, "6WD1d4QUPGyZ9pnwNqN1W6fsBd9zoJwZkDg9s7bYxVmq", 254},
+	}
+	for _, tt := range tests {
+		ata, bump, err := FindAssociatedTokenAddressWithProgram(wallet, mint, tt.tokenProgramID)
+		if err != nil {
+			t.Fatalf("FindAssociatedTokenAddressWithProgram Failed: %s", err.Error())
+		}
+		if ata.String() != tt.want || bump != tt.bump {
+			t.Errorf("FindAssociatedTokenAddressWithProgram(%s) Err ==> Got %s %d, Want: %s %d", tt.tokenProgramID, ata, bump, tt.want, tt.bump)
+		}
+		// the same as the variadic option
+		if ata2, _, _ := FindAssociatedTokenAddress(wallet, mint, tt.tokenProgramID); ata2 != ata {
+			t.Errorf("FindAssociatedTokenAddress(%s) Err ==> Got %s, Want: %s", tt.tokenProgramID, ata2, ata)
+		}
+	}
+}
+
+func TestFindAssociatedTokenAddresses(t *testing.T) {
+	mint := common.Base58ToAddress("7o36UsWR1JQLpZ9PE2gn9L4SQ69CNNiWAXd4Jt7rqz9Z")
+	pairs := make([]WalletMint, 100)
+	for i := range pairs {
+		pairs[i] = WalletMint{Wallet: common.BytesToAddress([]byte{byte(i), 1, 2, 3}), Mint: mint}
+	}
+	pairs[7].Wallet = common.Base58ToAddress("B8UwBUUnKwCyKuGMbFKWaG7exYdDk2ozZrPg72NyVbfj")
+	for _, tokenProgramID := range []common.Address{TokenProgramID, Token2022ProgramID} {
+		addresses, err := FindAssociatedTokenAddresses(pairs, tokenProgramID)
+		if err != nil {
+			t.Fatalf("FindAssociatedTokenAddresses Failed: %s", err)
+		}
+		if len(addresses) != len(pairs) {
+			t.Fatalf("FindAssociatedTokenAddresses Err ==> Got %d addresses, Want: %d", len(addresses), len(pairs))
+		}
+		for i, pair := range pairs {
+			want, _, _ := FindAssociatedTokenAddress(pair.Wallet, pair.Mint, tokenProgramID)
+			if addresses[i] != want {
+				t.Errorf("FindAssociatedTokenAddresses[%d] Err ==> Got %s, Want: %s", i, addresses[i], want)
+			}
+		}
+	}
+	if addresses, err := FindAssociatedTokenAddresses(nil); err != nil || len(addresses) != 0 {
+		t.Errorf("FindAssociatedTokenAddresses(nil) Err ==> Got %v, %v", addresses, err)
+	}
+}
+
+func TestCreateWithSeed(t *testing.T) {
+	// vector from @solana/web3.js
+	systemProgram := common.Base58ToAddress("11111111111111111111111111111111")
+	derived := CreateWithSeed(systemProgram, "limber chicken: 4/45", systemProgram)
+	if want := common.Base58ToAddress("9h1HyLCW5dZnBVap8C5egQ9Z6pHyjsh5MNy83iPqqRuq"); derived != want {
+		t.Errorf("CreateWithSeed Err ==> Got %s, Want: %s", derived, want)
+	}
+}
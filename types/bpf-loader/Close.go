@@ -0,0 +1,150 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package bpfloader
+
+import (
+	"encoding/binary"
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/types/base"
+)
+
+// Close Closes an account owned by the upgradeable loader of all lamports and withdraws all the lamports
+type Close struct {
+	// [0] = [WRITE] account
+	// ··········· Buffer, ProgramData or uninitialized account to close
+	//
+	// [1] = [WRITE] recipient
+	// ··········· Recipient of the lamports
+	//
+	// [2] = [SIGNER] authority
+	// ··········· Authority, optional, required for a Buffer or ProgramData account
+	//
+	// [3] = [WRITE] program
+	// ··········· Program account, optional, required when closing a ProgramData account
+	base.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewCloseInstructionBuilder creates a new `Close` instruction builder.
+func NewCloseInstructionBuilder() *Close {
+	nd := &Close{
+		AccountMetaSlice: make([]*base.AccountMeta, 4),
+	}
+	return nd
+}
+
+// SetAccount sets the "account" account.
+// Buffer, ProgramData or uninitialized account to close.
+func (cl *Close) SetAccount(account common.Address) *Close {
+	cl.AccountMetaSlice[0] = base.Meta(account).WRITE()
+	return cl
+}
+
+// GetAccount gets the "account" account.
+func (cl *Close) GetAccount() *base.AccountMeta {
+	return cl.AccountMetaSlice[0]
+}
+
+// SetRecipientAccount sets the "recipient" account.
+// Recipient of the lamports.
+func (cl *Close) SetRecipientAccount(recipient common.Address) *Close {
+	cl.AccountMetaSlice[1] = base.Meta(recipient).WRITE()
+	return cl
+}
+
+// GetRecipientAccount gets the "recipient" account.
+func (cl *Close) GetRecipientAccount() *base.AccountMeta {
+	return cl.AccountMetaSlice[1]
+}
+
+// SetAuthorityAccount sets the "authority" account.
+// Authority, optional, required for a Buffer or ProgramData account.
+func (cl *Close) SetAuthorityAccount(authority common.Address) *Close {
+	cl.AccountMetaSlice[2] = base.Meta(authority).SIGNER()
+	return cl
+}
+
+// GetAuthorityAccount gets the "authority" account (optional).
+func (cl *Close) GetAuthorityAccount() *base.AccountMeta {
+	return cl.AccountMetaSlice[2]
+}
+
+// SetProgramAccount sets the "program" account.
+// Program account, optional, required when closing a ProgramData account.
+func (cl *Close) SetProgramAccount(program common.Address) *Close {
+	cl.AccountMetaSlice[3] = base.Meta(program).WRITE()
+	return cl
+}
+
+// GetProgramAccount gets the "program" account (optional).
+func (cl *Close) GetProgramAccount() *base.AccountMeta {
+	return cl.AccountMetaSlice[3]
+}
+
+func (cl Close) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{BaseVariant: encodbin.BaseVariant{
+		Impl:   cl,
+		TypeID: encodbin.TypeIDFromUint32(Instruction_Close, binary.LittleEndian),
+	}}, opts)
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (cl Close) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
+	if err := cl.Validate(); err != nil {
+		return nil, err
+	}
+	return cl.Build(opts...), nil
+}
+
+func (cl *Close) Validate() error {
+	// Check whether all (required) accounts are set:
+	{
+		if cl.AccountMetaSlice[0] == nil {
+			return &base.MissingAccountError{Account: "Account"}
+		}
+		if cl.AccountMetaSlice[1] == nil {
+			return &base.MissingAccountError{Account: "Recipient"}
+		}
+		if cl.AccountMetaSlice[3] != nil && cl.AccountMetaSlice[2] == nil {
+			return &base.MissingAccountError{Account: "Authority"}
+		}
+	}
+	return nil
+}
+
+func (cl Close) MarshalWithEncoder(encoder *encodbin.Encoder) (err error) {
+	return nil
+}
+
+// NewCloseInstruction declares a new Close instruction with the provided parameters and accounts,
+// closing a Buffer account.
+func NewCloseInstruction(
+	// Accounts:
+	buffer common.Address,
+	recipient common.Address,
+	authority common.Address,
+) *Close {
+	return NewCloseInstructionBuilder().
+		SetAccount(buffer).
+		SetRecipientAccount(recipient).
+		SetAuthorityAccount(authority)
+}
+
+// NewCloseProgramInstruction declares a new Close instruction closing the ProgramData account of the program,
+// the program can not be invoked nor upgraded anymore.
+func NewCloseProgramInstruction(
+	// Accounts:
+	program common.Address,
+	recipient common.Address,
+	authority common.Address,
+) *Close {
+	programData, _, _ := FindProgramDataAddress(program)
+	return NewCloseInstructionBuilder().
+		SetAccount(programData).
+		SetRecipientAccount(recipient).
+		SetAuthorityAccount(authority).
+		SetProgramAccount(program)
+}
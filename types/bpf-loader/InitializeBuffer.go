@@ -0,0 +1,96 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package bpfloader
+
+import (
+	"encoding/binary"
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/types/base"
+)
+
+// InitializeBuffer Initialize a Buffer account, the account must be created with enough
+// space for the buffer metadata and the program data in the same transaction
+type InitializeBuffer struct {
+	// [0] = [WRITE] buffer
+	// ··········· Source account to initialize
+	//
+	// [1] = [] authority
+	// ··········· Buffer authority, optional, if omitted then the buffer will be immutable
+	base.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewInitializeBufferInstructionBuilder creates a new `InitializeBuffer` instruction builder.
+func NewInitializeBufferInstructionBuilder() *InitializeBuffer {
+	nd := &InitializeBuffer{
+		AccountMetaSlice: make([]*base.AccountMeta, 2),
+	}
+	return nd
+}
+
+// SetBufferAccount sets the "buffer" account.
+// Source account to initialize.
+func (ib *InitializeBuffer) SetBufferAccount(buffer common.Address) *InitializeBuffer {
+	ib.AccountMetaSlice[0] = base.Meta(buffer).WRITE()
+	return ib
+}
+
+// GetBufferAccount gets the "buffer" account.
+func (ib *InitializeBuffer) GetBufferAccount() *base.AccountMeta {
+	return ib.AccountMetaSlice[0]
+}
+
+// SetAuthorityAccount sets the "authority" account.
+// Buffer authority, optional, if omitted then the buffer will be immutable.
+func (ib *InitializeBuffer) SetAuthorityAccount(authority common.Address) *InitializeBuffer {
+	ib.AccountMetaSlice[1] = base.Meta(authority)
+	return ib
+}
+
+// GetAuthorityAccount gets the "authority" account (optional).
+func (ib *InitializeBuffer) GetAuthorityAccount() *base.AccountMeta {
+	return ib.AccountMetaSlice[1]
+}
+
+func (ib InitializeBuffer) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{BaseVariant: encodbin.BaseVariant{
+		Impl:   ib,
+		TypeID: encodbin.TypeIDFromUint32(Instruction_InitializeBuffer, binary.LittleEndian),
+	}}, opts)
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (ib InitializeBuffer) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
+	if err := ib.Validate(); err != nil {
+		return nil, err
+	}
+	return ib.Build(opts...), nil
+}
+
+func (ib *InitializeBuffer) Validate() error {
+	// Check whether all (required) accounts are set:
+	{
+		if ib.AccountMetaSlice[0] == nil {
+			return &base.MissingAccountError{Account: "Buffer"}
+		}
+	}
+	return nil
+}
+
+func (ib InitializeBuffer) MarshalWithEncoder(encoder *encodbin.Encoder) (err error) {
+	return nil
+}
+
+// NewInitializeBufferInstruction declares a new InitializeBuffer instruction with the provided parameters and accounts.
+func NewInitializeBufferInstruction(
+	// Accounts:
+	buffer common.Address,
+	authority common.Address,
+) *InitializeBuffer {
+	return NewInitializeBufferInstructionBuilder().
+		SetBufferAccount(buffer).
+		SetAuthorityAccount(authority)
+}
@@ -0,0 +1,119 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package bpfloader
+
+import (
+	"encoding/binary"
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/types/base"
+)
+
+// SetAuthority Set a new authority that is allowed to write the buffer or upgrade the program
+type SetAuthority struct {
+	// [0] = [WRITE] account
+	// ··········· Buffer or ProgramData account
+	//
+	// [1] = [SIGNER] currentAuthority
+	// ··········· Current authority
+	//
+	// [2] = [] newAuthority
+	// ··········· New authority, optional, if omitted then the program will not be upgradeable
+	base.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewSetAuthorityInstructionBuilder creates a new `SetAuthority` instruction builder.
+func NewSetAuthorityInstructionBuilder() *SetAuthority {
+	nd := &SetAuthority{
+		AccountMetaSlice: make([]*base.AccountMeta, 3),
+	}
+	return nd
+}
+
+// SetAccount sets the "account" account.
+// Buffer or ProgramData account.
+func (sa *SetAuthority) SetAccount(account common.Address) *SetAuthority {
+	sa.AccountMetaSlice[0] = base.Meta(account).WRITE()
+	return sa
+}
+
+// GetAccount gets the "account" account.
+func (sa *SetAuthority) GetAccount() *base.AccountMeta {
+	return sa.AccountMetaSlice[0]
+}
+
+// SetCurrentAuthorityAccount sets the "currentAuthority" account.
+// Current authority.
+func (sa *SetAuthority) SetCurrentAuthorityAccount(currentAuthority common.Address) *SetAuthority {
+	sa.AccountMetaSlice[1] = base.Meta(currentAuthority).SIGNER()
+	return sa
+}
+
+// GetCurrentAuthorityAccount gets the "currentAuthority" account.
+func (sa *SetAuthority) GetCurrentAuthorityAccount() *base.AccountMeta {
+	return sa.AccountMetaSlice[1]
+}
+
+// SetNewAuthorityAccount sets the "newAuthority" account.
+// New authority, optional, if omitted then the program will not be upgradeable.
+func (sa *SetAuthority) SetNewAuthorityAccount(newAuthority common.Address) *SetAuthority {
+	sa.AccountMetaSlice[2] = base.Meta(newAuthority)
+	return sa
+}
+
+// GetNewAuthorityAccount gets the "newAuthority" account (optional).
+func (sa *SetAuthority) GetNewAuthorityAccount() *base.AccountMeta {
+	return sa.AccountMetaSlice[2]
+}
+
+func (sa SetAuthority) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{BaseVariant: encodbin.BaseVariant{
+		Impl:   sa,
+		TypeID: encodbin.TypeIDFromUint32(Instruction_SetAuthority, binary.LittleEndian),
+	}}, opts)
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (sa SetAuthority) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
+	if err := sa.Validate(); err != nil {
+		return nil, err
+	}
+	return sa.Build(opts...), nil
+}
+
+func (sa *SetAuthority) Validate() error {
+	// Check whether all (required) accounts are set:
+	{
+		if sa.AccountMetaSlice[0] == nil {
+			return &base.MissingAccountError{Account: "Account"}
+		}
+		if sa.AccountMetaSlice[1] == nil {
+			return &base.MissingAccountError{Account: "CurrentAuthority"}
+		}
+	}
+	return nil
+}
+
+func (sa SetAuthority) MarshalWithEncoder(encoder *encodbin.Encoder) (err error) {
+	return nil
+}
+
+// NewSetAuthorityInstruction declares a new SetAuthority instruction with the provided parameters and accounts.
+// An empty newAuthority makes the program immutable.
+func NewSetAuthorityInstruction(
+	// Accounts:
+	account common.Address,
+	currentAuthority common.Address,
+	newAuthority common.Address,
+) *SetAuthority {
+	sa := NewSetAuthorityInstructionBuilder().
+		SetAccount(account).
+		SetCurrentAuthorityAccount(currentAuthority)
+	if !newAuthority.IsEmpty() {
+		sa.SetNewAuthorityAccount(newAuthority)
+	}
+	return sa
+}
@@ -0,0 +1,167 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package bpfloader
+
+import (
+	"encoding/binary"
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/types/base"
+)
+
+// Upgrade Upgrade a program, the program data is replaced by the data of the buffer
+type Upgrade struct {
+	// [0] = [WRITE] programData
+	// ··········· ProgramData account
+	//
+	// [1] = [WRITE] program
+	// ··········· Program account
+	//
+	// [2] = [WRITE] buffer
+	// ··········· Buffer account where the new program data has been written
+	//
+	// [3] = [WRITE] spill
+	// ··········· Spill account, receives the lamports of the buffer
+	//
+	// [4] = [] rentSysvar
+	// ··········· Rent sysvar
+	//
+	// [5] = [] clockSysvar
+	// ··········· Clock sysvar
+	//
+	// [6] = [SIGNER] authority
+	// ··········· Program's authority
+	base.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewUpgradeInstructionBuilder creates a new `Upgrade` instruction builder.
+func NewUpgradeInstructionBuilder() *Upgrade {
+	nd := &Upgrade{
+		AccountMetaSlice: make([]*base.AccountMeta, 7),
+	}
+	nd.AccountMetaSlice[4] = base.Meta(base.SysVarRentPubkey)
+	nd.AccountMetaSlice[5] = base.Meta(base.SysVarClockPubkey)
+	return nd
+}
+
+// SetProgramDataAccount sets the "programData" account.
+// ProgramData account.
+func (up *Upgrade) SetProgramDataAccount(programData common.Address) *Upgrade {
+	up.AccountMetaSlice[0] = base.Meta(programData).WRITE()
+	return up
+}
+
+// GetProgramDataAccount gets the "programData" account.
+func (up *Upgrade) GetProgramDataAccount() *base.AccountMeta {
+	return up.AccountMetaSlice[0]
+}
+
+// SetProgramAccount sets the "program" account.
+// Program account.
+func (up *Upgrade) SetProgramAccount(program common.Address) *Upgrade {
+	up.AccountMetaSlice[1] = base.Meta(program).WRITE()
+	return up
+}
+
+// GetProgramAccount gets the "program" account.
+func (up *Upgrade) GetProgramAccount() *base.AccountMeta {
+	return up.AccountMetaSlice[1]
+}
+
+// SetBufferAccount sets the "buffer" account.
+// Buffer account where the new program data has been written.
+func (up *Upgrade) SetBufferAccount(buffer common.Address) *Upgrade {
+	up.AccountMetaSlice[2] = base.Meta(buffer).WRITE()
+	return up
+}
+
+// GetBufferAccount gets the "buffer" account.
+func (up *Upgrade) GetBufferAccount() *base.AccountMeta {
+	return up.AccountMetaSlice[2]
+}
+
+// SetSpillAccount sets the "spill" account.
+// Spill account, receives the lamports of the buffer.
+func (up *Upgrade) SetSpillAccount(spill common.Address) *Upgrade {
+	up.AccountMetaSlice[3] = base.Meta(spill).WRITE()
+	return up
+}
+
+// GetSpillAccount gets the "spill" account.
+func (up *Upgrade) GetSpillAccount() *base.AccountMeta {
+	return up.AccountMetaSlice[3]
+}
+
+// SetAuthorityAccount sets the "authority" account.
+// Program's authority.
+func (up *Upgrade) SetAuthorityAccount(authority common.Address) *Upgrade {
+	up.AccountMetaSlice[6] = base.Meta(authority).SIGNER()
+	return up
+}
+
+// GetAuthorityAccount gets the "authority" account.
+func (up *Upgrade) GetAuthorityAccount() *base.AccountMeta {
+	return up.AccountMetaSlice[6]
+}
+
+func (up Upgrade) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{BaseVariant: encodbin.BaseVariant{
+		Impl:   up,
+		TypeID: encodbin.TypeIDFromUint32(Instruction_Upgrade, binary.LittleEndian),
+	}}, opts)
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (up Upgrade) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
+	if err := up.Validate(); err != nil {
+		return nil, err
+	}
+	return up.Build(opts...), nil
+}
+
+func (up *Upgrade) Validate() error {
+	// Check whether all (required) accounts are set:
+	{
+		if up.AccountMetaSlice[0] == nil {
+			return &base.MissingAccountError{Account: "ProgramData"}
+		}
+		if up.AccountMetaSlice[1] == nil {
+			return &base.MissingAccountError{Account: "Program"}
+		}
+		if up.AccountMetaSlice[2] == nil {
+			return &base.MissingAccountError{Account: "Buffer"}
+		}
+		if up.AccountMetaSlice[3] == nil {
+			return &base.MissingAccountError{Account: "Spill"}
+		}
+		if up.AccountMetaSlice[6] == nil {
+			return &base.MissingAccountError{Account: "Authority"}
+		}
+	}
+	return nil
+}
+
+func (up Upgrade) MarshalWithEncoder(encoder *encodbin.Encoder) (err error) {
+	return nil
+}
+
+// NewUpgradeInstruction declares a new Upgrade instruction with the provided parameters and accounts.
+// The programData account is derived from the program.
+func NewUpgradeInstruction(
+	// Accounts:
+	program common.Address,
+	buffer common.Address,
+	spill common.Address,
+	authority common.Address,
+) *Upgrade {
+	programData, _, _ := FindProgramDataAddress(program)
+	return NewUpgradeInstructionBuilder().
+		SetProgramDataAccount(programData).
+		SetProgramAccount(program).
+		SetBufferAccount(buffer).
+		SetSpillAccount(spill).
+		SetAuthorityAccount(authority)
+}
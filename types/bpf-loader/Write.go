@@ -0,0 +1,141 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package bpfloader
+
+import (
+	"encoding/binary"
+	"errors"
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/types/base"
+)
+
+// Write Write program data into a Buffer account
+type Write struct {
+	// Offset at which to write the given bytes
+	Offset *uint32
+	// Serialized program data
+	Bytes []byte
+
+	// [0] = [WRITE] buffer
+	// ··········· Buffer account to write program data to
+	//
+	// [1] = [SIGNER] authority
+	// ··········· Buffer authority
+	base.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewWriteInstructionBuilder creates a new `Write` instruction builder.
+func NewWriteInstructionBuilder() *Write {
+	nd := &Write{
+		AccountMetaSlice: make([]*base.AccountMeta, 2),
+	}
+	return nd
+}
+
+// SetOffset sets the "offset" parameter.
+// Offset at which to write the given bytes.
+func (wr *Write) SetOffset(offset uint32) *Write {
+	wr.Offset = &offset
+	return wr
+}
+
+// SetBytes sets the "bytes" parameter.
+// Serialized program data.
+func (wr *Write) SetBytes(bytes []byte) *Write {
+	wr.Bytes = bytes
+	return wr
+}
+
+// SetBufferAccount sets the "buffer" account.
+// Buffer account to write program data to.
+func (wr *Write) SetBufferAccount(buffer common.Address) *Write {
+	wr.AccountMetaSlice[0] = base.Meta(buffer).WRITE()
+	return wr
+}
+
+// GetBufferAccount gets the "buffer" account.
+func (wr *Write) GetBufferAccount() *base.AccountMeta {
+	return wr.AccountMetaSlice[0]
+}
+
+// SetAuthorityAccount sets the "authority" account.
+// Buffer authority.
+func (wr *Write) SetAuthorityAccount(authority common.Address) *Write {
+	wr.AccountMetaSlice[1] = base.Meta(authority).SIGNER()
+	return wr
+}
+
+// GetAuthorityAccount gets the "authority" account.
+func (wr *Write) GetAuthorityAccount() *base.AccountMeta {
+	return wr.AccountMetaSlice[1]
+}
+
+func (wr Write) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{BaseVariant: encodbin.BaseVariant{
+		Impl:   wr,
+		TypeID: encodbin.TypeIDFromUint32(Instruction_Write, binary.LittleEndian),
+	}}, opts)
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (wr Write) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
+	if err := wr.Validate(); err != nil {
+		return nil, err
+	}
+	return wr.Build(opts...), nil
+}
+
+func (wr *Write) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if wr.Offset == nil {
+			return errors.New("Offset parameter is not set")
+		}
+		if len(wr.Bytes) == 0 {
+			return errors.New("Bytes parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if wr.AccountMetaSlice[0] == nil {
+			return &base.MissingAccountError{Account: "Buffer"}
+		}
+		if wr.AccountMetaSlice[1] == nil {
+			return &base.MissingAccountError{Account: "Authority"}
+		}
+	}
+	return nil
+}
+
+func (wr Write) MarshalWithEncoder(encoder *encodbin.Encoder) (err error) {
+	// Serialize `Offset` param:
+	if err = encoder.WriteUint32(*wr.Offset, binary.LittleEndian); err != nil {
+		return err
+	}
+	// Serialize `Bytes` param, a bincode Vec<u8> with a u64 length:
+	if err = encoder.WriteUint64(uint64(len(wr.Bytes)), binary.LittleEndian); err != nil {
+		return err
+	}
+	return encoder.WriteBytes(wr.Bytes, false)
+}
+
+// NewWriteInstruction declares a new Write instruction with the provided parameters and accounts.
+func NewWriteInstruction(
+	// Parameters:
+	offset uint32,
+	bytes []byte,
+	// Accounts:
+	buffer common.Address,
+	authority common.Address,
+) *Write {
+	return NewWriteInstructionBuilder().
+		SetOffset(offset).
+		SetBytes(bytes).
+		SetBufferAccount(buffer).
+		SetAuthorityAccount(authority)
+}
@@ -0,0 +1,113 @@
+package bpfloader
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/types/base"
+)
+
+func TestUpgradeEncode(t *testing.T) {
+	var (
+		program   = common.StrToAddress("whirLbMiicVdio4qvUfM5KAg6Ct8VwpYzGff3uctyCc")
+		buffer    = common.StrToAddress("BZYExy8yxFZF6jTp4h7X98dPLBcbQDFhvHXPdTjDb2ag")
+		spill     = common.StrToAddress("EXC6EAnN7HMXbTWomY6j7tQZY1cfZ52LRJpwZ6i3CY66")
+		authority = common.StrToAddress("F8HCC3DyoR6KN9SSK9NL1V6weRgsEvp8hjL26EnTxNTF")
+	)
+	programData, _, err := FindProgramDataAddress(program)
+	if err != nil {
+		t.Fatalf("FindProgramDataAddress Failed: %s", err)
+	}
+	inst, err := NewUpgradeInstruction(program, buffer, spill, authority).ValidateAndBuild()
+	if err != nil {
+		t.Fatalf("ValidateAndBuild Failed: %s", err)
+	}
+	if inst.ProgramID() != base.BPFLoaderUpgradeableProgramID {
+		t.Errorf("ProgramID Err ==> Got %s, Want: %s", inst.ProgramID(), base.BPFLoaderUpgradeableProgramID)
+	}
+	data, err := inst.Data()
+	if err != nil {
+		t.Fatalf("Data Failed: %s", err)
+	}
+	if want := []byte{3, 0, 0, 0}; !bytes.Equal(data, want) {
+		t.Errorf("Data Err ==> Got %v, Want: %v", data, want)
+	}
+	want := []*base.AccountMeta{
+		base.Meta(programData).WRITE(),
+		base.Meta(program).WRITE(),
+		base.Meta(buffer).WRITE(),
+		base.Meta(spill).WRITE(),
+		base.Meta(base.SysVarRentPubkey),
+		base.Meta(base.SysVarClockPubkey),
+		base.Meta(authority).SIGNER(),
+	}
+	accounts := inst.Accounts()
+	if len(accounts) != len(want) {
+		t.Fatalf("Accounts Err ==> Got %d, Want: %d", len(accounts), len(want))
+	}
+	for i := range want {
+		if *accounts[i] != *want[i] {
+			t.Errorf("Accounts[%d] Err ==> Got %+v, Want: %+v", i, *accounts[i], *want[i])
+		}
+	}
+
+	// SetAuthority to none makes the program immutable
+	inst, err = NewSetAuthorityInstruction(programData, authority, common.Address{}).ValidateAndBuild()
+	if err != nil {
+		t.Fatalf("SetAuthority ValidateAndBuild Failed: %s", err)
+	}
+	if data, _ = inst.Data(); !bytes.Equal(data, []byte{4, 0, 0, 0}) || len(inst.Accounts()) != 2 {
+		t.Errorf("SetAuthority Err ==> Got %v, %d accounts", data, len(inst.Accounts()))
+	}
+	// Write offset and a u64 length prefixed payload
+	inst = NewWriteInstruction(16, []byte{0xde, 0xad}, buffer, authority).Build()
+	if data, _ = inst.Data(); !bytes.Equal(data, []byte{1, 0, 0, 0, 16, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 0xde, 0xad}) {
+		t.Errorf("Write Data Err ==> Got %v", data)
+	}
+	if _, err = NewUpgradeInstructionBuilder().SetProgramAccount(program).ValidateAndBuild(); err == nil {
+		t.Errorf("ValidateAndBuild without accounts should fail")
+	}
+}
+
+func TestDecodeProgramDataAccount(t *testing.T) {
+	var (
+		authority = common.StrToAddress("F8HCC3DyoR6KN9SSK9NL1V6weRgsEvp8hjL26EnTxNTF")
+		elf       = []byte{0x7f, 'E', 'L', 'F'}
+	)
+	data := make([]byte, ProgramDataMetadataSize, ProgramDataMetadataSize+len(elf))
+	binary.LittleEndian.PutUint32(data, StateProgramData)
+	binary.LittleEndian.PutUint64(data[4:], 250_000_123)
+	data[12] = 1
+	copy(data[13:], authority[:])
+	data = append(data, elf...)
+
+	programData, err := DecodeProgramDataAccount(data)
+	if err != nil {
+		t.Fatalf("DecodeProgramDataAccount Failed: %s", err)
+	}
+	if programData.Slot != 250_000_123 || programData.UpgradeAuthority == nil || *programData.UpgradeAuthority != authority {
+		t.Errorf("DecodeProgramDataAccount Err ==> Got %+v", programData)
+	}
+	if !bytes.Equal(programData.Data, elf) {
+		t.Errorf("Data Err ==> Got %v, Want: %v", programData.Data, elf)
+	}
+	// an immutable program keeps the space of the authority
+	data[12] = 0
+	copy(data[13:], make([]byte, 32))
+	if programData, err = DecodeProgramDataAccount(data); err != nil || programData.UpgradeAuthority != nil || !bytes.Equal(programData.Data, elf) {
+		t.Errorf("DecodeProgramDataAccount immutable Err ==> Got %+v, %v", programData, err)
+	}
+
+	// the Program account points to its ProgramData
+	program := make([]byte, 36)
+	binary.LittleEndian.PutUint32(program, StateProgram)
+	copy(program[4:], authority[:])
+	if address, err := DecodeProgramAccount(program); err != nil || address != authority {
+		t.Errorf("DecodeProgramAccount Err ==> Got %s, %v, Want: %s", address, err, authority)
+	}
+	if _, err = DecodeProgramDataAccount(program); err == nil {
+		t.Errorf("DecodeProgramDataAccount of a Program account should fail")
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package bpfloader
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/types/base"
+)
+
+const (
+	// Initialize a Buffer account
+	Instruction_InitializeBuffer uint32 = iota
+
+	// Write program data into a Buffer account
+	Instruction_Write
+
+	// Deploy an executable program
+	Instruction_DeployWithMaxDataLen
+
+	// Upgrade a program
+	Instruction_Upgrade
+
+	// Set a new authority that is allowed to write the buffer or upgrade the program
+	Instruction_SetAuthority
+
+	// Closes an account owned by the upgradeable loader of all lamports and withdraws all the lamports
+	Instruction_Close
+
+	// Extend a program's ProgramData account by the specified number of bytes
+	Instruction_ExtendProgram
+
+	// Set a new authority that is allowed to write the buffer or upgrade the program, the new authority must sign
+	Instruction_SetAuthorityChecked
+)
+
+// FindProgramDataAddress returns the ProgramData account of an upgradeable program
+func FindProgramDataAddress(program common.Address) (common.Address, uint8, error) {
+	return base.FindProgramAddress([][]byte{program[:]}, base.BPFLoaderUpgradeableProgramID)
+}
+
+type Instruction struct {
+	encodbin.BaseVariant
+	// programID overrides base.BPFLoaderUpgradeableProgramID when set, see base.WithProgramID
+	programID common.Address
+}
+
+// withBuildOptions applies the options of Build to the instruction
+func withBuildOptions(inst *Instruction, opts []base.BuildOption) *Instruction {
+	inst.programID = base.NewBuildOptions(opts...).ProgramID
+	return inst
+}
+
+func (inst *Instruction) ProgramID() common.Address {
+	if !inst.programID.IsEmpty() {
+		return inst.programID
+	}
+	return base.BPFLoaderUpgradeableProgramID
+}
+
+func (inst *Instruction) Accounts() (out []*base.AccountMeta) {
+	return inst.Impl.(base.AccountsGettable).GetAccounts()
+}
+
+func (inst *Instruction) Data() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := encodbin.NewBinEncoder(buf).Encode(inst); err != nil {
+		return nil, fmt.Errorf("unable to encode instruction: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (inst *Instruction) MarshalWithEncoder(encoder *encodbin.Encoder) error {
+	err := encoder.WriteUint32(inst.TypeID.Uint32(), binary.LittleEndian)
+	if err != nil {
+		return fmt.Errorf("unable to write variant type: %w", err)
+	}
+	return encoder.Encode(inst.Impl)
+}
@@ -0,0 +1,96 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package bpfloader
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/pkg/encodbin"
+)
+
+const (
+	// Account is not initialized
+	StateUninitialized uint32 = iota
+	// A Buffer account
+	StateBuffer
+	// A Program account
+	StateProgram
+	// A ProgramData account
+	StateProgramData
+)
+
+// ProgramDataMetadataSize the size of the ProgramData account state preceding the program data
+const ProgramDataMetadataSize = 4 + 8 + 1 + 32
+
+// ProgramData the state of the ProgramData account of an upgradeable program
+type ProgramData struct {
+	// Slot that the program was last modified
+	Slot uint64
+	// Address of the Program's upgrade authority, nil when the program is immutable
+	UpgradeAuthority *common.Address
+	// The program data, the ELF of the program
+	Data []byte
+}
+
+// DecodeProgramDataAccount decode the ProgramData account of an upgradeable program
+func DecodeProgramDataAccount(data []byte) (*ProgramData, error) {
+	decoder := encodbin.NewBinDecoder(data)
+	if err := readState(decoder, StateProgramData); err != nil {
+		return nil, err
+	}
+	slot, err := decoder.ReadUint64(binary.LittleEndian)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read slot: %w", err)
+	}
+	authority, err := readOptionalAddress(decoder)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read upgrade authority: %w", err)
+	}
+	programData := &ProgramData{Slot: slot, UpgradeAuthority: authority}
+	// the program data starts after the metadata, whether the authority is set or not
+	if len(data) > ProgramDataMetadataSize {
+		programData.Data = data[ProgramDataMetadataSize:]
+	}
+	return programData, nil
+}
+
+// DecodeProgramAccount decode the Program account of an upgradeable program, returns its ProgramData address
+func DecodeProgramAccount(data []byte) (common.Address, error) {
+	decoder := encodbin.NewBinDecoder(data)
+	if err := readState(decoder, StateProgram); err != nil {
+		return common.Address{}, err
+	}
+	var programData common.Address
+	if _, err := decoder.Read(programData[:]); err != nil {
+		return common.Address{}, fmt.Errorf("unable to read programdata address: %w", err)
+	}
+	return programData, nil
+}
+
+// readState reads the u32 state discriminant, which must be the expected one
+func readState(decoder *encodbin.Decoder, expected uint32) error {
+	state, err := decoder.ReadUint32(binary.LittleEndian)
+	if err != nil {
+		return fmt.Errorf("unable to read state: %w", err)
+	}
+	if state != expected {
+		return fmt.Errorf("invalid state: %d, expected: %d", state, expected)
+	}
+	return nil
+}
+
+// readOptionalAddress reads a bincode Option<Pubkey>
+func readOptionalAddress(decoder *encodbin.Decoder) (*common.Address, error) {
+	present, err := decoder.ReadOption(encodbin.OptionKindBorsh)
+	if err != nil || !present {
+		return nil, err
+	}
+	address := new(common.Address)
+	if _, err = decoder.Read(address[:]); err != nil {
+		return nil, err
+	}
+	return address, nil
+}
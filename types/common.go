@@ -1,11 +1,39 @@
 package types
 
+import (
+	"fmt"
+	"time"
+)
+
+// UnixTime converts the block time, a unix timestamp in seconds, to a time.Time.
+// A 0 block time is not available and returns the zero time
+func UnixTime(blockTime int64) time.Time {
+	if blockTime == 0 {
+		return time.Time{}
+	}
+	return time.Unix(blockTime, 0)
+}
+
 // SlotRange the first slot --> lastSlot
 type SlotRange struct {
-	FirstSlot uint64 `json:"firstSlot,omitempty"`
+	FirstSlot uint64 `json:"firstSlot"`
 	LastSlot  uint64 `json:"lastSlot,omitempty"`
 }
 
+// NewSlotRange returns the range of the slots first to last, inclusive
+func NewSlotRange(first, last uint64) (SlotRange, error) {
+	r := SlotRange{FirstSlot: first, LastSlot: last}
+	return r, r.Validate()
+}
+
+// Validate checks the first slot is not after the last slot, a 0 last slot is the current slot
+func (r SlotRange) Validate() error {
+	if r.LastSlot != 0 && r.FirstSlot > r.LastSlot {
+		return fmt.Errorf("invalid slot range %d-%d. Require: firstSlot <= lastSlot", r.FirstSlot, r.LastSlot)
+	}
+	return nil
+}
+
 // DataSlice Request a slice of the data range.
 type DataSlice struct {
 	Length uint64 `json:"length"`
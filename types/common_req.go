@@ -42,7 +42,7 @@ type RpcCommitmentWithIdentity struct {
 type RpcGetBlockProduction struct {
 	Commitment EnumRpcCommitment `json:"commitment,omitempty"`
 	Identity   *common.Address   `json:"identity,omitempty"`
-	Range      SlotRange         `json:"range,omitempty"`
+	Range      *SlotRange        `json:"range,omitempty"`
 }
 
 // RpcCommitmentWithFilter commitment with filter
@@ -65,7 +65,7 @@ type RpcCombinedCfg struct {
 	Commitment     EnumRpcCommitment        `json:"commitment,omitempty"`
 	MinContextSlot *uint64                  `json:"minContextSlot,omitempty"`
 	DataSlice      *DataSlice               `json:"dataSlice,omitempty"`
-	Filter         []map[string]interface{} `json:"filter,omitempty"`
+	Filter         []map[string]interface{} `json:"filters,omitempty"`
 }
 
 type RpcSearchTxHistoryCfg struct {
@@ -105,6 +105,10 @@ type RpcGetTransactionCfg struct {
 	// If the requested transaction is a higher version, an error will be returned.
 	// If this parameter is omitted, only legacy transactions will be returned, and any versioned transaction will prompt the error.
 	MaxSupportedTxVersion uint8 `json:"maxSupportedTransactionVersion,omitempty"`
+
+	// FillBlockTime Fetch the block time of the slot with getBlockTime when the blockTime of the
+	// transaction is null, an extra request. Not sent to the node
+	FillBlockTime bool `json:"-"`
 }
 
 type RpcVoteAccountCfg struct {
@@ -137,6 +141,21 @@ type RpcSendTxCfg struct {
 	MinContextSlot *uint64 `json:"minContextSlot,omitempty"`
 }
 
+// RpcSimulateTxCfg struct
+type RpcSimulateTxCfg struct {
+	// Default: finalized
+	// Commitment level to simulate the transaction at.
+	Commitment EnumRpcCommitment `json:"commitment,omitempty"`
+	// if true the transaction signatures will be verified (conflicts with ReplaceRecentBlockhash)
+	SigVerify bool `json:"sigVerify,omitempty"`
+	// if true the transaction recent blockhash will be replaced with the most recent blockhash (conflicts with SigVerify)
+	ReplaceRecentBlockhash bool `json:"replaceRecentBlockhash,omitempty"`
+	// Encoding used for the transaction data. Values: base58 (slow, DEPRECATED), or base64.
+	Encoding EnumEncoding `json:"encoding,omitempty"`
+	// the minimum slot that the request can be evaluated at
+	MinContextSlot *uint64 `json:"minContextSlot,omitempty"`
+}
+
 type MentionsAccountProgramCfg struct {
 	MentionsAccountOrProgram common.Address `json:"MentionsAccountOrProgram,omitempty"`
 }
@@ -4,9 +4,18 @@
 package types
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/cielu/go-solana/common"
+	"github.com/mr-tron/base58"
 	"math/big"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type ContextSlot struct {
@@ -42,13 +51,33 @@ type BalanceWithCtx struct {
 }
 
 type BlockReward struct {
+	// vote account commission when the reward was credited, nil for the fee and rent rewards
 	Commission  *uint8         `json:"commission"`
 	Lamports    *big.Int       `json:"lamports"`
 	PostBalance uint64         `json:"postBalance"`
-	RewardType  string         `json:"rewardType"`
+	RewardType  EnumRewardType `json:"rewardType"`
 	Pubkey      common.Address `json:"pubkey"`
 }
 
+// UnmarshalJSON parses the reward type case-insensitively, e.g. "fee" or "Fee" is RewardTypeFee
+func (t *EnumRewardType) UnmarshalJSON(input []byte) error {
+	var rewardType *string
+	if err := json.Unmarshal(input, &rewardType); err != nil {
+		return err
+	}
+	*t = ""
+	if rewardType == nil {
+		return nil
+	}
+	*t = EnumRewardType(*rewardType)
+	for _, known := range []EnumRewardType{RewardTypeFee, RewardTypeRent, RewardTypeStaking, RewardTypeVoting} {
+		if strings.EqualFold(*rewardType, string(known)) {
+			*t = known
+		}
+	}
+	return nil
+}
+
 type UiTokenAmount struct {
 	// Address account
 	Address *common.Address `json:"address,omitempty"`
@@ -66,6 +95,38 @@ type UiTokenAmount struct {
 	UiAmountString string `json:"uiAmountString"`
 }
 
+// AmountBigInt returns the raw amount of tokens
+func (ta UiTokenAmount) AmountBigInt() (*big.Int, error) {
+	amount, ok := new(big.Int).SetString(ta.Amount, 10)
+	if !ok || amount.Sign() < 0 {
+		return nil, fmt.Errorf("invalid token amount: %q", ta.Amount)
+	}
+	return amount, nil
+}
+
+// AmountUint64 returns the raw amount of tokens, it fails if the amount overflows uint64
+func (ta UiTokenAmount) AmountUint64() (uint64, error) {
+	amount, err := ta.AmountBigInt()
+	if err != nil {
+		return 0, err
+	}
+	if !amount.IsUint64() {
+		return 0, fmt.Errorf("token amount overflows uint64: %s", ta.Amount)
+	}
+	return amount.Uint64(), nil
+}
+
+// UiAmountDecimal returns the exact amount accounting for decimals, without the float
+// rounding of UiAmount. Use FloatString(int(ta.Decimals)) to format it
+func (ta UiTokenAmount) UiAmountDecimal() (*big.Rat, error) {
+	amount, err := ta.AmountBigInt()
+	if err != nil {
+		return nil, err
+	}
+	denom := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(ta.Decimals)), nil)
+	return new(big.Rat).SetFrac(amount, denom), nil
+}
+
 type TokenBalance struct {
 	// Index of the account in which the token balance is provided for.
 	AccountIndex uint16 `json:"accountIndex"`
@@ -140,6 +201,37 @@ type TransactionMeta struct {
 	LoadedAddresses LoadedAddresses `json:"loadedAddresses"`
 }
 
+// ProgramLogs returns the log messages emitted while the program was executing, grouped by
+// the "Program <id> invoke [n]" and "Program <id> success" / "Program <id> failed" markers.
+// The logs of the programs it invokes via CPI are excluded, its own markers are included.
+// The messages after one not matching the invocations, see LogParser, are ignored
+func (meta TransactionMeta) ProgramLogs(programID common.Address) []string {
+	var parser LogParser
+	for _, line := range meta.LogMessages {
+		if parser.Parse(line) != nil {
+			break
+		}
+	}
+	var lines []int
+	collectProgramLines(parser.Invocations(), programID, &lines)
+	// the lines of nested invocations of the program are interleaved
+	sort.Ints(lines)
+	var logs []string
+	for _, i := range lines {
+		logs = append(logs, meta.LogMessages[i])
+	}
+	return logs
+}
+
+func collectProgramLines(invocations []*ProgramInvocation, programID common.Address, lines *[]int) {
+	for _, invocation := range invocations {
+		if invocation.ProgramID == programID {
+			*lines = append(*lines, invocation.lines...)
+		}
+		collectProgramLines(invocation.Invocations, programID, lines)
+	}
+}
+
 type BlockTransaction struct {
 	// Transaction status metadata object
 	Meta *TransactionMeta `json:"meta"`
@@ -153,19 +245,138 @@ type BlockTransaction struct {
 
 	// Transaction
 	Transaction *Transaction `json:"transaction"`
-	// Version Of Transaction
+	// Version Of Transaction, the version field of the response or
+	// the version of the decoded message when the field is omitted
 	Version TxVersion `json:"version"`
 }
 
+// UnmarshalJSON parses the block transaction, see Version
+func (bt *BlockTransaction) UnmarshalJSON(input []byte) error {
+	type blockTransaction BlockTransaction
+	aux := struct {
+		*blockTransaction
+		Version *TxVersion `json:"version"`
+	}{blockTransaction: (*blockTransaction)(bt)}
+	if err := json.Unmarshal(input, &aux); err != nil {
+		return err
+	}
+	switch {
+	case aux.Version != nil:
+		bt.Version = *aux.Version
+	case bt.Transaction != nil:
+		bt.Version = bt.Transaction.Version()
+	default:
+		bt.Version = LegacyTransactionVersion
+	}
+	return nil
+}
+
+// ResolvedAccountKeys returns the account keys the instruction account indexes refer to: the static
+// keys of the message, then the writable and the readonly addresses loaded from lookup tables
+func (bt BlockTransaction) ResolvedAccountKeys() []common.Address {
+	if bt.Transaction == nil {
+		return nil
+	}
+	if bt.Meta == nil {
+		return bt.Transaction.Message.AccountKeys
+	}
+	return bt.Transaction.WithLoadedAddresses(*bt.Meta).AccountKeys
+}
+
+// Mentions reports whether the account is in the transaction account keys,
+// including the addresses loaded from lookup tables
+func (bt BlockTransaction) Mentions(account common.Address) bool {
+	if bt.Transaction != nil {
+		for _, key := range bt.Transaction.Message.AccountKeys {
+			if key == account {
+				return true
+			}
+		}
+	}
+	if bt.Meta != nil {
+		for _, key := range bt.Meta.LoadedAddresses.Writable {
+			if key == account {
+				return true
+			}
+		}
+		for _, key := range bt.Meta.LoadedAddresses.ReadOnly {
+			if key == account {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ToTransaction Returns a copy of the fetched transaction which can be re-signed or re-serialized: the
+// static account keys, header, instructions, blockhash, lookups and signatures are preserved, the addresses
+// loaded from lookup tables are not, even when resolved. Requires the transaction fetched with transactionDetails "full"
+func (bt BlockTransaction) ToTransaction() (*Transaction, error) {
+	if bt.Transaction == nil || len(bt.Transaction.Message.Instructions) == 0 {
+		return nil, errors.New("transaction content not available. Require: transactionDetails full")
+	}
+	src := bt.Transaction.Message
+	tx := &Transaction{
+		Signatures: append([]common.Signature(nil), bt.Transaction.Signatures...),
+		Message: Message{
+			AccountKeys:     append([]common.Address(nil), src.AccountKeys[:src.numStaticAccountKeys()]...),
+			Header:          src.Header,
+			RecentBlockhash: src.RecentBlockhash,
+			Instructions:    make([]CompiledInstruction, len(src.Instructions)),
+		},
+	}
+	for i, inst := range src.Instructions {
+		tx.Message.Instructions[i] = CompiledInstruction{
+			ProgramIDIndex: inst.ProgramIDIndex,
+			Accounts:       append([]uint16(nil), inst.Accounts...),
+			Data:           append(common.Base58(nil), inst.Data...),
+		}
+	}
+	if src.IsVersioned() {
+		lookups := make(MessageAddressTableLookupSlice, len(src.addressTableLookups))
+		for i, lookup := range src.addressTableLookups {
+			lookups[i] = MessageAddressTableLookup{
+				AccountKey:      lookup.AccountKey,
+				WritableIndexes: append([]uint8(nil), lookup.WritableIndexes...),
+				ReadonlyIndexes: append([]uint8(nil), lookup.ReadonlyIndexes...),
+			}
+		}
+		tx.Message.SetAddressTableLookups(lookups)
+	}
+	return tx, nil
+}
+
 type BlockInfo struct {
-	Err               json.RawMessage    `json:"err"`
-	BlockHeight       uint64             `json:"blockHeight"`
-	BlockTime         int64              `json:"blockTime"`
-	ParentSlot        uint64             `json:"parentSlot"`
-	BlockHash         common.Hash        `json:"blockHash"`
-	PreviousBlockhash common.Hash        `json:"previousBlockhash"`
-	Rewards           []BlockReward      `json:"rewards"`
-	BlockTransaction  []BlockTransaction `json:"transactions"`
+	// Error of the block notification, nil for a successful block
+	Err               json.RawMessage `json:"err"`
+	BlockHeight       uint64          `json:"blockHeight"`
+	BlockTime         int64           `json:"blockTime"`
+	ParentSlot        uint64          `json:"parentSlot"`
+	BlockHash         common.Hash     `json:"blockHash"`
+	PreviousBlockhash common.Hash     `json:"previousBlockhash"`
+	// Omitted when rewards is false
+	Rewards []BlockReward `json:"rewards"`
+	// Present when transactionDetails is "full" or "accounts"
+	BlockTransaction []BlockTransaction `json:"transactions"`
+	// Present when transactionDetails is "signatures"
+	Signatures []common.Signature `json:"signatures"`
+}
+
+// UnmarshalJSON parses the block, Err is nil for a successful block instead of a null raw message
+func (b *BlockInfo) UnmarshalJSON(input []byte) error {
+	type blockInfo BlockInfo
+	if err := json.Unmarshal(input, (*blockInfo)(b)); err != nil {
+		return err
+	}
+	if string(bytes.TrimSpace(b.Err)) == "null" {
+		b.Err = nil
+	}
+	return nil
+}
+
+// Time returns the block time, the zero time when not available
+func (b BlockInfo) Time() time.Time {
+	return UnixTime(b.BlockTime)
 }
 
 type BlockCommitment struct {
@@ -205,6 +416,41 @@ type ClusterInformation struct {
 	ShredVersion *uint16 `json:"shredVersion,omitempty"`
 }
 
+// RPCEndpoint Returns the host and port of the JSON RPC service, ok is false when it's not enabled
+func (ci ClusterInformation) RPCEndpoint() (host string, port int, ok bool) {
+	return splitHostPort(ci.Rpc)
+}
+
+// GossipEndpoint Returns the host and port of the gossip service
+func (ci ClusterInformation) GossipEndpoint() (host string, port int, ok bool) {
+	return splitHostPort(ci.Gossip)
+}
+
+// TpuEndpoint Returns the host and port of the TPU service
+func (ci ClusterInformation) TpuEndpoint() (host string, port int, ok bool) {
+	return splitHostPort(ci.Tpu)
+}
+
+// HasVersionPrefix reports whether the software version of the node starts with the prefix, e.g. "1.18"
+func (ci ClusterInformation) HasVersionPrefix(prefix string) bool {
+	return ci.Version != "" && strings.HasPrefix(ci.Version, prefix)
+}
+
+// splitHostPort split a "host:port" network address
+func splitHostPort(addr string) (host string, port int, ok bool) {
+	if addr == "" {
+		return "", 0, false
+	}
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", 0, false
+	}
+	if port, err = strconv.Atoi(portStr); err != nil || port <= 0 || port > 65535 {
+		return "", 0, false
+	}
+	return host, port, true
+}
+
 type EpochInformation struct {
 	// the current slot
 	AbsoluteSlot uint64 `json:"absoluteSlot"`
@@ -233,6 +479,59 @@ type EpochSchedule struct {
 	FirstNormalSlot uint64 `json:"firstNormalSlot"`
 }
 
+// LeaderSchedule the leader schedule of an epoch, slot indexes are relative to the first slot of the epoch
+type LeaderSchedule struct {
+	// the slot indexes of each validator identity
+	Leaders map[common.Address][]uint64
+	// reverse index, the leader of each slot index
+	slotLeaders []common.Address
+}
+
+// MaxLeaderScheduleSlots the bound of the slot indexes of a leader schedule, the slots of an epoch of the public clusters
+const MaxLeaderScheduleSlots = 432_000
+
+// NewLeaderSchedule decodes the base58 identities of a getLeaderSchedule result, it fails
+// when a slot index is not below MaxLeaderScheduleSlots
+func NewLeaderSchedule(raw map[string][]uint64) (*LeaderSchedule, error) {
+	ls := &LeaderSchedule{Leaders: make(map[common.Address][]uint64, len(raw))}
+	var numSlots uint64
+	for key, slots := range raw {
+		b, err := base58.Decode(key)
+		if err != nil || len(b) != common.AddressLength {
+			return nil, fmt.Errorf("invalid leader identity: %s", key)
+		}
+		ls.Leaders[common.BytesToAddress(b)] = slots
+		for _, slot := range slots {
+			if slot >= MaxLeaderScheduleSlots {
+				return nil, fmt.Errorf("invalid slot index %d of leader %s", slot, key)
+			}
+			if slot+1 > numSlots {
+				numSlots = slot + 1
+			}
+		}
+	}
+	ls.slotLeaders = make([]common.Address, numSlots)
+	for identity, slots := range ls.Leaders {
+		for _, slot := range slots {
+			ls.slotLeaders[slot] = identity
+		}
+	}
+	return ls, nil
+}
+
+// LeaderForSlot returns the leader of the slot index, an empty address when the index is out of the schedule
+func (ls *LeaderSchedule) LeaderForSlot(slotIndex uint64) common.Address {
+	if slotIndex >= uint64(len(ls.slotLeaders)) {
+		return common.Address{}
+	}
+	return ls.slotLeaders[slotIndex]
+}
+
+// Slots returns the slot indexes the identity is scheduled to lead
+func (ls *LeaderSchedule) Slots(identity common.Address) []uint64 {
+	return ls.Leaders[identity]
+}
+
 type U64ValueWithCtx struct {
 	Context ContextSlot `json:"context"`
 	Value   *uint64     `json:"value,omitempty"`
@@ -303,6 +602,11 @@ type LastBlockWithCtx struct {
 	LastBlock LastBlock   `json:"value"`
 }
 
+type BlockhashValidWithCtx struct {
+	Context ContextSlot `json:"context"`
+	Valid   bool        `json:"value"`
+}
+
 type AccountsInfoWithCtx struct {
 	Context  ContextSlot    `json:"context"`
 	Accounts []*AccountInfo `json:"value,omitempty"`
@@ -339,15 +643,22 @@ type SignatureStatus struct {
 	Slot uint64 `json:"slot"`
 	// Error if transaction failed, null if transaction succeeded. See TransactionError definitions for more info.
 	Err json.RawMessage `json:"err"`
-	// estimated production time, as Unix timestamp (seconds since the Unix epoch) of when transaction was processed. null if not available.
+	// Number of blocks since signature confirmation, null if rooted, as well as finalized by a supermajority of the cluster
 	Confirmations *uint64 `json:"confirmations,omitempty"`
 	// The transaction's cluster confirmation status; Either processed, confirmed, or finalized.
 	ConfirmationStatus string `json:"confirmationStatus,omitempty"`
 }
 
+// Time returns the block time of the transaction, the zero time when not available
+func (si SignatureInfo) Time() time.Time {
+	return UnixTime(si.BlockTime)
+}
+
+// SignatureStatusWithCtx statuses in the order of the requested signatures,
+// an unknown signature has a nil status
 type SignatureStatusWithCtx struct {
-	Context         ContextSlot       `json:"context"`
-	SignatureStatus []SignatureStatus `json:"value,omitempty"`
+	Context         ContextSlot        `json:"context"`
+	SignatureStatus []*SignatureStatus `json:"value,omitempty"`
 }
 
 type SignatureInfo struct {
@@ -395,6 +706,20 @@ type TokenAccountWithCtx struct {
 	UiToken UiTokenAmount `json:"value"`
 }
 
+type SimulateTxResult struct {
+	// Error if transaction failed, null if transaction succeeded.
+	Err json.RawMessage `json:"err"`
+	// Array of log messages the transaction instructions output during execution
+	Logs []string `json:"logs"`
+	// The number of compute budget units consumed during the processing of this transaction
+	UnitsConsumed *uint64 `json:"unitsConsumed"`
+}
+
+type SimulateTxResultWithCtx struct {
+	Context ContextSlot      `json:"context"`
+	Result  SimulateTxResult `json:"value"`
+}
+
 type TokenAccount struct {
 	Account AccountInfo    `json:"account"`
 	Pubkey  common.Address `json:"pubkey,omitempty"`
@@ -410,6 +735,40 @@ type TokenLargestHolders struct {
 	Holders []UiTokenAmount `json:"value"`
 }
 
+// TokenHolder a token account of the largest holders, with its raw amount
+type TokenHolder struct {
+	// The token account address
+	Address common.Address
+	// Raw amount of tokens, ignoring decimals
+	Amount uint64
+	// Number of decimals configured for token's mint
+	Decimals uint8
+	// Token amount as a float, accounting for decimals
+	UiAmount float64
+}
+
+// TokenHolders Returns the holders with their account address, it fails if a holder
+// has no address or its amount is not a valid uint64
+func (th TokenLargestHolders) TokenHolders() ([]TokenHolder, error) {
+	holders := make([]TokenHolder, len(th.Holders))
+	for i, holder := range th.Holders {
+		if holder.Address == nil {
+			return nil, fmt.Errorf("token holder %d has no address", i)
+		}
+		amount, err := holder.AmountUint64()
+		if err != nil {
+			return nil, err
+		}
+		holders[i] = TokenHolder{
+			Address:  *holder.Address,
+			Amount:   amount,
+			Decimals: holder.Decimals,
+			UiAmount: holder.UiAmount,
+		}
+	}
+	return holders, nil
+}
+
 type SolVersion struct {
 	// software version of solana-core as a string
 	SolanaCore string `json:"solana-core"`
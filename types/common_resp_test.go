@@ -0,0 +1,161 @@
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/cielu/go-solana/common"
+)
+
+func TestUiTokenAmount(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  UiTokenAmount
+		uint64  uint64
+		decimal string
+		fails   bool
+	}{
+		{
+			name:    "9 decimals",
+			amount:  UiTokenAmount{Amount: "123456789012345678", Decimals: 9},
+			uint64:  123456789012345678,
+			decimal: "123456789.012345678",
+		},
+		{
+			name:    "nft",
+			amount:  UiTokenAmount{Amount: "1", Decimals: 0},
+			uint64:  1,
+			decimal: "1",
+		},
+		{
+			name:   "invalid",
+			amount: UiTokenAmount{Amount: "1.5", Decimals: 0},
+			fails:  true,
+		},
+	}
+	for _, test := range tests {
+		amount, err := test.amount.AmountUint64()
+		if test.fails {
+			if err == nil {
+				t.Errorf("%s: AmountUint64 should fail", test.name)
+			}
+			continue
+		}
+		if err != nil || amount != test.uint64 {
+			t.Errorf("%s: AmountUint64 Err ==> Got %d, %v, Want: %d", test.name, amount, err, test.uint64)
+		}
+		decimal, err := test.amount.UiAmountDecimal()
+		if err != nil {
+			t.Fatalf("%s: UiAmountDecimal Failed: %s", test.name, err)
+		}
+		if got := decimal.FloatString(int(test.amount.Decimals)); got != test.decimal {
+			t.Errorf("%s: UiAmountDecimal Err ==> Got %s, Want: %s", test.name, got, test.decimal)
+		}
+	}
+	// a supply over uint64
+	large := UiTokenAmount{Amount: "340282366920938463463374607431768211455", Decimals: 9}
+	if _, err := large.AmountUint64(); err == nil {
+		t.Errorf("AmountUint64 with overflow should fail")
+	}
+	amount, err := large.AmountBigInt()
+	want, _ := new(big.Int).SetString(large.Amount, 10)
+	if err != nil || amount.Cmp(want) != 0 {
+		t.Errorf("AmountBigInt Err ==> Got %v, %v, Want: %s", amount, err, want)
+	}
+}
+
+func TestTransactionMetaProgramLogs(t *testing.T) {
+	var (
+		program = common.StrToAddress("JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4")
+		token   = "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"
+		meta    = TransactionMeta{LogMessages: []string{
+			"Program ComputeBudget111111111111111111111111111111 invoke [1]",
+			"Program ComputeBudget111111111111111111111111111111 success",
+			"Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 invoke [1]",
+			"Program log: Instruction: Route",
+			"Program " + token + " invoke [2]",
+			"Program log: Instruction: Transfer",
+			"Program " + token + " consumed 4645 of 180000 compute units",
+			"Program " + token + " success",
+			"Program data: ZXZlbnQ=",
+			"Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 invoke [2]",
+			"Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 consumed 2000 of 170000 compute units",
+			"Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 success",
+			"Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 consumed 30000 of 200000 compute units",
+			"Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 success",
+			"Program " + token + " invoke [1]",
+			"Program log: Instruction: CloseAccount",
+			"Program " + token + " failed: custom program error: 0x1",
+		}}
+	)
+	want := []string{
+		"Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 invoke [1]",
+		"Program log: Instruction: Route",
+		"Program data: ZXZlbnQ=",
+		"Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 invoke [2]",
+		"Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 consumed 2000 of 170000 compute units",
+		"Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 success",
+		"Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 consumed 30000 of 200000 compute units",
+		"Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 success",
+	}
+	if got := meta.ProgramLogs(program); !reflect.DeepEqual(got, want) {
+		t.Errorf("ProgramLogs Err ==> Got %q, Want: %q", got, want)
+	}
+	tokenLogs := meta.ProgramLogs(common.StrToAddress(token))
+	if len(tokenLogs) != 7 || tokenLogs[6] != "Program "+token+" failed: custom program error: 0x1" {
+		t.Errorf("ProgramLogs token Err ==> Got %q", tokenLogs)
+	}
+	if got := meta.ProgramLogs(common.StrToAddress("11111111111111111111111111111111")); got != nil {
+		t.Errorf("ProgramLogs Err ==> Got %q, Want: nil", got)
+	}
+	// the messages after the truncation are dropped
+	meta.LogMessages = []string{
+		"Program " + token + " invoke [1]",
+		"Program log: Instruction: Transfer",
+		"Log truncated",
+		"Program " + token + " success",
+	}
+	if got := meta.ProgramLogs(common.StrToAddress(token)); !reflect.DeepEqual(got, meta.LogMessages[:3]) {
+		t.Errorf("ProgramLogs truncated Err ==> Got %q, Want: %q", got, meta.LogMessages[:3])
+	}
+}
+
+func TestBlockInfoRewards(t *testing.T) {
+	input := `{"err": null, "blockHeight": 280000000, "blockTime": 1700000000, "parentSlot": 301999999,
+		"blockhash": "EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N", "previousBlockhash": "EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N",
+		"rewards": [
+			{"pubkey": "he1iusunGwqrNtafDtLdhsUQDFvo13z9sUa36PauBtk", "lamports": 1520000, "postBalance": 98000000000, "rewardType": "Staking", "commission": 7},
+			{"pubkey": "HEL1USMZKAL2odpNBj2oCjffnFGaYwmbGmyewGv1e2TU", "lamports": -2000, "postBalance": 500000, "rewardType": "rent", "commission": null},
+			{"pubkey": "HEL1USMZKAL2odpNBj2oCjffnFGaYwmbGmyewGv1e2TU", "lamports": 5000, "postBalance": 505000, "rewardType": "Fee"},
+			{"pubkey": "HEL1USMZKAL2odpNBj2oCjffnFGaYwmbGmyewGv1e2TU", "lamports": 1, "postBalance": 505001, "rewardType": null}
+		]}`
+	var block BlockInfo
+	if err := json.Unmarshal([]byte(input), &block); err != nil {
+		t.Fatalf("Unmarshal Failed: %s", err)
+	}
+	if block.Err != nil {
+		t.Errorf("BlockInfo.Err Err ==> Got %s, Want: nil", block.Err)
+	}
+	if len(block.Rewards) != 4 {
+		t.Fatalf("Rewards Err ==> Got %d, Want: 4", len(block.Rewards))
+	}
+	staking, rent, fee := block.Rewards[0], block.Rewards[1], block.Rewards[2]
+	if staking.RewardType != RewardTypeStaking || staking.Commission == nil || *staking.Commission != 7 {
+		t.Errorf("staking reward Err ==> Got %s %v, Want: Staking 7", staking.RewardType, staking.Commission)
+	}
+	if rent.RewardType != RewardTypeRent || rent.Commission != nil || rent.Lamports.Int64() != -2000 {
+		t.Errorf("rent reward Err ==> Got %s %v %s, Want: Rent nil -2000", rent.RewardType, rent.Commission, rent.Lamports)
+	}
+	if fee.RewardType != RewardTypeFee || fee.Commission != nil {
+		t.Errorf("fee reward Err ==> Got %s %v, Want: Fee nil", fee.RewardType, fee.Commission)
+	}
+	if block.Rewards[3].RewardType != "" {
+		t.Errorf("null reward type Err ==> Got %s, Want: empty", block.Rewards[3].RewardType)
+	}
+	// a failed block keeps the error
+	if err := json.Unmarshal([]byte(`{"err": "BlockNotAvailable"}`), &block); err != nil || string(block.Err) != `"BlockNotAvailable"` {
+		t.Errorf("BlockInfo.Err Err ==> Got %s %v, Want: \"BlockNotAvailable\"", block.Err, err)
+	}
+}
@@ -0,0 +1,27 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNewSlotRange(t *testing.T) {
+	r, err := NewSlotRange(100, 200)
+	if err != nil || r.FirstSlot != 100 || r.LastSlot != 200 {
+		t.Errorf("NewSlotRange Err ==> Got %+v %v, Want: 100-200", r, err)
+	}
+	if _, err = NewSlotRange(100, 100); err != nil {
+		t.Errorf("NewSlotRange Err ==> Got %v, Want: a single slot range", err)
+	}
+	if _, err = NewSlotRange(200, 100); err == nil {
+		t.Errorf("NewSlotRange Err ==> Got nil, Want: inverted range error")
+	}
+	// the last slot defaults to the current slot
+	r, err = NewSlotRange(0, 0)
+	if err != nil {
+		t.Fatalf("NewSlotRange Failed: %s", err)
+	}
+	if data, _ := json.Marshal(r); string(data) != `{"firstSlot":0}` {
+		t.Errorf("SlotRange Err ==> Got %s, Want: {\"firstSlot\":0}", data)
+	}
+}
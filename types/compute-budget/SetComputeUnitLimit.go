@@ -46,21 +46,21 @@ func (obj *SetComputeUnitLimit) SetUnits(units uint32) *SetComputeUnitLimit {
 	return obj
 }
 
-func (obj SetComputeUnitLimit) Build() *Instruction {
-	return &Instruction{BaseVariant: encodbin.BaseVariant{
+func (obj SetComputeUnitLimit) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{BaseVariant: encodbin.BaseVariant{
 		Impl:   obj,
 		TypeID: encodbin.TypeIDFromUint8(Instruction_SetComputeUnitLimit),
-	}}
+	}}, opts)
 }
 
 // ValidateAndBuild validates the instruction parameters and accounts;
 // if there is a validation error, it returns the error.
 // Otherwise, it builds and returns the instruction.
-func (obj SetComputeUnitLimit) ValidateAndBuild() (*Instruction, error) {
+func (obj SetComputeUnitLimit) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
 	if err := obj.Validate(); err != nil {
 		return nil, err
 	}
-	return obj.Build(), nil
+	return obj.Build(opts...), nil
 }
 
 func (obj *SetComputeUnitLimit) Validate() error {
@@ -43,21 +43,21 @@ func (obj *SetComputeUnitPrice) SetMicroLamports(microLamports uint64) *SetCompu
 	return obj
 }
 
-func (obj SetComputeUnitPrice) Build() *Instruction {
-	return &Instruction{BaseVariant: encodbin.BaseVariant{
+func (obj SetComputeUnitPrice) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{BaseVariant: encodbin.BaseVariant{
 		Impl:   obj,
 		TypeID: encodbin.TypeIDFromUint8(Instruction_SetComputeUnitPrice),
-	}}
+	}}, opts)
 }
 
 // ValidateAndBuild validates the instruction parameters and accounts;
 // if there is a validation error, it returns the error.
 // Otherwise, it builds and returns the instruction.
-func (obj SetComputeUnitPrice) ValidateAndBuild() (*Instruction, error) {
+func (obj SetComputeUnitPrice) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
 	if err := obj.Validate(); err != nil {
 		return nil, err
 	}
-	return obj.Build(), nil
+	return obj.Build(opts...), nil
 }
 
 func (obj *SetComputeUnitPrice) Validate() error {
@@ -80,7 +80,7 @@ func (obj SetComputeUnitPrice) MarshalWithEncoder(encoder *encodbin.Encoder) (er
 }
 func (obj *SetComputeUnitPrice) UnmarshalWithDecoder(decoder *encodbin.Decoder) (err error) {
 	// Deserialize `MicroLamports`:
-	err = decoder.Decode(obj.MicroLamports)
+	err = decoder.Decode(&obj.MicroLamports)
 	if err != nil {
 		return err
 	}
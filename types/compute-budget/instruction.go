@@ -43,9 +43,20 @@ const (
 
 type Instruction struct {
 	encodbin.BaseVariant
+	// programID overrides base.ComputeBudget when set, see base.WithProgramID
+	programID common.Address
+}
+
+// withBuildOptions applies the options of Build to the instruction
+func withBuildOptions(inst *Instruction, opts []base.BuildOption) *Instruction {
+	inst.programID = base.NewBuildOptions(opts...).ProgramID
+	return inst
 }
 
 func (inst *Instruction) ProgramID() common.Address {
+	if !inst.programID.IsEmpty() {
+		return inst.programID
+	}
 	return base.ComputeBudget
 }
 
@@ -68,3 +79,26 @@ func (inst *Instruction) MarshalWithEncoder(encoder *encodbin.Encoder) error {
 	}
 	return encoder.Encode(inst.Impl)
 }
+
+// DecodeInstruction decodes the data of a compute budget instruction into
+// its typed instruction, e.g. a *SetComputeUnitPrice
+func DecodeInstruction(accounts []*base.AccountMeta, data []byte) (interface{}, error) {
+	decoder := encodbin.NewBinDecoder(data)
+	typeID, err := decoder.ReadUint8()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read instruction type: %w", err)
+	}
+	var inst base.AccountsSettable
+	switch typeID {
+	case Instruction_SetComputeUnitLimit:
+		inst = new(SetComputeUnitLimit)
+	case Instruction_SetComputeUnitPrice:
+		inst = new(SetComputeUnitPrice)
+	default:
+		return nil, fmt.Errorf("unsupported compute budget instruction: %d", typeID)
+	}
+	if err = decoder.Decode(inst); err != nil {
+		return nil, fmt.Errorf("unable to decode compute budget instruction %d: %w", typeID, err)
+	}
+	return inst, nil
+}
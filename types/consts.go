@@ -32,6 +32,16 @@ const (
 	EncodingJsonParsed EnumEncoding = "jsonParsed"
 )
 
+type EnumRewardType string
+
+// type of a block reward, empty when the node omits it
+const (
+	RewardTypeFee     EnumRewardType = "Fee"
+	RewardTypeRent    EnumRewardType = "Rent"
+	RewardTypeStaking EnumRewardType = "Staking"
+	RewardTypeVoting  EnumRewardType = "Voting"
+)
+
 type EnumTxDetailLevel string
 
 // level of transaction detail to return
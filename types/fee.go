@@ -0,0 +1,152 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package types
+
+import (
+	"encoding/binary"
+	"math"
+	"math/big"
+	"sort"
+
+	"github.com/cielu/go-solana/types/base"
+	computebudget "github.com/cielu/go-solana/types/compute-budget"
+)
+
+const (
+	// DefaultInstructionComputeUnitLimit the compute unit limit of each instruction without SetComputeUnitLimit
+	DefaultInstructionComputeUnitLimit = 200_000
+	// microLamportsPerLamport the compute unit price is in micro-lamports
+	microLamportsPerLamport = 1_000_000
+)
+
+// FeeBreakdown the fee of a transaction
+type FeeBreakdown struct {
+	// Signature fee, the number of signatures times the lamports per signature
+	BaseFee              uint64
+	LamportsPerSignature uint64
+	Signatures           uint8
+	// Prioritization fee, the compute unit limit times the compute unit price
+	PriorityFee      uint64
+	ComputeUnitLimit uint32
+	// Compute unit price in micro-lamports
+	ComputeUnitPrice uint64
+	// BaseFee + PriorityFee in lamports
+	Total uint64
+}
+
+// ComputeBudget Returns the compute unit limit and price (micro-lamports) set by the compute budget instructions
+// of the message. Without SetComputeUnitLimit the limit is DefaultInstructionComputeUnitLimit per
+// instruction other than compute budget, up to MaxComputeUnitLimit
+func (m *Message) ComputeBudget() (unitLimit uint32, unitPrice uint64) {
+	var (
+		hasLimit     bool
+		instructions uint32
+	)
+	for _, instruction := range m.Instructions {
+		if int(instruction.ProgramIDIndex) >= len(m.AccountKeys) {
+			continue
+		}
+		programID, data := m.AccountKeys[instruction.ProgramIDIndex], instruction.Data
+		switch {
+		case isComputeBudgetInstruction(programID, data, computebudget.Instruction_SetComputeUnitLimit) && len(data) >= 5:
+			unitLimit, hasLimit = binary.LittleEndian.Uint32(data[1:]), true
+		case isComputeBudgetInstruction(programID, data, computebudget.Instruction_SetComputeUnitPrice) && len(data) >= 9:
+			unitPrice = binary.LittleEndian.Uint64(data[1:])
+		case programID != base.ComputeBudgetProgramID:
+			instructions++
+		}
+	}
+	if !hasLimit {
+		unitLimit = instructions * DefaultInstructionComputeUnitLimit
+	}
+	if unitLimit > MaxComputeUnitLimit {
+		unitLimit = MaxComputeUnitLimit
+	}
+	return unitLimit, unitPrice
+}
+
+// WithoutComputeUnitPrice Returns a copy of the message with a zero compute unit price, the instructions
+// and account keys are unchanged so it has the same signature fee
+func (m *Message) WithoutComputeUnitPrice() *Message {
+	msg := *m
+	msg.Instructions = make([]CompiledInstruction, len(m.Instructions))
+	copy(msg.Instructions, m.Instructions)
+	for i, instruction := range msg.Instructions {
+		if int(instruction.ProgramIDIndex) < len(m.AccountKeys) &&
+			isComputeBudgetInstruction(m.AccountKeys[instruction.ProgramIDIndex], instruction.Data, computebudget.Instruction_SetComputeUnitPrice) {
+			data := make([]byte, len(instruction.Data))
+			data[0] = instruction.Data[0]
+			msg.Instructions[i].Data = data
+		}
+	}
+	return &msg
+}
+
+// PriorityFee Returns the prioritization fee in lamports of the compute unit limit
+// at the compute unit price in micro-lamports, rounded up
+func PriorityFee(unitLimit uint32, unitPrice uint64) uint64 {
+	fee := new(big.Int).Mul(big.NewInt(int64(unitLimit)), new(big.Int).SetUint64(unitPrice))
+	fee.Add(fee, big.NewInt(microLamportsPerLamport-1))
+	fee.Div(fee, big.NewInt(microLamportsPerLamport))
+	if !fee.IsUint64() {
+		return ^uint64(0)
+	}
+	return fee.Uint64()
+}
+
+// RpcPrioritizationFees the prioritization fees of getRecentPrioritizationFees, the aggregates are 0 when empty
+type RpcPrioritizationFees []RpcPrioritizationFee
+
+// sorted returns the prioritization fees in ascending order
+func (fees RpcPrioritizationFees) sorted() []uint64 {
+	values := make([]uint64, len(fees))
+	for i, fee := range fees {
+		values[i] = fee.PrioritizationFee
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	return values
+}
+
+// Percentile Returns the p-th percentile (0-100) of the prioritization fees, the nearest rank of p% of the
+// way from the lowest to the highest fee. p is clamped to [0, 100]
+func (fees RpcPrioritizationFees) Percentile(p float64) uint64 {
+	if len(fees) == 0 {
+		return 0
+	}
+	if p < 0 || math.IsNaN(p) {
+		p = 0
+	} else if p > 100 {
+		p = 100
+	}
+	values := fees.sorted()
+	return values[int(math.Round(p/100*float64(len(values)-1)))]
+}
+
+// Median Returns the 50th percentile of the prioritization fees, the upper median of an even count
+func (fees RpcPrioritizationFees) Median() uint64 {
+	return fees.Percentile(50)
+}
+
+// Max Returns the highest prioritization fee
+func (fees RpcPrioritizationFees) Max() uint64 {
+	var max uint64
+	for _, fee := range fees {
+		if fee.PrioritizationFee > max {
+			max = fee.PrioritizationFee
+		}
+	}
+	return max
+}
+
+// Average Returns the mean of the prioritization fees, rounded down
+func (fees RpcPrioritizationFees) Average() uint64 {
+	if len(fees) == 0 {
+		return 0
+	}
+	sum := new(big.Int)
+	for _, fee := range fees {
+		sum.Add(sum, new(big.Int).SetUint64(fee.PrioritizationFee))
+	}
+	return sum.Div(sum, big.NewInt(int64(len(fees)))).Uint64()
+}
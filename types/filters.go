@@ -0,0 +1,75 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package types
+
+import (
+	"encoding/base64"
+	"github.com/mr-tron/base58"
+)
+
+// RpcMemcmp compares a provided series of bytes with program account data at a particular offset
+type RpcMemcmp struct {
+	// offset into program account data to start comparison
+	Offset uint64 `json:"offset"`
+	// data to match, as encoded string
+	Bytes string `json:"bytes"`
+	// encoding for filter bytes data, either "base58" or "base64", default base58
+	Encoding EnumEncoding `json:"encoding,omitempty"`
+}
+
+// RpcFilter getProgramAccounts filter, either memcmp or dataSize
+type RpcFilter struct {
+	Memcmp   *RpcMemcmp `json:"memcmp,omitempty"`
+	DataSize *uint64    `json:"dataSize,omitempty"`
+}
+
+// Filters list of RpcFilter
+type Filters []RpcFilter
+
+// Memcmp filter the account data bytes at offset, the bytes are base58 encoded
+func Memcmp(offset uint64, bytes []byte) RpcFilter {
+	return RpcFilter{Memcmp: &RpcMemcmp{Offset: offset, Bytes: base58.Encode(bytes)}}
+}
+
+// MemcmpWithEncoding filter the account data bytes at offset,
+// base64 is preferred for large filter bytes
+func MemcmpWithEncoding(offset uint64, bytes []byte, encoding EnumEncoding) RpcFilter {
+	if encoding == EncodingBase64 {
+		return RpcFilter{Memcmp: &RpcMemcmp{Offset: offset, Bytes: base64.StdEncoding.EncodeToString(bytes), Encoding: encoding}}
+	}
+	return Memcmp(offset, bytes)
+}
+
+// DataSize filter the account data length
+func DataSize(size uint64) RpcFilter {
+	return RpcFilter{DataSize: &size}
+}
+
+// Map return the raw map form of filter, see RpcCombinedCfg.Filter
+func (f RpcFilter) Map() map[string]interface{} {
+	m := make(map[string]interface{})
+	if f.Memcmp != nil {
+		memcmp := map[string]interface{}{
+			"offset": f.Memcmp.Offset,
+			"bytes":  f.Memcmp.Bytes,
+		}
+		if f.Memcmp.Encoding != "" {
+			memcmp["encoding"] = f.Memcmp.Encoding
+		}
+		m["memcmp"] = memcmp
+	}
+	if f.DataSize != nil {
+		m["dataSize"] = *f.DataSize
+	}
+	return m
+}
+
+// Maps return the raw map form of filters, used as RpcCombinedCfg.Filter
+func (fs Filters) Maps() []map[string]interface{} {
+	out := make([]map[string]interface{}, len(fs))
+	for i, f := range fs {
+		out[i] = f.Map()
+	}
+	return out
+}
@@ -0,0 +1,51 @@
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// jsonEqual compare two json documents regardless of key order
+func jsonEqual(a, b []byte) bool {
+	var va, vb interface{}
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+func TestFilters(t *testing.T) {
+	filters := Filters{
+		DataSize(17),
+		Memcmp(4, []byte{1, 2, 3, 4}),
+		MemcmpWithEncoding(8, []byte{1, 2, 3, 4}, EncodingBase64),
+	}
+	raw := []map[string]interface{}{
+		{"dataSize": 17},
+		{"memcmp": map[string]interface{}{"offset": 4, "bytes": "2VfUX"}},
+		{"memcmp": map[string]interface{}{"offset": 8, "bytes": "AQIDBA==", "encoding": "base64"}},
+	}
+	want, _ := json.Marshal(raw)
+
+	got, err := json.Marshal(filters)
+	if err != nil {
+		t.Fatalf("Marshal Filters Failed: %s", err)
+	}
+	if !jsonEqual(got, want) {
+		t.Errorf("Filters JSON Err ==> Got %s, Want: %s", got, want)
+	}
+	// raw map form
+	got, err = json.Marshal(filters.Maps())
+	if err != nil {
+		t.Fatalf("Marshal Filters.Maps Failed: %s", err)
+	}
+	if !jsonEqual(got, want) {
+		t.Errorf("Filters.Maps JSON Err ==> Got %s, Want: %s", got, want)
+	}
+	// combined cfg
+	got, _ = json.Marshal(RpcCombinedCfg{Filter: filters.Maps()})
+	if want := `{"filters":` + string(want) + `}`; !jsonEqual(got, []byte(want)) {
+		t.Errorf("RpcCombinedCfg JSON Err ==> Got %s, Want: %s", got, want)
+	}
+}
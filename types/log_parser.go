@@ -0,0 +1,219 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package types
+
+import (
+	"encoding/base64"
+	"fmt"
+	"github.com/cielu/go-solana/common"
+	"strconv"
+	"strings"
+)
+
+// ProgramInvocation a program invocation of a transaction, as reported by its log messages.
+// Invocations is the programs it invoked via CPI, in order.
+// Both Success and Failed are false when the logs were truncated before the invocation ended
+type ProgramInvocation struct {
+	ProgramID common.Address
+	// Depth of the invocation, 1 for a transaction instruction
+	Depth int
+	// The "Program log: " messages, without prefix, and the unrecognized lines emitted by the program
+	Logs []string
+	// The decoded "Program data: " events
+	Data [][][]byte
+	// The return data set by the program
+	ReturnData []byte
+	// Compute units consumed, and the remaining budget when invoked. Not logged by the builtin programs
+	ComputeUnitsConsumed uint64
+	ComputeUnitsBudget   uint64
+
+	Success bool
+	Failed  bool
+	// The failure message, e.g. "custom program error: 0x1"
+	FailureMessage string
+
+	Invocations []*ProgramInvocation
+
+	// indexes of the messages parsed in the invocation, its own markers included
+	lines []int
+}
+
+// LogParser builds the program invocation tree from the log messages of a transaction,
+// fed one line at a time, e.g. from a logs subscription
+type LogParser struct {
+	invocations []*ProgramInvocation
+	stack       []*ProgramInvocation
+	truncated   bool
+	// number of messages parsed
+	count int
+}
+
+// ParseLogs Returns the program invocation tree of the log messages, one entry per transaction instruction
+func ParseLogs(logs []string) ([]*ProgramInvocation, error) {
+	var parser LogParser
+	for _, line := range logs {
+		if err := parser.Parse(line); err != nil {
+			return nil, err
+		}
+	}
+	return parser.Invocations(), nil
+}
+
+// ParseLogs Returns the program invocation tree of the transaction log messages, see ParseLogs
+func (meta TransactionMeta) ParseLogs() ([]*ProgramInvocation, error) {
+	return ParseLogs(meta.LogMessages)
+}
+
+// Invocations Returns the parsed transaction instruction invocations
+func (p *LogParser) Invocations() []*ProgramInvocation {
+	return p.invocations
+}
+
+// Truncated reports whether the runtime truncated the log messages, the invocations
+// still open stay neither successful nor failed
+func (p *LogParser) Truncated() bool {
+	return p.truncated
+}
+
+// Parse Adds a log message to the invocation tree, it fails if the message does not match the invocation stack
+func (p *LogParser) Parse(line string) error {
+	if p.truncated {
+		return nil
+	}
+	// the message belongs to the invocation it opens, or to the current one
+	depth := len(p.stack)
+	owner := p.current()
+	if err := p.parse(line); err != nil {
+		return err
+	}
+	if len(p.stack) > depth {
+		owner = p.current()
+	}
+	if owner != nil {
+		owner.lines = append(owner.lines, p.count)
+	}
+	p.count++
+	return nil
+}
+
+func (p *LogParser) current() *ProgramInvocation {
+	if len(p.stack) == 0 {
+		return nil
+	}
+	return p.stack[len(p.stack)-1]
+}
+
+func (p *LogParser) parse(line string) error {
+	if line == "Log truncated" {
+		p.truncated = true
+		return nil
+	}
+	current := p.current()
+	rest, ok := cutPrefix(line, "Program ")
+	if !ok {
+		current.addLog(line)
+		return nil
+	}
+	switch {
+	case strings.HasPrefix(rest, "log: "):
+		current.addLog(rest[len("log: "):])
+		return nil
+	case strings.HasPrefix(rest, "data: "):
+		if current == nil {
+			return fmt.Errorf("program data outside of an invocation: %q", line)
+		}
+		var data [][]byte
+		for _, field := range strings.Fields(rest[len("data: "):]) {
+			b, err := base64.StdEncoding.DecodeString(field)
+			if err != nil {
+				return fmt.Errorf("invalid program data %q: %w", line, err)
+			}
+			data = append(data, b)
+		}
+		current.Data = append(current.Data, data)
+		return nil
+	case strings.HasPrefix(rest, "return: "):
+		fields := strings.Fields(rest[len("return: "):])
+		if current == nil || len(fields) != 2 || fields[0] != current.ProgramID.String() {
+			return fmt.Errorf("program return outside of its invocation: %q", line)
+		}
+		b, err := base64.StdEncoding.DecodeString(fields[1])
+		if err != nil {
+			return fmt.Errorf("invalid program return %q: %w", line, err)
+		}
+		current.ReturnData = b
+		return nil
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) < 2 {
+		current.addLog(line)
+		return nil
+	}
+	programID, err := common.ParseAddress(fields[0])
+	if err != nil {
+		current.addLog(line)
+		return nil
+	}
+	switch {
+	case fields[1] == "invoke" && len(fields) == 3:
+		depth, err := strconv.Atoi(strings.Trim(fields[2], "[]"))
+		if err != nil || depth != len(p.stack)+1 {
+			return fmt.Errorf("invalid invoke depth %q, expected [%d]", line, len(p.stack)+1)
+		}
+		invocation := &ProgramInvocation{ProgramID: programID, Depth: depth}
+		if current == nil {
+			p.invocations = append(p.invocations, invocation)
+		} else {
+			current.Invocations = append(current.Invocations, invocation)
+		}
+		p.stack = append(p.stack, invocation)
+		return nil
+	case fields[1] == "consumed" && len(fields) == 7:
+		if current == nil || current.ProgramID != programID {
+			return fmt.Errorf("compute units outside of the invocation: %q", line)
+		}
+		if current.ComputeUnitsConsumed, err = strconv.ParseUint(fields[2], 10, 64); err != nil {
+			return fmt.Errorf("invalid compute units %q: %w", line, err)
+		}
+		if current.ComputeUnitsBudget, err = strconv.ParseUint(fields[4], 10, 64); err != nil {
+			return fmt.Errorf("invalid compute units %q: %w", line, err)
+		}
+		return nil
+	case fields[1] == "success" && len(fields) == 2:
+		if current == nil || current.ProgramID != programID {
+			return fmt.Errorf("success outside of the invocation: %q", line)
+		}
+		current.Success = true
+		p.stack = p.stack[:len(p.stack)-1]
+		return nil
+	case strings.HasPrefix(fields[1], "failed"):
+		if current == nil || current.ProgramID != programID {
+			return fmt.Errorf("failure outside of the invocation: %q", line)
+		}
+		current.Failed = true
+		if _, msg, ok := strings.Cut(rest, "failed: "); ok {
+			current.FailureMessage = msg
+		}
+		p.stack = p.stack[:len(p.stack)-1]
+		return nil
+	}
+	current.addLog(line)
+	return nil
+}
+
+// addLog add a message to the invocation, the messages outside of invocations are dropped
+func (pi *ProgramInvocation) addLog(msg string) {
+	if pi != nil {
+		pi.Logs = append(pi.Logs, msg)
+	}
+}
+
+// cutPrefix is strings.CutPrefix, which requires go1.20
+func cutPrefix(s, prefix string) (string, bool) {
+	if !strings.HasPrefix(s, prefix) {
+		return s, false
+	}
+	return s[len(prefix):], true
+}
@@ -0,0 +1,116 @@
+package types
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/cielu/go-solana/common"
+)
+
+func TestParseLogs(t *testing.T) {
+	var (
+		ata    = "ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL"
+		token  = "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"
+		system = "11111111111111111111111111111111"
+	)
+	// create an associated token account, CPI with depth 2
+	invocations, err := ParseLogs([]string{
+		"Program " + ata + " invoke [1]",
+		"Program log: Create",
+		"Program " + token + " invoke [2]",
+		"Program log: Instruction: GetAccountDataSize",
+		"Program " + token + " consumed 1569 of 394301 compute units",
+		"Program return: " + token + " pQAAAAAAAAA=",
+		"Program " + token + " success",
+		"Program " + system + " invoke [2]",
+		"Program " + system + " success",
+		"Program log: Initialize the associated token account",
+		"Program " + token + " invoke [2]",
+		"Program log: Instruction: InitializeImmutableOwner",
+		"Program log: Please upgrade to SPL Token 2022 for immutable owner support",
+		"Program " + token + " consumed 1405 of 387714 compute units",
+		"Program " + token + " success",
+		"Program " + ata + " consumed 20345 of 400000 compute units",
+		"Program " + ata + " success",
+	})
+	if err != nil {
+		t.Fatalf("ParseLogs Failed: %s", err)
+	}
+	if len(invocations) != 1 {
+		t.Fatalf("invocations Err ==> Got %d, Want: %d", len(invocations), 1)
+	}
+	root := invocations[0]
+	if root.ProgramID != common.StrToAddress(ata) || !root.Success || root.Failed || root.Depth != 1 ||
+		root.ComputeUnitsConsumed != 20345 || root.ComputeUnitsBudget != 400000 {
+		t.Errorf("invocation Err ==> Got %+v", root)
+	}
+	if want := []string{"Create", "Initialize the associated token account"}; !reflect.DeepEqual(root.Logs, want) {
+		t.Errorf("Logs Err ==> Got %q, Want: %q", root.Logs, want)
+	}
+	if len(root.Invocations) != 3 {
+		t.Fatalf("inner invocations Err ==> Got %d, Want: %d", len(root.Invocations), 3)
+	}
+	getSize, create, immutable := root.Invocations[0], root.Invocations[1], root.Invocations[2]
+	if getSize.ProgramID != common.StrToAddress(token) || getSize.Depth != 2 || getSize.ComputeUnitsConsumed != 1569 ||
+		!bytes.Equal(getSize.ReturnData, []byte{165, 0, 0, 0, 0, 0, 0, 0}) {
+		t.Errorf("GetAccountDataSize Err ==> Got %+v", getSize)
+	}
+	if create.ProgramID != common.StrToAddress(system) || !create.Success || create.ComputeUnitsConsumed != 0 {
+		t.Errorf("CreateAccount Err ==> Got %+v", create)
+	}
+	if len(immutable.Logs) != 2 || !immutable.Success {
+		t.Errorf("InitializeImmutableOwner Err ==> Got %+v", immutable)
+	}
+
+	// a swap whose inner transfer fails
+	meta := TransactionMeta{LogMessages: []string{
+		"Program ComputeBudget111111111111111111111111111111 invoke [1]",
+		"Program ComputeBudget111111111111111111111111111111 success",
+		"Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 invoke [1]",
+		"Program log: Instruction: Route",
+		"Program data: ZXZlbnQ= AQI=",
+		"Program " + token + " invoke [2]",
+		"Program log: Instruction: Transfer",
+		"Program log: Error: insufficient funds",
+		"Program " + token + " consumed 4381 of 1385450 compute units",
+		"Program " + token + " failed: custom program error: 0x1",
+		"Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 consumed 19550 of 1400000 compute units",
+		"Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 failed: custom program error: 0x1",
+	}}
+	if invocations, err = meta.ParseLogs(); err != nil {
+		t.Fatalf("ParseLogs Failed: %s", err)
+	}
+	if len(invocations) != 2 || !invocations[0].Success || len(invocations[1].Invocations) != 1 {
+		t.Fatalf("invocations Err ==> Got %+v", invocations)
+	}
+	swap, transfer := invocations[1], invocations[1].Invocations[0]
+	if !swap.Failed || swap.Success || swap.FailureMessage != "custom program error: 0x1" {
+		t.Errorf("swap Err ==> Got %+v", swap)
+	}
+	if want := [][][]byte{{[]byte("event"), {1, 2}}}; !reflect.DeepEqual(swap.Data, want) {
+		t.Errorf("Data Err ==> Got %v, Want: %v", swap.Data, want)
+	}
+	if !transfer.Failed || transfer.FailureMessage != "custom program error: 0x1" || transfer.Logs[1] != "Error: insufficient funds" {
+		t.Errorf("transfer Err ==> Got %+v", transfer)
+	}
+
+	// truncated logs leave the invocation open
+	var parser LogParser
+	for _, line := range []string{"Program " + token + " invoke [1]", "Log truncated", "Program " + token + " success"} {
+		if err = parser.Parse(line); err != nil {
+			t.Fatalf("Parse Failed: %s", err)
+		}
+	}
+	if open := parser.Invocations(); !parser.Truncated() || len(open) != 1 || open[0].Success || open[0].Failed {
+		t.Errorf("truncated Err ==> Got %+v", open)
+	}
+
+	// mismatched markers
+	if _, err = ParseLogs([]string{"Program " + token + " invoke [2]"}); err == nil {
+		t.Errorf("ParseLogs with invoke [2] at the top should fail")
+	}
+	if _, err = ParseLogs([]string{"Program " + token + " invoke [1]", "Program " + system + " success"}); err == nil {
+		t.Errorf("ParseLogs with success of another program should fail")
+	}
+}
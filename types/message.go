@@ -22,6 +22,9 @@ const (
 	MessageVersionV0     MessageVersion = 1 // v0
 )
 
+// messageVersionPrefix the first byte of a v0 message, the high bit marks a versioned message
+const messageVersionPrefix = 0x80
+
 type MessageAddressTableLookupSlice []MessageAddressTableLookup
 
 // NumLookups returns the number of accounts in all the MessageAddressTableLookupSlice
@@ -76,6 +79,8 @@ type Message struct {
 	// NOTE: you need to fetch these from the chain, and then call `SetAddressTables`
 	// before you use this transaction -- otherwise, you will get a panic.
 	addressTables map[common.Address][]common.Address
+	// The addresses loaded from lookup tables, appended to AccountKeys by WithLoadedAddresses
+	loadedAddresses LoadedAddresses
 }
 
 // GetProgram current program address
@@ -83,16 +88,72 @@ func (m Message) GetProgram(idIndex uint16) common.Address {
 	return m.AccountKeys[idIndex]
 }
 
+// SetVersion sets the version of the message, MarshalBinary serializes it accordingly
+func (m *Message) SetVersion(version MessageVersion) *Message {
+	m.version = version
+	return m
+}
+
+// GetVersion returns the version of the message
+func (m *Message) GetVersion() MessageVersion {
+	return m.version
+}
+
+// IsVersioned reports whether the message is a versioned (v0) message
+func (m *Message) IsVersioned() bool {
+	return m.version != MessageVersionLegacy
+}
+
+// SetAddressTableLookups sets the address table lookups of the message, which becomes a v0 message
+func (m *Message) SetAddressTableLookups(lookups MessageAddressTableLookupSlice) *Message {
+	m.addressTableLookups = lookups
+	m.version = MessageVersionV0
+	return m
+}
+
+// MarshalBinary serializes the message without signatures, as a legacy or v0 message per its version
 func (m *Message) MarshalBinary() ([]byte, error) {
+	switch m.version {
+	case MessageVersionV0:
+		return m.MarshalV0()
+	case MessageVersionLegacy:
+		return m.MarshalLegacy()
+	default:
+		return nil, fmt.Errorf("invalid message version: %d", m.version)
+	}
+}
 
-	buf := []byte{
+// MarshalLegacy serializes the message as a legacy message
+func (m *Message) MarshalLegacy() ([]byte, error) {
+	return m.marshalContent(nil), nil
+}
+
+// MarshalV0 serializes the message as a v0 message: the version prefix, the legacy content,
+// then the address table lookups. The accounts loaded from the lookups are not serialized
+func (m *Message) MarshalV0() ([]byte, error) {
+	buf := m.marshalContent([]byte{messageVersionPrefix})
+	encodbin.EncodeCompactU16Length(&buf, len(m.addressTableLookups))
+	for _, lookup := range m.addressTableLookups {
+		buf = append(buf, lookup.AccountKey[:]...)
+		encodbin.EncodeCompactU16Length(&buf, len(lookup.WritableIndexes))
+		buf = append(buf, lookup.WritableIndexes...)
+		encodbin.EncodeCompactU16Length(&buf, len(lookup.ReadonlyIndexes))
+		buf = append(buf, lookup.ReadonlyIndexes...)
+	}
+	return buf, nil
+}
+
+// marshalContent appends the header, the static account keys, the blockhash and the instructions to buf
+func (m *Message) marshalContent(buf []byte) []byte {
+	buf = append(buf,
 		m.Header.NumRequiredSignatures,
 		m.Header.NumReadonlySignedAccounts,
 		m.Header.NumReadonlyUnsignedAccounts,
-	}
+	)
 
-	encodbin.EncodeCompactU16Length(&buf, len(m.AccountKeys))
-	for _, key := range m.AccountKeys {
+	staticKeys := m.AccountKeys[:m.numStaticAccountKeys()]
+	encodbin.EncodeCompactU16Length(&buf, len(staticKeys))
+	for _, key := range staticKeys {
 		buf = append(buf, key[:]...)
 	}
 
@@ -110,7 +171,7 @@ func (m *Message) MarshalBinary() ([]byte, error) {
 
 		buf = append(buf, instruction.Data...)
 	}
-	return buf, nil
+	return buf
 }
 
 // Signers returns the pubkeys of all accounts that are signers.
@@ -134,6 +195,25 @@ func (m *Message) Writable() (out []common.Address) {
 	return out
 }
 
+// HasComputeBudgetInstruction reports whether the message has a compute budget
+// instruction of the kind, e.g. computebudget.Instruction_SetComputeUnitPrice
+func (m *Message) HasComputeBudgetInstruction(kind uint8) bool {
+	for _, instruction := range m.Instructions {
+		if int(instruction.ProgramIDIndex) >= len(m.AccountKeys) {
+			continue
+		}
+		if isComputeBudgetInstruction(m.AccountKeys[instruction.ProgramIDIndex], instruction.Data, kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// isComputeBudgetInstruction reports whether the instruction is a compute budget instruction of the kind
+func isComputeBudgetInstruction(programID common.Address, data []byte, kind uint8) bool {
+	return programID == base.ComputeBudgetProgramID && len(data) > 0 && data[0] == kind
+}
+
 func (m *Message) IsSigner(account common.Address) bool {
 	for idx, acc := range m.AccountKeys {
 		if acc == account {
@@ -155,9 +235,84 @@ func (m *Message) IsWritable(account common.Address) bool {
 	if !found {
 		return false
 	}
+	return m.isWritableIndex(index)
+}
+
+// isWritableIndex reports whether the account key at the index is writable
+func (m *Message) isWritableIndex(index int) bool {
+	numStatic := m.numStaticAccountKeys()
+	// loaded addresses, writable first
+	if index >= numStatic {
+		return index < numStatic+len(m.loadedAddresses.Writable)
+	}
 	h := m.Header
 	return (index < int(h.NumRequiredSignatures-h.NumReadonlySignedAccounts)) ||
-		((index >= int(h.NumRequiredSignatures)) && (index < len(m.AccountKeys)-int(h.NumReadonlyUnsignedAccounts)))
+		((index >= int(h.NumRequiredSignatures)) && (index < numStatic-int(h.NumReadonlyUnsignedAccounts)))
+}
+
+// AddressTableLookups returns the address table lookups of a v0 message, nil for a legacy message
+func (m *Message) AddressTableLookups() MessageAddressTableLookupSlice {
+	return m.addressTableLookups
+}
+
+// GetTableIDs returns the accounts of the address tables the message looks up,
+// fetch them from the chain and pass them to SetAddressTables
+func (m *Message) GetTableIDs() []common.Address {
+	return m.addressTableLookups.GetTableIDs()
+}
+
+// SetAddressTables sets the address lookup tables of a v0 message, keyed by the table account,
+// with the addresses fetched from the chain. Call ResolveLookups to load the accounts.
+func (m *Message) SetAddressTables(tables map[common.Address][]common.Address) {
+	m.addressTables = tables
+}
+
+// ResolveLookups appends the addresses looked up from the address tables to AccountKeys, the writable
+// ones of every lookup first, then the readonly ones, in the order the runtime loads them,
+// so IsWritable, IsSigner and Writable account for them. It does nothing for a legacy message
+// or when the lookups are already resolved.
+func (m *Message) ResolveLookups() error {
+	if len(m.addressTableLookups) == 0 || len(m.loadedAddresses.Writable)+len(m.loadedAddresses.ReadOnly) > 0 {
+		return nil
+	}
+	var loaded LoadedAddresses
+	for _, lookup := range m.addressTableLookups {
+		table, ok := m.addressTables[lookup.AccountKey]
+		if !ok {
+			return fmt.Errorf("address table %s not set", lookup.AccountKey)
+		}
+		for _, index := range lookup.WritableIndexes {
+			if int(index) >= len(table) {
+				return fmt.Errorf("writable index %d out of range of address table %s", index, lookup.AccountKey)
+			}
+			loaded.Writable = append(loaded.Writable, table[index])
+		}
+	}
+	for _, lookup := range m.addressTableLookups {
+		table := m.addressTables[lookup.AccountKey]
+		for _, index := range lookup.ReadonlyIndexes {
+			if int(index) >= len(table) {
+				return fmt.Errorf("readonly index %d out of range of address table %s", index, lookup.AccountKey)
+			}
+			loaded.ReadOnly = append(loaded.ReadOnly, table[index])
+		}
+	}
+	accountKeys := make([]common.Address, 0, len(m.AccountKeys)+len(loaded.Writable)+len(loaded.ReadOnly))
+	accountKeys = append(accountKeys, m.AccountKeys...)
+	accountKeys = append(accountKeys, loaded.Writable...)
+	m.AccountKeys = append(accountKeys, loaded.ReadOnly...)
+	m.loadedAddresses = loaded
+	return nil
+}
+
+// numStaticAccountKeys the number of account keys in the message itself, without the loaded addresses
+func (m *Message) numStaticAccountKeys() int {
+	return len(m.AccountKeys) - len(m.loadedAddresses.Writable) - len(m.loadedAddresses.ReadOnly)
+}
+
+// NumSigners returns the number of signatures the message requires
+func (m *Message) NumSigners() int {
+	return int(m.Header.NumRequiredSignatures)
 }
 
 func (m *Message) signerKeys() []common.Address {
@@ -211,6 +366,15 @@ func (m *Message) UnmarshalBase64(b64 string) error {
 	return m.UnmarshalWithDecoder(encodbin.NewBinDecoder(b))
 }
 
+// ToBase64 returns the base64 encoded message without signatures, as getFeeForMessage requires
+func (m *Message) ToBase64() (string, error) {
+	b, err := m.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
 func (m *Message) UnmarshalV0(decoder *encodbin.Decoder) (err error) {
 	version, err := decoder.ReadByte()
 	if err != nil {
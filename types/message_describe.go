@@ -0,0 +1,75 @@
+package types
+
+import (
+	"reflect"
+
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/types/base"
+)
+
+// MessageDescription a structured summary of a message, e.g. to display it before signing
+type MessageDescription struct {
+	FeePayer        common.Address
+	RecentBlockhash common.Hash
+	Instructions    []InstructionDescription
+}
+
+// InstructionDescription the summary of an instruction of a message
+type InstructionDescription struct {
+	ProgramID    common.Address
+	ProgramLabel string
+	// Type the name of the decoded instruction, e.g. "Transfer", empty when it can't be decoded
+	Type string
+	// Decoded the instruction decoded by the registered decoder of the program, nil when it can't be decoded
+	Decoded  interface{}
+	Data     []byte
+	Accounts []AccountDescription
+}
+
+// AccountDescription an account of an instruction with its role in the message
+type AccountDescription struct {
+	Address  common.Address
+	Label    string
+	Signer   bool
+	Writable bool
+}
+
+// Describe returns the structured summary of the message, the instructions are decoded with the
+// decoders registered by RegisterInstructionDecoder. The accounts are labeled from accountLabels,
+// which may be nil. Lookup table accounts are only described once the lookups are resolved
+func (m *Message) Describe(accountLabels map[common.Address]string) MessageDescription {
+	desc := MessageDescription{
+		RecentBlockhash: m.RecentBlockhash,
+		Instructions:    make([]InstructionDescription, 0, len(m.Instructions)),
+	}
+	if len(m.AccountKeys) > 0 {
+		desc.FeePayer = m.AccountKeys[0]
+	}
+	for _, instruction := range m.Instructions {
+		insDesc := InstructionDescription{Data: instruction.Data}
+		if int(instruction.ProgramIDIndex) < len(m.AccountKeys) {
+			insDesc.ProgramID = m.AccountKeys[instruction.ProgramIDIndex]
+			insDesc.ProgramLabel = accountLabels[insDesc.ProgramID]
+		}
+		metas := make([]*base.AccountMeta, 0, len(instruction.Accounts))
+		for _, index := range instruction.Accounts {
+			if int(index) >= len(m.AccountKeys) {
+				continue
+			}
+			account := AccountDescription{
+				Address:  m.AccountKeys[index],
+				Label:    accountLabels[m.AccountKeys[index]],
+				Signer:   int(index) < int(m.Header.NumRequiredSignatures),
+				Writable: m.isWritableIndex(int(index)),
+			}
+			insDesc.Accounts = append(insDesc.Accounts, account)
+			metas = append(metas, &base.AccountMeta{PublicKey: account.Address, IsSigner: account.Signer, IsWritable: account.Writable})
+		}
+		if decoded, err := DecodeInstruction(insDesc.ProgramID, instruction.Data, metas); err == nil && decoded != nil {
+			insDesc.Decoded = decoded
+			insDesc.Type = reflect.Indirect(reflect.ValueOf(decoded)).Type().Name()
+		}
+		desc.Instructions = append(desc.Instructions, insDesc)
+	}
+	return desc
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package native
+
+import (
+	"encoding/binary"
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/types/base"
+)
+
+// AdvanceNonceAccount Consumes a stored nonce, replacing it with a successor
+type AdvanceNonceAccount struct {
+	// [0] = [WRITE] NonceAccount
+	// ··········· Nonce account
+	//
+	// [1] = [] SysVarRecentBlockHashes
+	// ··········· RecentBlockhashes sysvar
+	//
+	// [2] = [SIGNER] NonceAuthority
+	// ··········· Nonce authority
+	base.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewAdvanceNonceAccountInstructionBuilder creates a new `AdvanceNonceAccount` instruction builder.
+func NewAdvanceNonceAccountInstructionBuilder() *AdvanceNonceAccount {
+	nd := &AdvanceNonceAccount{
+		AccountMetaSlice: make([]*base.AccountMeta, 3),
+	}
+	nd.AccountMetaSlice[1] = base.Meta(base.SysVarRecentBlockHashesPubkey)
+	return nd
+}
+
+// Nonce account
+func (adv *AdvanceNonceAccount) SetNonceAccount(nonceAccount common.Address) *AdvanceNonceAccount {
+	adv.AccountMetaSlice[0] = base.Meta(nonceAccount).WRITE()
+	return adv
+}
+
+func (adv *AdvanceNonceAccount) GetNonceAccount() *base.AccountMeta {
+	return adv.AccountMetaSlice[0]
+}
+
+// Nonce authority
+func (adv *AdvanceNonceAccount) SetNonceAuthorityAccount(nonceAuthority common.Address) *AdvanceNonceAccount {
+	adv.AccountMetaSlice[2] = base.Meta(nonceAuthority).SIGNER()
+	return adv
+}
+
+func (adv *AdvanceNonceAccount) GetNonceAuthorityAccount() *base.AccountMeta {
+	return adv.AccountMetaSlice[2]
+}
+
+func (adv AdvanceNonceAccount) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{BaseVariant: encodbin.BaseVariant{
+		Impl:   adv,
+		TypeID: encodbin.TypeIDFromUint32(Instruction_AdvanceNonceAccount, binary.LittleEndian),
+	}}, opts)
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (adv AdvanceNonceAccount) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
+	if err := adv.Validate(); err != nil {
+		return nil, err
+	}
+	return adv.Build(opts...), nil
+}
+
+func (adv *AdvanceNonceAccount) Validate() error {
+	// Check whether all accounts are set:
+	{
+		if adv.AccountMetaSlice[0] == nil {
+			return &base.MissingAccountError{Account: "NonceAccount"}
+		}
+		if adv.AccountMetaSlice[1] == nil {
+			return &base.MissingAccountError{Account: "SysVarRecentBlockHashes"}
+		}
+		if adv.AccountMetaSlice[2] == nil {
+			return &base.MissingAccountError{Account: "NonceAuthority"}
+		}
+	}
+	return nil
+}
+
+func (adv AdvanceNonceAccount) MarshalWithEncoder(encoder *encodbin.Encoder) error {
+	return nil
+}
+
+// NewAdvanceNonceAccountInstruction declares a new AdvanceNonceAccount instruction with the provided accounts.
+func NewAdvanceNonceAccountInstruction(
+	// Accounts:
+	nonceAccount common.Address,
+	nonceAuthority common.Address) *AdvanceNonceAccount {
+	return NewAdvanceNonceAccountInstructionBuilder().
+		SetNonceAccount(nonceAccount).
+		SetNonceAuthorityAccount(nonceAuthority)
+}
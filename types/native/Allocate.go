@@ -0,0 +1,96 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package native
+
+import (
+	"encoding/binary"
+	"errors"
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/types/base"
+)
+
+// Allocate Allocate space in a (possibly new) account without funding
+type Allocate struct {
+	// Number of bytes of memory to allocate
+	Space *uint64
+
+	// [0] = [WRITE, SIGNER] NewAccount
+	// ··········· New account
+	base.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewAllocateInstructionBuilder creates a new `Allocate` instruction builder.
+func NewAllocateInstructionBuilder() *Allocate {
+	nd := &Allocate{
+		AccountMetaSlice: make([]*base.AccountMeta, 1),
+	}
+	return nd
+}
+
+// Number of bytes of memory to allocate
+func (alc *Allocate) SetSpace(space uint64) *Allocate {
+	alc.Space = &space
+	return alc
+}
+
+// New account
+func (alc *Allocate) SetNewAccount(newAccount common.Address) *Allocate {
+	alc.AccountMetaSlice[0] = base.Meta(newAccount).WRITE().SIGNER()
+	return alc
+}
+
+func (alc *Allocate) GetNewAccount() *base.AccountMeta {
+	return alc.AccountMetaSlice[0]
+}
+
+func (alc Allocate) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{BaseVariant: encodbin.BaseVariant{
+		Impl:   alc,
+		TypeID: encodbin.TypeIDFromUint32(Instruction_Allocate, binary.LittleEndian),
+	}}, opts)
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (alc Allocate) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
+	if err := alc.Validate(); err != nil {
+		return nil, err
+	}
+	return alc.Build(opts...), nil
+}
+
+func (alc *Allocate) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if alc.Space == nil {
+			return errors.New("Space parameter is not set")
+		}
+	}
+
+	// Check whether all accounts are set:
+	{
+		if alc.AccountMetaSlice[0] == nil {
+			return &base.MissingAccountError{Account: "NewAccount"}
+		}
+	}
+	return nil
+}
+
+func (alc Allocate) MarshalWithEncoder(encoder *encodbin.Encoder) error {
+	// Serialize `Space` param:
+	return encoder.Encode(*alc.Space)
+}
+
+// NewAllocateInstruction declares a new Allocate instruction with the provided parameters and accounts.
+func NewAllocateInstruction(
+	// Parameters:
+	space uint64,
+	// Accounts:
+	newAccount common.Address) *Allocate {
+	return NewAllocateInstructionBuilder().
+		SetSpace(space).
+		SetNewAccount(newAccount)
+}
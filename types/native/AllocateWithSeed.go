@@ -0,0 +1,188 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package native
+
+import (
+	"encoding/binary"
+	"errors"
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/types/base"
+)
+
+// AllocateWithSeed Allocate space for and assign an account at an address derived from a base public key and a seed
+type AllocateWithSeed struct {
+	// Base public key
+	Base *common.Address
+
+	// String of ASCII chars, no longer than Pubkey::MAX_SEED_LEN
+	Seed *string
+
+	// Number of bytes of memory to allocate
+	Space *uint64
+
+	// Owner program account address
+	Owner *common.Address
+
+	// [0] = [WRITE] AllocatedAccount
+	// ··········· Allocated account
+	//
+	// [1] = [SIGNER] BaseAccount
+	// ··········· Base account
+	base.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewAllocateWithSeedInstructionBuilder creates a new `AllocateWithSeed` instruction builder.
+func NewAllocateWithSeedInstructionBuilder() *AllocateWithSeed {
+	nd := &AllocateWithSeed{
+		AccountMetaSlice: make([]*base.AccountMeta, 2),
+	}
+	return nd
+}
+
+// Base public key
+func (alc *AllocateWithSeed) SetBase(base common.Address) *AllocateWithSeed {
+	alc.Base = &base
+	return alc
+}
+
+// String of ASCII chars, no longer than Pubkey::MAX_SEED_LEN
+func (alc *AllocateWithSeed) SetSeed(seed string) *AllocateWithSeed {
+	alc.Seed = &seed
+	return alc
+}
+
+// Number of bytes of memory to allocate
+func (alc *AllocateWithSeed) SetSpace(space uint64) *AllocateWithSeed {
+	alc.Space = &space
+	return alc
+}
+
+// Owner program account address
+func (alc *AllocateWithSeed) SetOwner(owner common.Address) *AllocateWithSeed {
+	alc.Owner = &owner
+	return alc
+}
+
+// Allocated account
+func (alc *AllocateWithSeed) SetAllocatedAccount(allocatedAccount common.Address) *AllocateWithSeed {
+	alc.AccountMetaSlice[0] = base.Meta(allocatedAccount).WRITE()
+	return alc
+}
+
+func (alc *AllocateWithSeed) GetAllocatedAccount() *base.AccountMeta {
+	return alc.AccountMetaSlice[0]
+}
+
+// Base account
+func (alc *AllocateWithSeed) SetBaseAccount(baseAccount common.Address) *AllocateWithSeed {
+	alc.AccountMetaSlice[1] = base.Meta(baseAccount).SIGNER()
+	return alc
+}
+
+func (alc *AllocateWithSeed) GetBaseAccount() *base.AccountMeta {
+	return alc.AccountMetaSlice[1]
+}
+
+func (alc AllocateWithSeed) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{BaseVariant: encodbin.BaseVariant{
+		Impl:   alc,
+		TypeID: encodbin.TypeIDFromUint32(Instruction_AllocateWithSeed, binary.LittleEndian),
+	}}, opts)
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (alc AllocateWithSeed) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
+	if err := alc.Validate(); err != nil {
+		return nil, err
+	}
+	return alc.Build(opts...), nil
+}
+
+func (alc *AllocateWithSeed) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if alc.Base == nil {
+			return errors.New("Base parameter is not set")
+		}
+		if alc.Seed == nil {
+			return errors.New("Seed parameter is not set")
+		}
+		if alc.Space == nil {
+			return errors.New("Space parameter is not set")
+		}
+		if alc.Owner == nil {
+			return errors.New("Owner parameter is not set")
+		}
+	}
+
+	// Check whether all accounts are set:
+	{
+		if alc.AccountMetaSlice[0] == nil {
+			return &base.MissingAccountError{Account: "AllocatedAccount"}
+		}
+		if alc.AccountMetaSlice[1] == nil {
+			return &base.MissingAccountError{Account: "BaseAccount"}
+		}
+	}
+	return nil
+}
+
+func (alc AllocateWithSeed) MarshalWithEncoder(encoder *encodbin.Encoder) error {
+	// Serialize `Base` param:
+	if err := encoder.Encode(*alc.Base); err != nil {
+		return err
+	}
+	// Serialize `Seed` param:
+	if err := encoder.WriteRustString(*alc.Seed); err != nil {
+		return err
+	}
+	// Serialize `Space` param:
+	if err := encoder.Encode(*alc.Space); err != nil {
+		return err
+	}
+	// Serialize `Owner` param:
+	return encoder.Encode(*alc.Owner)
+}
+
+func (alc *AllocateWithSeed) UnmarshalWithDecoder(decoder *encodbin.Decoder) (err error) {
+	// Deserialize `Base`:
+	if err = decoder.Decode(&alc.Base); err != nil {
+		return err
+	}
+	// Deserialize `Seed`:
+	seed, err := decoder.ReadRustString()
+	if err != nil {
+		return err
+	}
+	alc.Seed = &seed
+	// Deserialize `Space`:
+	if err = decoder.Decode(&alc.Space); err != nil {
+		return err
+	}
+	// Deserialize `Owner`:
+	return decoder.Decode(&alc.Owner)
+}
+
+// NewAllocateWithSeedInstruction declares a new AllocateWithSeed instruction with the provided parameters and accounts.
+// The allocated account address is base.CreateWithSeed(base, seed, owner).
+func NewAllocateWithSeedInstruction(
+	// Parameters:
+	base common.Address,
+	seed string,
+	space uint64,
+	owner common.Address,
+	// Accounts:
+	allocatedAccount common.Address,
+	baseAccount common.Address) *AllocateWithSeed {
+	return NewAllocateWithSeedInstructionBuilder().
+		SetBase(base).
+		SetSeed(seed).
+		SetSpace(space).
+		SetOwner(owner).
+		SetAllocatedAccount(allocatedAccount).
+		SetBaseAccount(baseAccount)
+}
@@ -0,0 +1,96 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package native
+
+import (
+	"encoding/binary"
+	"errors"
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/types/base"
+)
+
+// Assign Assign account to a program
+type Assign struct {
+	// Owner program account
+	Owner *common.Address
+
+	// [0] = [WRITE, SIGNER] AssignedAccount
+	// ··········· Assigned account public key
+	base.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewAssignInstructionBuilder creates a new `Assign` instruction builder.
+func NewAssignInstructionBuilder() *Assign {
+	nd := &Assign{
+		AccountMetaSlice: make([]*base.AccountMeta, 1),
+	}
+	return nd
+}
+
+// Owner program account
+func (asg *Assign) SetOwner(owner common.Address) *Assign {
+	asg.Owner = &owner
+	return asg
+}
+
+// Assigned account public key
+func (asg *Assign) SetAssignedAccount(assignedAccount common.Address) *Assign {
+	asg.AccountMetaSlice[0] = base.Meta(assignedAccount).WRITE().SIGNER()
+	return asg
+}
+
+func (asg *Assign) GetAssignedAccount() *base.AccountMeta {
+	return asg.AccountMetaSlice[0]
+}
+
+func (asg Assign) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{BaseVariant: encodbin.BaseVariant{
+		Impl:   asg,
+		TypeID: encodbin.TypeIDFromUint32(Instruction_Assign, binary.LittleEndian),
+	}}, opts)
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (asg Assign) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
+	if err := asg.Validate(); err != nil {
+		return nil, err
+	}
+	return asg.Build(opts...), nil
+}
+
+func (asg *Assign) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if asg.Owner == nil {
+			return errors.New("Owner parameter is not set")
+		}
+	}
+
+	// Check whether all accounts are set:
+	{
+		if asg.AccountMetaSlice[0] == nil {
+			return &base.MissingAccountError{Account: "AssignedAccount"}
+		}
+	}
+	return nil
+}
+
+func (asg Assign) MarshalWithEncoder(encoder *encodbin.Encoder) error {
+	// Serialize `Owner` param:
+	return encoder.Encode(*asg.Owner)
+}
+
+// NewAssignInstruction declares a new Assign instruction with the provided parameters and accounts.
+func NewAssignInstruction(
+	// Parameters:
+	owner common.Address,
+	// Accounts:
+	assignedAccount common.Address) *Assign {
+	return NewAssignInstructionBuilder().
+		SetOwner(owner).
+		SetAssignedAccount(assignedAccount)
+}
@@ -0,0 +1,166 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package native
+
+import (
+	"encoding/binary"
+	"errors"
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/types/base"
+)
+
+// AssignWithSeed Assign account to a program based on a seed
+type AssignWithSeed struct {
+	// Base public key
+	Base *common.Address
+
+	// String of ASCII chars, no longer than Pubkey::MAX_SEED_LEN
+	Seed *string
+
+	// Owner program account
+	Owner *common.Address
+
+	// [0] = [WRITE] AssignedAccount
+	// ··········· Assigned account
+	//
+	// [1] = [SIGNER] BaseAccount
+	// ··········· Base account
+	base.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewAssignWithSeedInstructionBuilder creates a new `AssignWithSeed` instruction builder.
+func NewAssignWithSeedInstructionBuilder() *AssignWithSeed {
+	nd := &AssignWithSeed{
+		AccountMetaSlice: make([]*base.AccountMeta, 2),
+	}
+	return nd
+}
+
+// Base public key
+func (asg *AssignWithSeed) SetBase(base common.Address) *AssignWithSeed {
+	asg.Base = &base
+	return asg
+}
+
+// String of ASCII chars, no longer than Pubkey::MAX_SEED_LEN
+func (asg *AssignWithSeed) SetSeed(seed string) *AssignWithSeed {
+	asg.Seed = &seed
+	return asg
+}
+
+// Owner program account
+func (asg *AssignWithSeed) SetOwner(owner common.Address) *AssignWithSeed {
+	asg.Owner = &owner
+	return asg
+}
+
+// Assigned account
+func (asg *AssignWithSeed) SetAssignedAccount(assignedAccount common.Address) *AssignWithSeed {
+	asg.AccountMetaSlice[0] = base.Meta(assignedAccount).WRITE()
+	return asg
+}
+
+func (asg *AssignWithSeed) GetAssignedAccount() *base.AccountMeta {
+	return asg.AccountMetaSlice[0]
+}
+
+// Base account
+func (asg *AssignWithSeed) SetBaseAccount(baseAccount common.Address) *AssignWithSeed {
+	asg.AccountMetaSlice[1] = base.Meta(baseAccount).SIGNER()
+	return asg
+}
+
+func (asg *AssignWithSeed) GetBaseAccount() *base.AccountMeta {
+	return asg.AccountMetaSlice[1]
+}
+
+func (asg AssignWithSeed) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{BaseVariant: encodbin.BaseVariant{
+		Impl:   asg,
+		TypeID: encodbin.TypeIDFromUint32(Instruction_AssignWithSeed, binary.LittleEndian),
+	}}, opts)
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (asg AssignWithSeed) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
+	if err := asg.Validate(); err != nil {
+		return nil, err
+	}
+	return asg.Build(opts...), nil
+}
+
+func (asg *AssignWithSeed) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if asg.Base == nil {
+			return errors.New("Base parameter is not set")
+		}
+		if asg.Seed == nil {
+			return errors.New("Seed parameter is not set")
+		}
+		if asg.Owner == nil {
+			return errors.New("Owner parameter is not set")
+		}
+	}
+
+	// Check whether all accounts are set:
+	{
+		if asg.AccountMetaSlice[0] == nil {
+			return &base.MissingAccountError{Account: "AssignedAccount"}
+		}
+		if asg.AccountMetaSlice[1] == nil {
+			return &base.MissingAccountError{Account: "BaseAccount"}
+		}
+	}
+	return nil
+}
+
+func (asg AssignWithSeed) MarshalWithEncoder(encoder *encodbin.Encoder) error {
+	// Serialize `Base` param:
+	if err := encoder.Encode(*asg.Base); err != nil {
+		return err
+	}
+	// Serialize `Seed` param:
+	if err := encoder.WriteRustString(*asg.Seed); err != nil {
+		return err
+	}
+	// Serialize `Owner` param:
+	return encoder.Encode(*asg.Owner)
+}
+
+func (asg *AssignWithSeed) UnmarshalWithDecoder(decoder *encodbin.Decoder) (err error) {
+	// Deserialize `Base`:
+	if err = decoder.Decode(&asg.Base); err != nil {
+		return err
+	}
+	// Deserialize `Seed`:
+	seed, err := decoder.ReadRustString()
+	if err != nil {
+		return err
+	}
+	asg.Seed = &seed
+	// Deserialize `Owner`:
+	return decoder.Decode(&asg.Owner)
+}
+
+// NewAssignWithSeedInstruction declares a new AssignWithSeed instruction with the provided parameters and accounts.
+// The assigned account address is base.CreateWithSeed(base, seed, owner).
+func NewAssignWithSeedInstruction(
+	// Parameters:
+	base common.Address,
+	seed string,
+	owner common.Address,
+	// Accounts:
+	assignedAccount common.Address,
+	baseAccount common.Address) *AssignWithSeed {
+	return NewAssignWithSeedInstructionBuilder().
+		SetBase(base).
+		SetSeed(seed).
+		SetOwner(owner).
+		SetAssignedAccount(assignedAccount).
+		SetBaseAccount(baseAccount)
+}
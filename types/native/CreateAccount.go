@@ -16,6 +16,7 @@ package native
 
 import (
 	"encoding/binary"
+	"errors"
 	"github.com/cielu/go-solana/common"
 	"github.com/cielu/go-solana/pkg/encodbin"
 	"github.com/cielu/go-solana/types/base"
@@ -86,11 +87,47 @@ func (cAcc *CreateAccount) GetNewAccount() *base.AccountMeta {
 	return cAcc.AccountMetaSlice[1]
 }
 
-func (cAcc CreateAccount) Build() *Instruction {
-	return &Instruction{BaseVariant: encodbin.BaseVariant{
+func (cAcc CreateAccount) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{BaseVariant: encodbin.BaseVariant{
 		Impl:   cAcc,
 		TypeID: encodbin.TypeIDFromUint32(Instruction_CreateAccount, binary.LittleEndian),
-	}}
+	}}, opts)
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (cAcc CreateAccount) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
+	if err := cAcc.Validate(); err != nil {
+		return nil, err
+	}
+	return cAcc.Build(opts...), nil
+}
+
+func (cAcc *CreateAccount) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if cAcc.Lamports == nil {
+			return errors.New("Lamports parameter is not set")
+		}
+		if cAcc.Space == nil {
+			return errors.New("Space parameter is not set")
+		}
+		if cAcc.Owner == nil {
+			return errors.New("Owner parameter is not set")
+		}
+	}
+
+	// Check whether all accounts are set:
+	{
+		if cAcc.AccountMetaSlice[0] == nil {
+			return &base.MissingAccountError{Account: "FundingAccount"}
+		}
+		if cAcc.AccountMetaSlice[1] == nil {
+			return &base.MissingAccountError{Account: "NewAccount"}
+		}
+	}
+	return nil
 }
 
 func (cAcc CreateAccount) MarshalWithEncoder(encoder *encodbin.Encoder) error {
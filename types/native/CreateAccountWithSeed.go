@@ -17,7 +17,6 @@ package native
 import (
 	"encoding/binary"
 	"errors"
-	"fmt"
 	"github.com/cielu/go-solana/common"
 	"github.com/cielu/go-solana/pkg/encodbin"
 	"github.com/cielu/go-solana/types/base"
@@ -119,26 +118,26 @@ func (cAcc *CreateAccountWithSeed) GetBaseAccount() *base.AccountMeta {
 	return cAcc.AccountMetaSlice[2]
 }
 
-func (cAcc CreateAccountWithSeed) Build() *Instruction {
+func (cAcc CreateAccountWithSeed) Build(opts ...base.BuildOption) *Instruction {
 	{
 		if *cAcc.Base != cAcc.GetFundingAccount().PublicKey {
 			cAcc.AccountMetaSlice[2] = base.Meta(*cAcc.Base).SIGNER()
 		}
 	}
-	return &Instruction{BaseVariant: encodbin.BaseVariant{
+	return withBuildOptions(&Instruction{BaseVariant: encodbin.BaseVariant{
 		Impl:   cAcc,
 		TypeID: encodbin.TypeIDFromUint32(Instruction_CreateAccountWithSeed, binary.LittleEndian),
-	}}
+	}}, opts)
 }
 
 // ValidateAndBuild validates the instruction parameters and accounts;
 // if there is a validation error, it returns the error.
 // Otherwise, it builds and returns the instruction.
-func (cAcc CreateAccountWithSeed) ValidateAndBuild() (*Instruction, error) {
+func (cAcc CreateAccountWithSeed) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
 	if err := cAcc.Validate(); err != nil {
 		return nil, err
 	}
-	return cAcc.Build(), nil
+	return cAcc.Build(opts...), nil
 }
 
 func (cAcc *CreateAccountWithSeed) Validate() error {
@@ -164,10 +163,10 @@ func (cAcc *CreateAccountWithSeed) Validate() error {
 	// Check whether all accounts are set:
 	{
 		if cAcc.AccountMetaSlice[0] == nil {
-			return fmt.Errorf("FundingAccount is not set")
+			return &base.MissingAccountError{Account: "FundingAccount"}
 		}
 		if cAcc.AccountMetaSlice[1] == nil {
-			return fmt.Errorf("CreatedAccount is not set")
+			return &base.MissingAccountError{Account: "CreatedAccount"}
 		}
 	}
 	return nil
@@ -212,7 +211,31 @@ func (cAcc CreateAccountWithSeed) MarshalWithEncoder(encoder *encodbin.Encoder)
 	return nil
 }
 
+func (cAcc *CreateAccountWithSeed) UnmarshalWithDecoder(decoder *encodbin.Decoder) (err error) {
+	// Deserialize `Base`:
+	if err = decoder.Decode(&cAcc.Base); err != nil {
+		return err
+	}
+	// Deserialize `Seed`:
+	seed, err := decoder.ReadRustString()
+	if err != nil {
+		return err
+	}
+	cAcc.Seed = &seed
+	// Deserialize `Lamports`:
+	if err = decoder.Decode(&cAcc.Lamports); err != nil {
+		return err
+	}
+	// Deserialize `Space`:
+	if err = decoder.Decode(&cAcc.Space); err != nil {
+		return err
+	}
+	// Deserialize `Owner`:
+	return decoder.Decode(&cAcc.Owner)
+}
+
 // NewCreateAccountWithSeedInstruction declares a new CreateAccountWithSeed instruction with the provided parameters and accounts.
+// The created account address is base.CreateWithSeed(base, seed, owner).
 func NewCreateAccountWithSeedInstruction(
 	// Parameters:
 	base common.Address,
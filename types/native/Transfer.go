@@ -2,6 +2,7 @@ package native
 
 import (
 	"encoding/binary"
+	"errors"
 	"github.com/cielu/go-solana/common"
 	"github.com/cielu/go-solana/pkg/encodbin"
 	"github.com/cielu/go-solana/types/base"
@@ -50,11 +51,41 @@ func (trans *Transfer) SetRecipientAccount(recipientAccount common.Address) *Tra
 	return trans
 }
 
-func (trans Transfer) Build() *Instruction {
-	return &Instruction{BaseVariant: encodbin.BaseVariant{
+func (trans Transfer) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{BaseVariant: encodbin.BaseVariant{
 		Impl:   trans,
 		TypeID: encodbin.TypeIDFromUint32(Instruction_Transfer, binary.LittleEndian),
-	}}
+	}}, opts)
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (trans Transfer) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
+	if err := trans.Validate(); err != nil {
+		return nil, err
+	}
+	return trans.Build(opts...), nil
+}
+
+func (trans *Transfer) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if trans.Lamports == nil {
+			return errors.New("Lamports parameter is not set")
+		}
+	}
+
+	// Check whether all accounts are set:
+	{
+		if trans.AccountMetaSlice[0] == nil {
+			return &base.MissingAccountError{Account: "FundingAccount"}
+		}
+		if trans.AccountMetaSlice[1] == nil {
+			return &base.MissingAccountError{Account: "RecipientAccount"}
+		}
+	}
+	return nil
 }
 
 func (trans Transfer) MarshalWithEncoder(encoder encodbin.Encoder) error {
@@ -0,0 +1,184 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package native
+
+import (
+	"encoding/binary"
+	"errors"
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/types/base"
+)
+
+// TransferWithSeed Transfer lamports from a derived address
+type TransferWithSeed struct {
+	// Amount to transfer
+	Lamports *uint64
+
+	// Seed to use to derive the funding account address
+	FromSeed *string
+
+	// Owner to use to derive the funding account address
+	FromOwner *common.Address
+
+	// [0] = [WRITE] FundingAccount
+	// ··········· Funding account
+	//
+	// [1] = [SIGNER] BaseForFundingAccount
+	// ··········· Base for funding account
+	//
+	// [2] = [WRITE] RecipientAccount
+	// ··········· Recipient account
+	base.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewTransferWithSeedInstructionBuilder creates a new `TransferWithSeed` instruction builder.
+func NewTransferWithSeedInstructionBuilder() *TransferWithSeed {
+	nd := &TransferWithSeed{
+		AccountMetaSlice: make([]*base.AccountMeta, 3),
+	}
+	return nd
+}
+
+// Amount to transfer
+func (trans *TransferWithSeed) SetLamports(lamports uint64) *TransferWithSeed {
+	trans.Lamports = &lamports
+	return trans
+}
+
+// Seed to use to derive the funding account address
+func (trans *TransferWithSeed) SetFromSeed(fromSeed string) *TransferWithSeed {
+	trans.FromSeed = &fromSeed
+	return trans
+}
+
+// Owner to use to derive the funding account address
+func (trans *TransferWithSeed) SetFromOwner(fromOwner common.Address) *TransferWithSeed {
+	trans.FromOwner = &fromOwner
+	return trans
+}
+
+// Funding account
+func (trans *TransferWithSeed) SetFundingAccount(fundingAccount common.Address) *TransferWithSeed {
+	trans.AccountMetaSlice[0] = base.Meta(fundingAccount).WRITE()
+	return trans
+}
+
+func (trans *TransferWithSeed) GetFundingAccount() *base.AccountMeta {
+	return trans.AccountMetaSlice[0]
+}
+
+// Base for funding account
+func (trans *TransferWithSeed) SetBaseForFundingAccount(baseForFundingAccount common.Address) *TransferWithSeed {
+	trans.AccountMetaSlice[1] = base.Meta(baseForFundingAccount).SIGNER()
+	return trans
+}
+
+func (trans *TransferWithSeed) GetBaseForFundingAccount() *base.AccountMeta {
+	return trans.AccountMetaSlice[1]
+}
+
+// Recipient account
+func (trans *TransferWithSeed) SetRecipientAccount(recipientAccount common.Address) *TransferWithSeed {
+	trans.AccountMetaSlice[2] = base.Meta(recipientAccount).WRITE()
+	return trans
+}
+
+func (trans *TransferWithSeed) GetRecipientAccount() *base.AccountMeta {
+	return trans.AccountMetaSlice[2]
+}
+
+func (trans TransferWithSeed) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{BaseVariant: encodbin.BaseVariant{
+		Impl:   trans,
+		TypeID: encodbin.TypeIDFromUint32(Instruction_TransferWithSeed, binary.LittleEndian),
+	}}, opts)
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (trans TransferWithSeed) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
+	if err := trans.Validate(); err != nil {
+		return nil, err
+	}
+	return trans.Build(opts...), nil
+}
+
+func (trans *TransferWithSeed) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if trans.Lamports == nil {
+			return errors.New("Lamports parameter is not set")
+		}
+		if trans.FromSeed == nil {
+			return errors.New("FromSeed parameter is not set")
+		}
+		if trans.FromOwner == nil {
+			return errors.New("FromOwner parameter is not set")
+		}
+	}
+
+	// Check whether all accounts are set:
+	{
+		if trans.AccountMetaSlice[0] == nil {
+			return &base.MissingAccountError{Account: "FundingAccount"}
+		}
+		if trans.AccountMetaSlice[1] == nil {
+			return &base.MissingAccountError{Account: "BaseForFundingAccount"}
+		}
+		if trans.AccountMetaSlice[2] == nil {
+			return &base.MissingAccountError{Account: "RecipientAccount"}
+		}
+	}
+	return nil
+}
+
+func (trans TransferWithSeed) MarshalWithEncoder(encoder *encodbin.Encoder) error {
+	// Serialize `Lamports` param:
+	if err := encoder.Encode(*trans.Lamports); err != nil {
+		return err
+	}
+	// Serialize `FromSeed` param:
+	if err := encoder.WriteRustString(*trans.FromSeed); err != nil {
+		return err
+	}
+	// Serialize `FromOwner` param:
+	return encoder.Encode(*trans.FromOwner)
+}
+
+func (trans *TransferWithSeed) UnmarshalWithDecoder(decoder *encodbin.Decoder) (err error) {
+	// Deserialize `Lamports`:
+	if err = decoder.Decode(&trans.Lamports); err != nil {
+		return err
+	}
+	// Deserialize `FromSeed`:
+	fromSeed, err := decoder.ReadRustString()
+	if err != nil {
+		return err
+	}
+	trans.FromSeed = &fromSeed
+	// Deserialize `FromOwner`:
+	return decoder.Decode(&trans.FromOwner)
+}
+
+// NewTransferWithSeedInstruction declares a new TransferWithSeed instruction with the provided parameters and accounts.
+// The funding account address is base.CreateWithSeed(baseForFundingAccount, fromSeed, fromOwner).
+func NewTransferWithSeedInstruction(
+	// Parameters:
+	lamports uint64,
+	fromSeed string,
+	fromOwner common.Address,
+	// Accounts:
+	fundingAccount common.Address,
+	baseForFundingAccount common.Address,
+	recipientAccount common.Address) *TransferWithSeed {
+	return NewTransferWithSeedInstructionBuilder().
+		SetLamports(lamports).
+		SetFromSeed(fromSeed).
+		SetFromOwner(fromOwner).
+		SetFundingAccount(fundingAccount).
+		SetBaseForFundingAccount(baseForFundingAccount).
+		SetRecipientAccount(recipientAccount)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/cielu/go-solana/common"
 	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/types"
 	"github.com/cielu/go-solana/types/base"
 )
 
@@ -49,9 +50,20 @@ const (
 
 type Instruction struct {
 	encodbin.BaseVariant
+	// programID overrides base.SystemProgramID when set, see base.WithProgramID
+	programID common.Address
+}
+
+// withBuildOptions applies the options of Build to the instruction
+func withBuildOptions(inst *Instruction, opts []base.BuildOption) *Instruction {
+	inst.programID = base.NewBuildOptions(opts...).ProgramID
+	return inst
 }
 
 func (inst *Instruction) ProgramID() common.Address {
+	if !inst.programID.IsEmpty() {
+		return inst.programID
+	}
 	return base.SystemProgramID
 }
 
@@ -74,3 +86,47 @@ func (inst *Instruction) MarshalWithEncoder(encoder *encodbin.Encoder) error {
 	}
 	return encoder.Encode(inst.Impl)
 }
+
+func init() {
+	types.RegisterInstructionDecoder(base.SystemProgramID, DecodeInstruction)
+}
+
+// DecodeInstruction decodes the accounts and data of a system program instruction
+// into its typed instruction, e.g. a *Transfer
+func DecodeInstruction(accounts []*base.AccountMeta, data []byte) (interface{}, error) {
+	decoder := encodbin.NewBinDecoder(data)
+	typeID, err := decoder.ReadUint32(binary.LittleEndian)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read instruction type: %w", err)
+	}
+	var inst base.AccountsSettable
+	switch typeID {
+	case Instruction_CreateAccount:
+		inst = new(CreateAccount)
+	case Instruction_Transfer:
+		inst = new(Transfer)
+	case Instruction_Assign:
+		inst = new(Assign)
+	case Instruction_CreateAccountWithSeed:
+		inst = new(CreateAccountWithSeed)
+	case Instruction_AdvanceNonceAccount:
+		inst = new(AdvanceNonceAccount)
+	case Instruction_Allocate:
+		inst = new(Allocate)
+	case Instruction_AllocateWithSeed:
+		inst = new(AllocateWithSeed)
+	case Instruction_AssignWithSeed:
+		inst = new(AssignWithSeed)
+	case Instruction_TransferWithSeed:
+		inst = new(TransferWithSeed)
+	default:
+		return nil, fmt.Errorf("unsupported system instruction: %d", typeID)
+	}
+	if err = decoder.Decode(inst); err != nil {
+		return nil, fmt.Errorf("unable to decode system instruction %d: %w", typeID, err)
+	}
+	if err = inst.SetAccounts(accounts); err != nil {
+		return nil, err
+	}
+	return inst, nil
+}
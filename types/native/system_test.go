@@ -1,13 +1,20 @@
-package native
+package native_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"github.com/cielu/go-solana/common"
 	"github.com/cielu/go-solana/crypto"
 	"github.com/cielu/go-solana/solclient"
 	"github.com/cielu/go-solana/types"
+	"github.com/cielu/go-solana/types/base"
 	computebudget "github.com/cielu/go-solana/types/compute-budget"
+	"github.com/cielu/go-solana/types/native"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -33,7 +40,7 @@ func TestSolTransfer(t *testing.T) {
 	setPriceInst := computebudget.NewSetComputeUnitPriceInstruction(10000)
 	execInst = append(execInst, setPriceInst.Build())
 
-	transferInst := NewTransferInstruction(
+	transferInst := native.NewTransferInstruction(
 		common.StrToAddress("EfgnVEwyeeFLZyZ4nnnzZtqV6B3DhdtXFNsGSzdti9ZN"),
 		common.StrToAddress("6XViKPqw7t47tZz8UJR1bJFVzxjnQbuKtN2TBgnfZmo4"),
 		1e1,
@@ -60,3 +67,148 @@ func TestSolTransfer(t *testing.T) {
 	println(res.String())
 
 }
+
+func TestDecodeInstruction(t *testing.T) {
+	var (
+		from = common.StrToAddress("EfgnVEwyeeFLZyZ4nnnzZtqV6B3DhdtXFNsGSzdti9ZN")
+		to   = common.StrToAddress("6XViKPqw7t47tZz8UJR1bJFVzxjnQbuKtN2TBgnfZmo4")
+	)
+	inst := native.NewTransferInstruction(from, to, 1e9).Build()
+	data, err := inst.Data()
+	if err != nil {
+		t.Fatalf("Transfer Data Failed: %s", err)
+	}
+	decoded, err := types.DecodeInstruction(inst.ProgramID(), data, inst.Accounts())
+	if err != nil {
+		t.Fatalf("DecodeInstruction Failed: %s", err)
+	}
+	transfer, ok := decoded.(*native.Transfer)
+	if !ok {
+		t.Fatalf("DecodeInstruction Err ==> Got %T, Want: *native.Transfer", decoded)
+	}
+	if *transfer.Lamports != 1e9 || transfer.Get(0).PublicKey != from || transfer.Get(1).PublicKey != to || !transfer.Get(0).IsSigner {
+		t.Errorf("Transfer Err ==> Got %d %v", *transfer.Lamports, transfer.GetAccounts())
+	}
+
+	seedInst := native.NewCreateAccountWithSeedInstruction(from, "vault", 2039280, 165, to, from, to, from).Build()
+	data, _ = seedInst.Data()
+	decoded, err = types.DecodeInstruction(seedInst.ProgramID(), data, seedInst.Accounts())
+	if seed, ok := decoded.(*native.CreateAccountWithSeed); err != nil || !ok || *seed.Seed != "vault" || *seed.Space != 165 || *seed.Owner != to {
+		t.Errorf("CreateAccountWithSeed Err ==> Got %+v, %v", decoded, err)
+	}
+
+	priceInst := computebudget.NewSetComputeUnitPriceInstruction(10000).Build()
+	data, _ = priceInst.Data()
+	decoded, err = types.DecodeInstruction(priceInst.ProgramID(), data, nil)
+	if price, ok := decoded.(*computebudget.SetComputeUnitPrice); err != nil || !ok || price.MicroLamports != 10000 {
+		t.Errorf("SetComputeUnitPrice Err ==> Got %+v, %v", decoded, err)
+	}
+
+	if _, err = native.DecodeInstruction(nil, []byte{8, 0, 0, 0}); err == nil {
+		t.Errorf("DecodeInstruction Err ==> Got nil, Want: unsupported system instruction")
+	}
+}
+
+func TestSystemInstructionDiscriminant(t *testing.T) {
+	var (
+		from  = common.StrToAddress("EfgnVEwyeeFLZyZ4nnnzZtqV6B3DhdtXFNsGSzdti9ZN")
+		to    = common.StrToAddress("6XViKPqw7t47tZz8UJR1bJFVzxjnQbuKtN2TBgnfZmo4")
+		owner = common.StrToAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+	)
+	tests := []struct {
+		name string
+		inst *native.Instruction
+		want uint32
+		size int
+		impl interface{}
+	}{
+		{"CreateAccount", native.NewCreateAccountInstruction(1, 165, owner, from, to).Build(), 0, 52, &native.CreateAccount{}},
+		{"Assign", native.NewAssignInstruction(owner, to).Build(), 1, 36, &native.Assign{}},
+		{"Transfer", native.NewTransferInstruction(from, to, 1).Build(), 2, 12, &native.Transfer{}},
+		{"CreateAccountWithSeed", native.NewCreateAccountWithSeedInstruction(from, "seed", 1, 165, owner, from, to, from).Build(), 3, 96, &native.CreateAccountWithSeed{}},
+		{"AdvanceNonceAccount", native.NewAdvanceNonceAccountInstruction(to, from).Build(), 4, 4, &native.AdvanceNonceAccount{}},
+		{"Allocate", native.NewAllocateInstruction(165, to).Build(), 8, 12, &native.Allocate{}},
+		{"AllocateWithSeed", native.NewAllocateWithSeedInstruction(from, "seed", 165, owner, to, from).Build(), 9, 88, &native.AllocateWithSeed{}},
+		{"AssignWithSeed", native.NewAssignWithSeedInstruction(from, "seed", owner, to, from).Build(), 10, 80, &native.AssignWithSeed{}},
+		{"TransferWithSeed", native.NewTransferWithSeedInstruction(1, "seed", owner, to, from, from).Build(), 11, 56, &native.TransferWithSeed{}},
+	}
+	for _, tt := range tests {
+		data, err := tt.inst.Data()
+		if err != nil {
+			t.Fatalf("%s Data Failed: %s", tt.name, err)
+		}
+		var want [4]byte
+		binary.LittleEndian.PutUint32(want[:], tt.want)
+		if len(data) != tt.size || !bytes.Equal(data[:4], want[:]) {
+			t.Errorf("%s Err ==> Got %v (%d bytes), Want: %v (%d bytes)", tt.name, data[:4], len(data), want, tt.size)
+		}
+		if tt.inst.ProgramID() != base.SystemProgramID {
+			t.Errorf("%s ProgramID Err ==> Got %s", tt.name, tt.inst.ProgramID())
+		}
+		decoded, err := native.DecodeInstruction(tt.inst.Accounts(), data)
+		if err != nil || reflect.TypeOf(decoded) != reflect.TypeOf(tt.impl) {
+			t.Errorf("%s DecodeInstruction Err ==> Got %T, %v", tt.name, decoded, err)
+		}
+	}
+}
+
+func TestBuildWithProgramID(t *testing.T) {
+	proxy := common.StrToAddress("9xQeWvG816bUx9EPjHmaT23yvVM2ZWbrrpZb9PusVFin")
+	builder := native.NewTransferInstruction(common.Address{1}, common.Address{2}, 1)
+	if inst := builder.Build(base.WithProgramID(proxy)); inst.ProgramID() != proxy {
+		t.Errorf("ProgramID Err ==> Got %s, Want: %s", inst.ProgramID(), proxy)
+	}
+	if inst := builder.Build(); inst.ProgramID() != base.SystemProgramID {
+		t.Errorf("ProgramID Err ==> Got %s, Want: %s", inst.ProgramID(), base.SystemProgramID)
+	}
+}
+
+func TestSystemInstructionValidate(t *testing.T) {
+	var (
+		from  = common.StrToAddress("F8HCC3DyoR6KN9SSK9NL1V6weRgsEvp8hjL26EnTxNTF")
+		to    = common.StrToAddress("BZYExy8yxFZF6jTp4h7X98dPLBcbQDFhvHXPdTjDb2ag")
+		owner = base.TokenProgramID
+	)
+	tests := []struct {
+		name     string
+		validate func() error
+		missing  string
+	}{
+		{"Transfer", native.NewTransferInstructionBuilder().SetFundingAccount(from).SetRecipientAccount(to).Validate, "Lamports"},
+		{"Transfer", native.NewTransferInstruction(from, to, 1).Validate, ""},
+		{"CreateAccount", native.NewCreateAccountInstructionBuilder().SetLamports(1).SetSpace(0).SetOwner(owner).SetNewAccount(to).Validate, "FundingAccount"},
+		{"CreateAccount", native.NewCreateAccountInstruction(1, 0, owner, from, to).Validate, ""},
+		{"TransferWithSeed", native.NewTransferWithSeedInstructionBuilder().SetLamports(1).SetFromOwner(owner).Validate, "FromSeed"},
+		{"TransferWithSeed", native.NewTransferWithSeedInstruction(1, "seed", owner, from, from, to).Validate, ""},
+		{"AdvanceNonceAccount", native.NewAdvanceNonceAccountInstructionBuilder().SetNonceAccount(from).Validate, "NonceAuthority"},
+		{"Allocate", native.NewAllocateInstructionBuilder().SetNewAccount(from).Validate, "Space"},
+		{"AllocateWithSeed", native.NewAllocateWithSeedInstructionBuilder().SetBase(from).SetSeed("seed").SetSpace(1).SetOwner(owner).Validate, "AllocatedAccount"},
+		{"Assign", native.NewAssignInstructionBuilder().SetOwner(owner).Validate, "AssignedAccount"},
+		{"AssignWithSeed", native.NewAssignWithSeedInstructionBuilder().SetBase(from).SetOwner(owner).Validate, "Seed"},
+	}
+	for _, tt := range tests {
+		err := tt.validate()
+		if tt.missing == "" {
+			if err != nil {
+				t.Errorf("%s Validate Failed: %s", tt.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.missing) {
+			t.Errorf("%s Validate Err ==> Got %v, Want: %s is not set", tt.name, err, tt.missing)
+		}
+	}
+	if _, err := native.NewAllocateInstructionBuilder().SetNewAccount(from).ValidateAndBuild(); err == nil {
+		t.Errorf("Allocate ValidateAndBuild without space should fail")
+	}
+
+	// the missing account is reported by a typed error
+	var missing *base.MissingAccountError
+	_, err := native.NewTransferInstructionBuilder().SetLamports(1).SetFundingAccount(from).ValidateAndBuild()
+	if !errors.Is(err, base.ErrMissingAccount) || !errors.As(err, &missing) || missing.Account != "RecipientAccount" {
+		t.Errorf("Transfer ValidateAndBuild Err ==> Got %v, Want: accounts.RecipientAccount is not set", err)
+	}
+	if err = native.NewAllocateInstructionBuilder().SetNewAccount(from).Validate(); errors.Is(err, base.ErrMissingAccount) {
+		t.Errorf("Allocate Validate Err ==> Got %v, Want: Space parameter is not set", err)
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+// Package raydium provides off-chain helpers for the Raydium AMM v4 pools.
+package raydium
+
+import "math/big"
+
+// QuoteSwap Returns the amount out of a swap of amountIn against the pool reserves, following
+// the constant product math of the AMM program: the fee is amountIn * feeNumerator / feeDenominator
+// rounded up, then amountOut = reserveOut * amountInAfterFee / (reserveIn + amountInAfterFee)
+// rounded down. Swapping coin to pc, reserveIn is the coin reserve and reserveOut the pc reserve.
+// Returns 0 when the pool is empty or the fee is invalid
+func QuoteSwap(reserveIn, reserveOut, amountIn, feeNumerator, feeDenominator uint64) uint64 {
+	if reserveIn == 0 || reserveOut == 0 || feeDenominator == 0 || feeNumerator > feeDenominator {
+		return 0
+	}
+	// the products do not fit into 64 bits
+	amount := new(big.Int).SetUint64(amountIn)
+	fee := new(big.Int).Mul(amount, new(big.Int).SetUint64(feeNumerator))
+	denominator := new(big.Int).SetUint64(feeDenominator)
+	fee.Add(fee, denominator).Sub(fee, big.NewInt(1)).Quo(fee, denominator)
+	amount.Sub(amount, fee)
+
+	out := new(big.Int).Mul(new(big.Int).SetUint64(reserveOut), amount)
+	out.Quo(out, amount.Add(amount, new(big.Int).SetUint64(reserveIn)))
+	return out.Uint64()
+}
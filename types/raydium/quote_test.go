@@ -0,0 +1,30 @@
+package raydium
+
+import (
+	"math"
+	"testing"
+)
+
+func TestQuoteSwap(t *testing.T) {
+	tests := []struct {
+		name                   string
+		reserveIn, reserveOut  uint64
+		amountIn               uint64
+		feeNumerator, feeDenom uint64
+		want                   uint64
+	}{
+		{name: "balanced pool", reserveIn: 1_000_000_000, reserveOut: 2_000_000_000, amountIn: 1_000_000, feeNumerator: 25, feeDenom: 10000, want: 1993011},
+		{name: "fee rounded up", reserveIn: 1_000_000, reserveOut: 5_000_000, amountIn: 1001, feeNumerator: 25, feeDenom: 10000, want: 4985},
+		{name: "decimals skew", reserveIn: 250_000_000_000_000, reserveOut: 40_000_000_000, amountIn: 3_000_000_000, feeNumerator: 25, feeDenom: 10000, want: 478794},
+		{name: "over 64 bits products", reserveIn: math.MaxUint64, reserveOut: math.MaxUint64, amountIn: math.MaxUint64, feeNumerator: 25, feeDenom: 10000, want: 9211828392252955061},
+		{name: "no fee", reserveIn: 100, reserveOut: 100, amountIn: 100, feeNumerator: 0, feeDenom: 10000, want: 50},
+		{name: "empty pool", reserveIn: 0, reserveOut: 100, amountIn: 100, feeNumerator: 25, feeDenom: 10000, want: 0},
+		{name: "invalid fee", reserveIn: 100, reserveOut: 100, amountIn: 100, feeNumerator: 25, feeDenom: 0, want: 0},
+	}
+	for _, test := range tests {
+		got := QuoteSwap(test.reserveIn, test.reserveOut, test.amountIn, test.feeNumerator, test.feeDenom)
+		if got != test.want {
+			t.Errorf("%s: QuoteSwap Err ==> Got %d, Want: %d", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package raydium
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/types/base"
+)
+
+const (
+	// AmmInfoSize the size of a Raydium AMM v4 pool account
+	AmmInfoSize = 752
+	// MarketStateSize the size of an OpenBook (Serum v3) market account
+	MarketStateSize = 388
+)
+
+// AmmStatus the status of an AMM pool
+type AmmStatus uint64
+
+const (
+	AmmStatusUninitialized AmmStatus = iota
+	AmmStatusInitialized
+	AmmStatusDisabled
+	AmmStatusWithdrawOnly
+	AmmStatusLiquidityOnly
+	AmmStatusOrderBookOnly
+	AmmStatusSwapOnly
+	AmmStatusWaitingTrade
+)
+
+// AmmFees the fees of an AMM pool, as numerator / denominator fractions
+type AmmFees struct {
+	MinSeparateNumerator   uint64
+	MinSeparateDenominator uint64
+	TradeFeeNumerator      uint64
+	TradeFeeDenominator    uint64
+	PnlNumerator           uint64
+	PnlDenominator         uint64
+	SwapFeeNumerator       uint64
+	SwapFeeDenominator     uint64
+}
+
+// AmmStateData the pnl and swap statistics of an AMM pool
+type AmmStateData struct {
+	// Coin pnl not yet taken, still held by the coin vault
+	NeedTakePnlCoin uint64
+	// Pc pnl not yet taken, still held by the pc vault
+	NeedTakePnlPc uint64
+	TotalPnlPc    uint64
+	TotalPnlCoin  uint64
+	// Unix timestamp the pool opens for swaps
+	PoolOpenTime        uint64
+	PunishPcAmount      uint64
+	PunishCoinAmount    uint64
+	OrderbookToInitTime uint64
+	SwapCoinInAmount    encodbin.Uint128
+	SwapPcOutAmount     encodbin.Uint128
+	SwapAccPcFee        uint64
+	SwapPcInAmount      encodbin.Uint128
+	SwapCoinOutAmount   encodbin.Uint128
+	SwapAccCoinFee      uint64
+}
+
+// AmmInfo the Raydium AMM v4 pool account
+type AmmInfo struct {
+	Status             AmmStatus
+	Nonce              uint64
+	OrderNum           uint64
+	Depth              uint64
+	CoinDecimals       uint64
+	PcDecimals         uint64
+	State              uint64
+	ResetFlag          uint64
+	MinSize            uint64
+	VolMaxCutRatio     uint64
+	AmountWave         uint64
+	CoinLotSize        uint64
+	PcLotSize          uint64
+	MinPriceMultiplier uint64
+	MaxPriceMultiplier uint64
+	SysDecimalValue    uint64
+	Fees               AmmFees
+	StateData          AmmStateData
+	CoinVault          common.Address
+	PcVault            common.Address
+	CoinMint           common.Address
+	PcMint             common.Address
+	LpMint             common.Address
+	OpenOrders         common.Address
+	Market             common.Address
+	MarketProgram      common.Address
+	TargetOrders       common.Address
+	Padding1           [8]uint64
+	AmmOwner           common.Address
+	LpAmount           uint64
+	ClientOrderID      uint64
+	RecentEpoch        uint64
+	Padding2           uint64
+}
+
+// DecodeAmmInfo decode the data of a Raydium AMM v4 pool account
+func DecodeAmmInfo(data []byte) (*AmmInfo, error) {
+	if len(data) != AmmInfoSize {
+		return nil, fmt.Errorf("invalid amm account size: %d, Require: %d", len(data), AmmInfoSize)
+	}
+	amm := new(AmmInfo)
+	if err := encodbin.UnmarshalBin(amm, data); err != nil {
+		return nil, fmt.Errorf("unable to decode amm account: %w", err)
+	}
+	return amm, nil
+}
+
+// SwapEnabled reports whether the pool status allows swaps
+func (amm *AmmInfo) SwapEnabled() bool {
+	switch amm.Status {
+	case AmmStatusInitialized, AmmStatusSwapOnly, AmmStatusWaitingTrade:
+		return true
+	}
+	return false
+}
+
+// Reserves Returns the pool reserves from the vault amounts, excluding the pnl not yet taken.
+// The amounts of the open orders account are not counted
+func (amm *AmmInfo) Reserves(coinVaultAmount, pcVaultAmount uint64) (coin, pc uint64) {
+	if coinVaultAmount > amm.StateData.NeedTakePnlCoin {
+		coin = coinVaultAmount - amm.StateData.NeedTakePnlCoin
+	}
+	if pcVaultAmount > amm.StateData.NeedTakePnlPc {
+		pc = pcVaultAmount - amm.StateData.NeedTakePnlPc
+	}
+	return coin, pc
+}
+
+// QuoteSwap Returns the amount out of a swap with the swap fee of the pool, see QuoteSwap
+func (amm *AmmInfo) QuoteSwap(reserveIn, reserveOut, amountIn uint64) uint64 {
+	return QuoteSwap(reserveIn, reserveOut, amountIn, amm.Fees.SwapFeeNumerator, amm.Fees.SwapFeeDenominator)
+}
+
+// MarketState the OpenBook (Serum v3) market account
+type MarketState struct {
+	// "serum" head padding
+	Head                   [5]byte
+	AccountFlags           uint64
+	OwnAddress             common.Address
+	VaultSignerNonce       uint64
+	BaseMint               common.Address
+	QuoteMint              common.Address
+	BaseVault              common.Address
+	BaseDepositsTotal      uint64
+	BaseFeesAccrued        uint64
+	QuoteVault             common.Address
+	QuoteDepositsTotal     uint64
+	QuoteFeesAccrued       uint64
+	QuoteDustThreshold     uint64
+	RequestQueue           common.Address
+	EventQueue             common.Address
+	Bids                   common.Address
+	Asks                   common.Address
+	BaseLotSize            uint64
+	QuoteLotSize           uint64
+	FeeRateBps             uint64
+	ReferrerRebatesAccrued uint64
+	// "padding" tail padding
+	Tail [7]byte
+}
+
+// DecodeMarketState decode the data of an OpenBook (Serum v3) market account
+func DecodeMarketState(data []byte) (*MarketState, error) {
+	if len(data) != MarketStateSize {
+		return nil, fmt.Errorf("invalid market account size: %d, Require: %d", len(data), MarketStateSize)
+	}
+	market := new(MarketState)
+	if err := encodbin.UnmarshalBin(market, data); err != nil {
+		return nil, fmt.Errorf("unable to decode market account: %w", err)
+	}
+	return market, nil
+}
+
+// VaultSigner Returns the vault signer of the market, the authority of the market vaults
+func (market *MarketState) VaultSigner(marketProgram common.Address) (common.Address, error) {
+	nonce := make([]byte, 8)
+	binary.LittleEndian.PutUint64(nonce, market.VaultSignerNonce)
+	return base.CreateProgramAddress([][]byte{market.OwnAddress.Bytes(), nonce}, marketProgram)
+}
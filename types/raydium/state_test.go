@@ -0,0 +1,136 @@
+package raydium
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/cielu/go-solana/common"
+)
+
+var (
+	solMint       = common.StrToAddress("So11111111111111111111111111111111111111112")
+	usdcMint      = common.StrToAddress("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")
+	coinVault     = common.StrToAddress("DQyrAcCrDXQ7NeoqGgDCZwBvWDcYmFCjSb9JtteuvPpz")
+	pcVault       = common.StrToAddress("HLmqeL62xR1QoZ1HKKbXRrdN1p3phKpxRMb2VVopvBBz")
+	openOrders    = common.StrToAddress("HmiHHzq4Fym9e1D4qzLS6LDDM3tNsCTBPDWHTLZ763jY")
+	targetOrders  = common.StrToAddress("CZza3Ej4Mc58MnxWA385itCC9jCo3L1D7zc3LKy1bZMR")
+	market        = common.StrToAddress("8BnEgHoWFysVcuFFX7QztDmzuH8r5ZFvyP3sYwn1XTh6")
+	marketProgram = common.StrToAddress("srmqPvymJeFKQ4zGQed1GFppgkRHL9kaELCbyksJtPX")
+	ammOwner      = common.StrToAddress("GThUX1Atko4tqhN2NaiTazWSeFWMuiUvfFnyJyUghFMJ")
+)
+
+// ammAccountData the SOL-USDC pool account laid out as the AMM program stores it
+func ammAccountData() []byte {
+	data := make([]byte, AmmInfoSize)
+	u64 := func(offset int, v uint64) { binary.LittleEndian.PutUint64(data[offset:], v) }
+	u64(0, uint64(AmmStatusSwapOnly))
+	u64(8, 254)      // nonce
+	u64(32, 9)       // coin decimals
+	u64(40, 6)       // pc decimals
+	u64(88, 1000000) // coin lot size
+	u64(96, 1)       // pc lot size
+	// fees
+	u64(128, 5)
+	u64(136, 100)
+	u64(144, 25)
+	u64(152, 10000)
+	u64(160, 12)
+	u64(168, 100)
+	u64(176, 25)
+	u64(184, 10000)
+	// state data
+	u64(192, 1_500_000)     // need take pnl coin
+	u64(200, 2_000_000)     // need take pnl pc
+	u64(224, 1_665_000_000) // pool open time
+	// swap coin in amount, u128 of 2^64 + 7
+	u64(256, 7)
+	u64(264, 1)
+	for offset, key := range []common.Address{coinVault, pcVault, solMint, usdcMint, {}, openOrders, market, marketProgram, targetOrders} {
+		copy(data[336+offset*32:], key[:])
+	}
+	copy(data[688:], ammOwner[:])
+	u64(720, 5_000_000_000) // lp amount
+	u64(736, 600)           // recent epoch
+	return data
+}
+
+// marketAccountData the SOL-USDC market account laid out as the OpenBook program stores it
+func marketAccountData() []byte {
+	data := make([]byte, MarketStateSize)
+	u64 := func(offset int, v uint64) { binary.LittleEndian.PutUint64(data[offset:], v) }
+	copy(data, "serum")
+	u64(5, 3) // initialized | market
+	copy(data[13:], market[:])
+	u64(45, 1) // vault signer nonce
+	copy(data[53:], solMint[:])
+	copy(data[85:], usdcMint[:])
+	copy(data[117:], coinVault[:])
+	u64(149, 42_000_000_000)
+	copy(data[165:], pcVault[:])
+	u64(197, 3_900_000_000)
+	u64(213, 100) // quote dust threshold
+	u64(349, 100_000_000)
+	u64(357, 100)
+	copy(data[381:], "padding")
+	return data
+}
+
+func TestDecodeAmmInfo(t *testing.T) {
+	amm, err := DecodeAmmInfo(ammAccountData())
+	if err != nil {
+		t.Fatalf("DecodeAmmInfo Failed: %s", err)
+	}
+	if amm.Status != AmmStatusSwapOnly || !amm.SwapEnabled() || amm.Nonce != 254 {
+		t.Errorf("Status Err ==> Got %d %d, Want: %d", amm.Status, amm.Nonce, AmmStatusSwapOnly)
+	}
+	if amm.CoinDecimals != 9 || amm.PcDecimals != 6 || amm.CoinLotSize != 1000000 {
+		t.Errorf("Decimals Err ==> Got %d %d, Want: 9 6", amm.CoinDecimals, amm.PcDecimals)
+	}
+	if amm.Fees.SwapFeeNumerator != 25 || amm.Fees.SwapFeeDenominator != 10000 || amm.Fees.PnlDenominator != 100 {
+		t.Errorf("Fees Err ==> Got %+v", amm.Fees)
+	}
+	if amm.StateData.PoolOpenTime != 1_665_000_000 || amm.StateData.SwapCoinInAmount.Lo != 7 || amm.StateData.SwapCoinInAmount.Hi != 1 {
+		t.Errorf("StateData Err ==> Got %+v", amm.StateData)
+	}
+	if amm.CoinVault != coinVault || amm.PcVault != pcVault || amm.CoinMint != solMint || amm.PcMint != usdcMint {
+		t.Errorf("Vaults Err ==> Got %s %s", amm.CoinVault, amm.PcVault)
+	}
+	if amm.OpenOrders != openOrders || amm.TargetOrders != targetOrders || amm.Market != market || amm.MarketProgram != marketProgram {
+		t.Errorf("Market Err ==> Got %s %s", amm.Market, amm.MarketProgram)
+	}
+	if amm.AmmOwner != ammOwner || amm.LpAmount != 5_000_000_000 || amm.RecentEpoch != 600 {
+		t.Errorf("AmmOwner Err ==> Got %s %d", amm.AmmOwner, amm.LpAmount)
+	}
+	coin, pc := amm.Reserves(101_500_000, 20_002_000_000)
+	if coin != 100_000_000 || pc != 20_000_000_000 {
+		t.Errorf("Reserves Err ==> Got %d %d, Want: 100000000 20000000000", coin, pc)
+	}
+	if got := amm.QuoteSwap(coin, pc, 1_000_000); got != QuoteSwap(coin, pc, 1_000_000, 25, 10000) {
+		t.Errorf("QuoteSwap Err ==> Got %d", got)
+	}
+	if _, err = DecodeAmmInfo(make([]byte, AmmInfoSize-1)); err == nil {
+		t.Errorf("DecodeAmmInfo Err ==> Got nil, Want: invalid amm account size")
+	}
+}
+
+func TestDecodeMarketState(t *testing.T) {
+	state, err := DecodeMarketState(marketAccountData())
+	if err != nil {
+		t.Fatalf("DecodeMarketState Failed: %s", err)
+	}
+	if string(state.Head[:]) != "serum" || string(state.Tail[:]) != "padding" || state.AccountFlags != 3 {
+		t.Errorf("Padding Err ==> Got %s %s", state.Head, state.Tail)
+	}
+	if state.OwnAddress != market || state.BaseMint != solMint || state.QuoteMint != usdcMint {
+		t.Errorf("Mints Err ==> Got %s %s", state.BaseMint, state.QuoteMint)
+	}
+	if state.BaseVault != coinVault || state.QuoteVault != pcVault || state.BaseDepositsTotal != 42_000_000_000 || state.QuoteDepositsTotal != 3_900_000_000 {
+		t.Errorf("Vaults Err ==> Got %s %s", state.BaseVault, state.QuoteVault)
+	}
+	if state.QuoteDustThreshold != 100 || state.BaseLotSize != 100_000_000 || state.QuoteLotSize != 100 {
+		t.Errorf("LotSize Err ==> Got %d %d", state.BaseLotSize, state.QuoteLotSize)
+	}
+	if _, err = state.VaultSigner(marketProgram); err != nil {
+		t.Errorf("VaultSigner Failed: %s", err)
+	}
+}
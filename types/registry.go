@@ -0,0 +1,42 @@
+package types
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/types/base"
+	computebudget "github.com/cielu/go-solana/types/compute-budget"
+)
+
+// InstructionDecoder decodes the accounts and data of an instruction into its typed instruction
+type InstructionDecoder func(accounts []*base.AccountMeta, data []byte) (interface{}, error)
+
+var instructionDecoders = struct {
+	sync.RWMutex
+	m map[common.Address]InstructionDecoder
+}{m: make(map[common.Address]InstructionDecoder)}
+
+// The system and token packages register their decoders when imported,
+// the compute budget package can't import types so it's registered here
+func init() {
+	RegisterInstructionDecoder(base.ComputeBudget, computebudget.DecodeInstruction)
+}
+
+// RegisterInstructionDecoder registers the instruction decoder of a program, replacing the previous one
+func RegisterInstructionDecoder(programID common.Address, decoder InstructionDecoder) {
+	instructionDecoders.Lock()
+	defer instructionDecoders.Unlock()
+	instructionDecoders.m[programID] = decoder
+}
+
+// DecodeInstruction decodes an instruction with the decoder registered for the program
+func DecodeInstruction(programID common.Address, data []byte, accounts []*base.AccountMeta) (interface{}, error) {
+	instructionDecoders.RLock()
+	decoder, ok := instructionDecoders.m[programID]
+	instructionDecoders.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no instruction decoder registered for program %s", programID)
+	}
+	return decoder(accounts, data)
+}
@@ -0,0 +1,278 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package types
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/pkg/encodbin"
+)
+
+const (
+	// StakeStateUninitialized the stake account is not initialized
+	StakeStateUninitialized uint32 = iota
+	// StakeStateInitialized the stake account is initialized but not delegated
+	StakeStateInitialized
+	// StakeStateStake the stake account is delegated
+	StakeStateStake
+	// StakeStateRewardsPool the stake account is a rewards pool
+	StakeStateRewardsPool
+)
+
+const (
+	// WarmupCooldownRate the fraction of the cluster effective stake that can (de)activate per epoch
+	WarmupCooldownRate = 0.25
+	// NewWarmupCooldownRate the rate once the reduce_stake_warmup_cooldown feature is active
+	NewWarmupCooldownRate = 0.09
+)
+
+const (
+	StakeActivationActive       = "active"
+	StakeActivationInactive     = "inactive"
+	StakeActivationActivating   = "activating"
+	StakeActivationDeactivating = "deactivating"
+)
+
+var ErrStakeNotDelegated = errors.New("stake account not delegated")
+
+type StakeAuthorized struct {
+	Staker     common.Address
+	Withdrawer common.Address
+}
+
+type StakeLockup struct {
+	UnixTimestamp int64
+	Epoch         uint64
+	Custodian     common.Address
+}
+
+type StakeMeta struct {
+	RentExemptReserve uint64
+	Authorized        StakeAuthorized
+	Lockup            StakeLockup
+}
+
+type StakeDelegation struct {
+	VoterPubkey       common.Address
+	Stake             uint64
+	ActivationEpoch   uint64
+	DeactivationEpoch uint64
+	// deprecated, the rate is picked by epoch, see NewWarmupCooldownRate
+	WarmupCooldownRate float64
+}
+
+type StakeInfo struct {
+	Delegation      StakeDelegation
+	CreditsObserved uint64
+}
+
+// StakeAccount the StakeStateV2 of a stake program account
+type StakeAccount struct {
+	State uint32
+	Meta  StakeMeta
+	Stake StakeInfo
+}
+
+// DecodeStakeAccount decode the data of a stake program account
+func DecodeStakeAccount(data []byte) (account StakeAccount, err error) {
+	err = encodbin.UnmarshalBin(&account, data)
+	if err != nil {
+		return account, fmt.Errorf("unable to decode stake account: %w", err)
+	}
+	if account.State > StakeStateRewardsPool {
+		return account, fmt.Errorf("unknown stake account state: %d", account.State)
+	}
+	return
+}
+
+// StakeHistoryEntry the cluster stake of an epoch
+type StakeHistoryEntry struct {
+	Effective    uint64
+	Activating   uint64
+	Deactivating uint64
+}
+
+// StakeHistory the StakeHistory sysvar, by epoch
+type StakeHistory map[uint64]StakeHistoryEntry
+
+// DecodeStakeHistory decode the data of the StakeHistory sysvar
+func DecodeStakeHistory(data []byte) (StakeHistory, error) {
+	decoder := encodbin.NewBinDecoder(data)
+	length, err := decoder.ReadUint64(binary.LittleEndian)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read stake history length: %w", err)
+	}
+	if length > uint64(decoder.Remaining()/32) {
+		return nil, fmt.Errorf("invalid stake history length: %d", length)
+	}
+	history := make(StakeHistory, length)
+	for i := uint64(0); i < length; i++ {
+		var (
+			epoch uint64
+			entry StakeHistoryEntry
+		)
+		if epoch, err = decoder.ReadUint64(binary.LittleEndian); err != nil {
+			return nil, err
+		}
+		if err = decoder.Decode(&entry); err != nil {
+			return nil, err
+		}
+		history[epoch] = entry
+	}
+	return history, nil
+}
+
+// warmupCooldownRate the rate at the epoch, newRateActivationEpoch nil means the new rate is not active
+func warmupCooldownRate(epoch uint64, newRateActivationEpoch *uint64) float64 {
+	if newRateActivationEpoch == nil || epoch < *newRateActivationEpoch {
+		return WarmupCooldownRate
+	}
+	return NewWarmupCooldownRate
+}
+
+// stakeAndActivating returns the effective and activating stake at the target epoch
+func (d StakeDelegation) stakeAndActivating(targetEpoch uint64, history StakeHistory, newRateActivationEpoch *uint64) (uint64, uint64) {
+	delegatedStake := d.Stake
+	switch {
+	case d.ActivationEpoch == math.MaxUint64:
+		// bootstrap stake is fully active
+		return delegatedStake, 0
+	case d.ActivationEpoch == d.DeactivationEpoch:
+		// deactivated before activation
+		return 0, 0
+	case targetEpoch == d.ActivationEpoch:
+		return 0, delegatedStake
+	case targetEpoch < d.ActivationEpoch:
+		return 0, 0
+	}
+	prevCluster, ok := history[d.ActivationEpoch]
+	if !ok {
+		// no history means the warmup is over
+		return delegatedStake, 0
+	}
+	var (
+		prevEpoch      = d.ActivationEpoch
+		effectiveStake uint64
+	)
+	for {
+		currentEpoch := prevEpoch + 1
+		if prevCluster.Activating == 0 {
+			break
+		}
+		weight := float64(delegatedStake-effectiveStake) / float64(prevCluster.Activating)
+		newlyEffectiveCluster := float64(prevCluster.Effective) * warmupCooldownRate(currentEpoch, newRateActivationEpoch)
+		newlyEffective := uint64(weight * newlyEffectiveCluster)
+		if newlyEffective < 1 {
+			newlyEffective = 1
+		}
+		effectiveStake += newlyEffective
+		if effectiveStake >= delegatedStake {
+			effectiveStake = delegatedStake
+			break
+		}
+		if currentEpoch >= targetEpoch || currentEpoch >= d.DeactivationEpoch {
+			break
+		}
+		if prevCluster, ok = history[currentEpoch]; !ok {
+			break
+		}
+		prevEpoch = currentEpoch
+	}
+	return effectiveStake, delegatedStake - effectiveStake
+}
+
+// ActivatingAndDeactivating returns the effective, activating and deactivating stake
+// of the delegation at the target epoch, the same way as the stake program.
+func (d StakeDelegation) ActivatingAndDeactivating(targetEpoch uint64, history StakeHistory, newRateActivationEpoch *uint64) (effective, activating, deactivating uint64) {
+	effective, activating = d.stakeAndActivating(targetEpoch, history, newRateActivationEpoch)
+	if targetEpoch < d.DeactivationEpoch {
+		return effective, activating, 0
+	}
+	if targetEpoch == d.DeactivationEpoch {
+		return effective, 0, effective
+	}
+	prevCluster, ok := history[d.DeactivationEpoch]
+	if !ok {
+		// no history means the cooldown is over
+		return 0, 0, 0
+	}
+	prevEpoch := d.DeactivationEpoch
+	for {
+		currentEpoch := prevEpoch + 1
+		if prevCluster.Deactivating == 0 {
+			break
+		}
+		weight := float64(effective) / float64(prevCluster.Deactivating)
+		newlyNotEffectiveCluster := float64(prevCluster.Effective) * warmupCooldownRate(currentEpoch, newRateActivationEpoch)
+		newlyNotEffective := uint64(weight * newlyNotEffectiveCluster)
+		if newlyNotEffective < 1 {
+			newlyNotEffective = 1
+		}
+		if newlyNotEffective >= effective {
+			effective = 0
+			break
+		}
+		effective -= newlyNotEffective
+		if currentEpoch >= targetEpoch {
+			break
+		}
+		if prevCluster, ok = history[currentEpoch]; !ok {
+			break
+		}
+		prevEpoch = currentEpoch
+	}
+	return effective, 0, effective
+}
+
+// Activation computes the stake activation at the epoch, as returned by the
+// deprecated getStakeActivation rpc method. lamports is the stake account balance.
+func (sa StakeAccount) Activation(lamports uint64, epoch uint64, history StakeHistory, newRateActivationEpoch *uint64) (res StakeActivation, err error) {
+	switch sa.State {
+	case StakeStateInitialized:
+		res.State = StakeActivationInactive
+		res.Inactive = saturatingSub(lamports, sa.Meta.RentExemptReserve)
+		return res, nil
+	case StakeStateStake:
+	default:
+		return res, ErrStakeNotDelegated
+	}
+	effective, activating, deactivating := sa.Stake.Delegation.ActivatingAndDeactivating(epoch, history, newRateActivationEpoch)
+	switch {
+	case deactivating > 0:
+		res.State = StakeActivationDeactivating
+	case activating > 0:
+		res.State = StakeActivationActivating
+	case effective > 0:
+		res.State = StakeActivationActive
+	default:
+		res.State = StakeActivationInactive
+	}
+	res.Active = effective
+	res.Inactive = saturatingSub(saturatingSub(lamports, effective), sa.Meta.RentExemptReserve)
+	return res, nil
+}
+
+// IsEligibleForRewards reports whether the stake earns the rewards of the epoch: it must be delegated,
+// activated before the epoch and not deactivated before it. The rewards of an epoch are paid on the stake
+// effective during the whole epoch, a stake activating in the epoch only earns from the next one
+func (sa StakeAccount) IsEligibleForRewards(epoch uint64) bool {
+	if sa.State != StakeStateStake {
+		return false
+	}
+	delegation := sa.Stake.Delegation
+	// the bootstrap stakes of the genesis are active from the first epoch
+	activated := delegation.ActivationEpoch == math.MaxUint64 || delegation.ActivationEpoch < epoch
+	return activated && delegation.DeactivationEpoch >= epoch
+}
+
+func saturatingSub(a, b uint64) uint64 {
+	if a < b {
+		return 0
+	}
+	return a - b
+}
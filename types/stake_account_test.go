@@ -0,0 +1,121 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/pkg/encodbin"
+)
+
+// stakeAccountBlob encode the stake account as the 200 bytes account data
+func stakeAccountBlob(t *testing.T, account StakeAccount) []byte {
+	data, err := encodbin.MarshalBin(&account)
+	if err != nil {
+		t.Fatalf("MarshalBin Failed: %s", err)
+	}
+	return append(data, make([]byte, 200-len(data))...)
+}
+
+func TestStakeAccountActivation(t *testing.T) {
+	const (
+		rent  = 2282880
+		stake = 1_000_000_000
+		epoch = 100
+	)
+	var (
+		newRateEpoch uint64
+		voter        = common.Base58ToAddress("9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM")
+	)
+	delegated := func(activation, deactivation uint64) StakeAccount {
+		return StakeAccount{
+			State: StakeStateStake,
+			Meta:  StakeMeta{RentExemptReserve: rent},
+			Stake: StakeInfo{Delegation: StakeDelegation{
+				VoterPubkey:        voter,
+				Stake:              stake,
+				ActivationEpoch:    activation,
+				DeactivationEpoch:  deactivation,
+				WarmupCooldownRate: WarmupCooldownRate,
+			}},
+		}
+	}
+	// cluster stake of epoch 99, 9% of the effective stake can activate
+	buf := new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []uint64{1, 99, 1e12, 1e11, 0})
+	history, err := DecodeStakeHistory(buf.Bytes())
+	if err != nil {
+		t.Fatalf("DecodeStakeHistory Failed: %s", err)
+	}
+	if history[99].Activating != 1e11 {
+		t.Fatalf("DecodeStakeHistory Err ==> Got %v", history)
+	}
+
+	tests := []struct {
+		name    string
+		account StakeAccount
+		want    StakeActivation
+	}{
+		{"delegated", delegated(90, math.MaxUint64), StakeActivation{State: StakeActivationActive, Active: stake}},
+		{"activating", delegated(epoch, math.MaxUint64), StakeActivation{State: StakeActivationActivating, Inactive: stake}},
+		{"warming up", delegated(99, math.MaxUint64), StakeActivation{State: StakeActivationActivating, Active: 9e8, Inactive: 1e8}},
+		{"deactivating", delegated(10, epoch), StakeActivation{State: StakeActivationDeactivating, Active: stake}},
+		{"deactivated", delegated(10, 90), StakeActivation{State: StakeActivationInactive, Inactive: stake}},
+		{"initialized", StakeAccount{State: StakeStateInitialized, Meta: StakeMeta{RentExemptReserve: rent}}, StakeActivation{State: StakeActivationInactive, Inactive: stake}},
+	}
+	for _, test := range tests {
+		account, err := DecodeStakeAccount(stakeAccountBlob(t, test.account))
+		if err != nil {
+			t.Errorf("%s: DecodeStakeAccount Failed: %s", test.name, err)
+			continue
+		}
+		if account != test.account {
+			t.Errorf("%s: DecodeStakeAccount Err ==> Got %+v, Want: %+v", test.name, account, test.account)
+		}
+		got, err := account.Activation(stake+rent, epoch, history, &newRateEpoch)
+		if err != nil {
+			t.Errorf("%s: Activation Failed: %s", test.name, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("%s: Activation Err ==> Got %+v, Want: %+v", test.name, got, test.want)
+		}
+	}
+
+	// not delegated
+	account, _ := DecodeStakeAccount(make([]byte, 200))
+	if _, err = account.Activation(rent, epoch, history, &newRateEpoch); !errors.Is(err, ErrStakeNotDelegated) {
+		t.Errorf("Activation Err ==> Got %v, Want: %v", err, ErrStakeNotDelegated)
+	}
+}
+
+func TestStakeAccountIsEligibleForRewards(t *testing.T) {
+	const epoch = 100
+	delegated := func(activation, deactivation uint64) StakeAccount {
+		return StakeAccount{State: StakeStateStake, Stake: StakeInfo{Delegation: StakeDelegation{
+			Stake:             1_000_000_000,
+			ActivationEpoch:   activation,
+			DeactivationEpoch: deactivation,
+		}}}
+	}
+	tests := []struct {
+		name    string
+		account StakeAccount
+		want    bool
+	}{
+		{"initialized", StakeAccount{State: StakeStateInitialized}, false},
+		{"activating", delegated(epoch, math.MaxUint64), false},
+		{"active", delegated(epoch-1, math.MaxUint64), true},
+		{"bootstrap", delegated(math.MaxUint64, math.MaxUint64), true},
+		{"deactivating", delegated(epoch-10, epoch), true},
+		{"deactivated", delegated(epoch-10, epoch-1), false},
+	}
+	for _, tt := range tests {
+		if got := tt.account.IsEligibleForRewards(epoch); got != tt.want {
+			t.Errorf("%s: IsEligibleForRewards Err ==> Got %v, Want: %v", tt.name, got, tt.want)
+		}
+	}
+}
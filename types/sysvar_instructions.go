@@ -0,0 +1,108 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package types
+
+import (
+	"encoding/binary"
+	"fmt"
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/types/base"
+)
+
+const (
+	// instructionsSysvarSignerFlag account meta is signer
+	instructionsSysvarSignerFlag = 1 << 0
+	// instructionsSysvarWritableFlag account meta is writable
+	instructionsSysvarWritableFlag = 1 << 1
+)
+
+// SysvarInstruction an instruction decoded from the Instructions sysvar.
+// Unlike CompiledInstruction, the sysvar stores the account pubkeys instead of
+// indexes into the message account keys, so it implements Instruction directly.
+type SysvarInstruction struct {
+	ProgramAddr common.Address
+	AccountList []*base.AccountMeta
+	DataBytes   []byte
+}
+
+func (inst *SysvarInstruction) ProgramID() common.Address {
+	return inst.ProgramAddr
+}
+
+func (inst *SysvarInstruction) Accounts() []*base.AccountMeta {
+	return inst.AccountList
+}
+
+func (inst *SysvarInstruction) Data() ([]byte, error) {
+	return inst.DataBytes, nil
+}
+
+// DecodeInstructionsSysvar decode the data of the Instructions sysvar account,
+// returns the serialized instructions and the index of the current executing instruction.
+//
+// Layout:
+//
+//	u16 numInstructions
+//	u16 offsets[numInstructions]
+//	per instruction: u16 numAccounts, (u8 flags, [32]u8 pubkey)[numAccounts], [32]u8 programID, u16 dataLen, data
+//	u16 currentIndex (the last two bytes)
+func DecodeInstructionsSysvar(data []byte) ([]SysvarInstruction, uint16, error) {
+	if len(data) < 4 {
+		return nil, 0, fmt.Errorf("invalid instructions sysvar length: %d", len(data))
+	}
+	decoder := encodbin.NewBinDecoder(data)
+
+	numInstructions, err := decoder.ReadUint16(binary.LittleEndian)
+	if err != nil {
+		return nil, 0, fmt.Errorf("unable to decode numInstructions: %w", err)
+	}
+	offsets := make([]uint16, numInstructions)
+	for i := range offsets {
+		if offsets[i], err = decoder.ReadUint16(binary.LittleEndian); err != nil {
+			return nil, 0, fmt.Errorf("unable to decode offsets[%d]: %w", i, err)
+		}
+	}
+
+	instructions := make([]SysvarInstruction, numInstructions)
+	for i, offset := range offsets {
+		if err = decoder.SetPosition(uint(offset)); err != nil {
+			return nil, 0, fmt.Errorf("invalid offset for ix[%d]: %w", i, err)
+		}
+		numAccounts, err := decoder.ReadUint16(binary.LittleEndian)
+		if err != nil {
+			return nil, 0, fmt.Errorf("unable to decode numAccounts for ix[%d]: %w", i, err)
+		}
+		instructions[i].AccountList = make([]*base.AccountMeta, numAccounts)
+		for j := range instructions[i].AccountList {
+			flags, err := decoder.ReadUint8()
+			if err != nil {
+				return nil, 0, fmt.Errorf("unable to decode flags for ix[%d].Accounts[%d]: %w", i, j, err)
+			}
+			var pubkey common.Address
+			if _, err = decoder.Read(pubkey[:]); err != nil {
+				return nil, 0, fmt.Errorf("unable to decode pubkey for ix[%d].Accounts[%d]: %w", i, j, err)
+			}
+			instructions[i].AccountList[j] = base.NewAccountMeta(
+				pubkey,
+				flags&instructionsSysvarWritableFlag != 0,
+				flags&instructionsSysvarSignerFlag != 0,
+			)
+		}
+		if _, err = decoder.Read(instructions[i].ProgramAddr[:]); err != nil {
+			return nil, 0, fmt.Errorf("unable to decode programID for ix[%d]: %w", i, err)
+		}
+		dataLen, err := decoder.ReadUint16(binary.LittleEndian)
+		if err != nil {
+			return nil, 0, fmt.Errorf("unable to decode dataLen for ix[%d]: %w", i, err)
+		}
+		if instructions[i].DataBytes, err = decoder.ReadNBytes(int(dataLen)); err != nil {
+			return nil, 0, fmt.Errorf("unable to decode data for ix[%d]: %w", i, err)
+		}
+	}
+
+	currentIndex := binary.LittleEndian.Uint16(data[len(data)-2:])
+
+	return instructions, currentIndex, nil
+}
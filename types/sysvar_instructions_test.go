@@ -0,0 +1,81 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/types/base"
+)
+
+// TestDecodeInstructionsSysvar decode a sysvar blob assembled by hand following
+// the layout of solana_program::sysvar::instructions, not captured from a node
+func TestDecodeInstructionsSysvar(t *testing.T) {
+	var (
+		from   = common.Base58ToAddress("9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM")
+		to     = common.Base58ToAddress("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")
+		u16    = func(v uint16) []byte { b := make([]byte, 2); binary.LittleEndian.PutUint16(b, v); return b }
+		limit  = []byte{2, 0x40, 0x0d, 0x03, 0x00}
+		trans  = []byte{2, 0, 0, 0, 0x40, 0x42, 0x0f, 0, 0, 0, 0, 0}
+		ix0    []byte
+		ix1    []byte
+		sysvar []byte
+	)
+	// compute budget: no accounts
+	ix0 = append(ix0, u16(0)...)
+	ix0 = append(ix0, base.ComputeBudgetProgramID[:]...)
+	ix0 = append(ix0, u16(uint16(len(limit)))...)
+	ix0 = append(ix0, limit...)
+	// system transfer: from (signer, writable), to (writable)
+	ix1 = append(ix1, u16(2)...)
+	ix1 = append(ix1, 0b11)
+	ix1 = append(ix1, from[:]...)
+	ix1 = append(ix1, 0b10)
+	ix1 = append(ix1, to[:]...)
+	ix1 = append(ix1, base.SystemProgramID[:]...)
+	ix1 = append(ix1, u16(uint16(len(trans)))...)
+	ix1 = append(ix1, trans...)
+	// header: count + offsets
+	sysvar = append(sysvar, u16(2)...)
+	sysvar = append(sysvar, u16(6)...)
+	sysvar = append(sysvar, u16(uint16(6+len(ix0)))...)
+	sysvar = append(sysvar, ix0...)
+	sysvar = append(sysvar, ix1...)
+	// current index
+	sysvar = append(sysvar, u16(1)...)
+
+	instructions, current, err := DecodeInstructionsSysvar(sysvar)
+	if err != nil {
+		t.Fatalf("DecodeInstructionsSysvar Failed: %s", err.Error())
+	}
+	if current != 1 {
+		t.Errorf("current index Err ==> Got %d, Want: %d", current, 1)
+	}
+	if len(instructions) != 2 {
+		t.Fatalf("instructions length Err ==> Got %d, Want: %d", len(instructions), 2)
+	}
+	if instructions[0].ProgramID() != base.ComputeBudgetProgramID || len(instructions[0].Accounts()) != 0 {
+		t.Errorf("ix[0] Err ==> Got %s, Want: %s", instructions[0].ProgramID(), base.ComputeBudgetProgramID)
+	}
+	if data, _ := instructions[0].Data(); !bytes.Equal(data, limit) {
+		t.Errorf("ix[0] data Err ==> Got %v, Want: %v", data, limit)
+	}
+	accounts := instructions[1].Accounts()
+	if instructions[1].ProgramID() != base.SystemProgramID || len(accounts) != 2 {
+		t.Fatalf("ix[1] Err ==> Got %s with %d accounts", instructions[1].ProgramID(), len(accounts))
+	}
+	if accounts[0].PublicKey != from || !accounts[0].IsSigner || !accounts[0].IsWritable {
+		t.Errorf("ix[1].Accounts[0] Err ==> Got %+v", accounts[0])
+	}
+	if accounts[1].PublicKey != to || accounts[1].IsSigner || !accounts[1].IsWritable {
+		t.Errorf("ix[1].Accounts[1] Err ==> Got %+v", accounts[1])
+	}
+	if data, _ := instructions[1].Data(); !bytes.Equal(data, trans) {
+		t.Errorf("ix[1] data Err ==> Got %v, Want: %v", data, trans)
+	}
+	// truncated
+	if _, _, err = DecodeInstructionsSysvar(sysvar[:40]); err == nil {
+		t.Errorf("DecodeInstructionsSysvar with truncated data should fail")
+	}
+}
@@ -42,6 +42,9 @@ type Approve struct {
 	// ··········· M signer accounts.
 	Accounts []*base.AccountMeta `bin:"-" borsh_skip:"true"`
 	Signers  []*base.AccountMeta `bin:"-" borsh_skip:"true"`
+
+	// The token program, base.TokenProgramID when empty
+	TokenProgramID common.Address `bin:"-" borsh_skip:"true"`
 }
 
 func (appr *Approve) SetAccounts(accounts []*base.AccountMeta) error {
@@ -64,6 +67,13 @@ func NewApproveInstructionBuilder() *Approve {
 	return nd
 }
 
+// SetTokenProgramID sets the token program of the instruction,
+// e.g. base.Token2022ProgramID for a Token-2022 mint.
+func (appr *Approve) SetTokenProgramID(tokenProgramID common.Address) *Approve {
+	appr.TokenProgramID = tokenProgramID
+	return appr
+}
+
 // SetAmount sets the "amount" parameter.
 // The amount of tokens the delegate is approved for.
 func (appr *Approve) SetAmount(amount uint64) *Approve {
@@ -116,21 +126,24 @@ func (appr *Approve) GetOwnerAccount() *base.AccountMeta {
 	return appr.Accounts[2]
 }
 
-func (appr Approve) Build() *Instruction {
-	return &Instruction{BaseVariant: encodbin.BaseVariant{
-		Impl:   appr,
-		TypeID: encodbin.TypeIDFromUint8(Instruction_Approve),
-	}}
+func (appr Approve) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{
+		BaseVariant: encodbin.BaseVariant{
+			Impl:   appr,
+			TypeID: encodbin.TypeIDFromUint8(Instruction_Approve),
+		},
+		TokenProgramID: appr.TokenProgramID,
+	}, opts)
 }
 
 // ValidateAndBuild validates the instruction parameters and accounts;
 // if there is a validation error, it returns the error.
 // Otherwise, it builds and returns the instruction.
-func (appr Approve) ValidateAndBuild() (*Instruction, error) {
+func (appr Approve) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
 	if err := appr.Validate(); err != nil {
 		return nil, err
 	}
-	return appr.Build(), nil
+	return appr.Build(opts...), nil
 }
 
 func (appr *Approve) Validate() error {
@@ -144,16 +157,16 @@ func (appr *Approve) Validate() error {
 	// Check whether all (required) accounts are set:
 	{
 		if appr.Accounts[0] == nil {
-			return errors.New("accounts.Source is not set")
+			return &base.MissingAccountError{Account: "Source"}
 		}
 		if appr.Accounts[1] == nil {
-			return errors.New("accounts.Delegate is not set")
+			return &base.MissingAccountError{Account: "Delegate"}
 		}
 		if appr.Accounts[2] == nil {
-			return errors.New("accounts.Owner is not set")
+			return &base.MissingAccountError{Account: "Owner"}
 		}
 		if !appr.Accounts[2].IsSigner && len(appr.Signers) == 0 {
-			return fmt.Errorf("accounts.Signers is not set")
+			return &base.MissingAccountError{Account: "Signers"}
 		}
 		if len(appr.Signers) > MAX_SIGNERS {
 			return fmt.Errorf("too many signers; got %v, but max is 11", len(appr.Signers))
@@ -172,6 +185,9 @@ func (appr Approve) MarshalWithEncoder(encoder *encodbin.Encoder) (err error) {
 }
 
 // NewApproveInstruction declares a new Approve instruction with the provided parameters and accounts.
+//
+// Deprecated: the unchecked approve does not verify the mint decimals,
+// use NewApproveCheckedInstruction unless the program requires the unchecked form.
 func NewApproveInstruction(
 	// Parameters:
 	amount uint64,
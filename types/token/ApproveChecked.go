@@ -52,6 +52,9 @@ type ApproveChecked struct {
 	// ··········· M signer accounts.
 	Accounts []*base.AccountMeta `bin:"-" borsh_skip:"true"`
 	Signers  []*base.AccountMeta `bin:"-" borsh_skip:"true"`
+
+	// The token program, base.TokenProgramID when empty
+	TokenProgramID common.Address `bin:"-" borsh_skip:"true"`
 }
 
 func (apprCkd *ApproveChecked) SetAccounts(accounts []*base.AccountMeta) error {
@@ -74,6 +77,13 @@ func NewApproveCheckedInstructionBuilder() *ApproveChecked {
 	return nd
 }
 
+// SetTokenProgramID sets the token program of the instruction,
+// e.g. base.Token2022ProgramID for a Token-2022 mint.
+func (apprCkd *ApproveChecked) SetTokenProgramID(tokenProgramID common.Address) *ApproveChecked {
+	apprCkd.TokenProgramID = tokenProgramID
+	return apprCkd
+}
+
 // SetAmount sets the "amount" parameter.
 // The amount of tokens the delegate is approved for.
 func (apprCkd *ApproveChecked) SetAmount(amount uint64) *ApproveChecked {
@@ -146,21 +156,24 @@ func (apprCkd *ApproveChecked) GetOwnerAccount() *base.AccountMeta {
 	return apprCkd.Accounts[3]
 }
 
-func (apprCkd ApproveChecked) Build() *Instruction {
-	return &Instruction{BaseVariant: encodbin.BaseVariant{
-		Impl:   apprCkd,
-		TypeID: encodbin.TypeIDFromUint8(Instruction_ApproveChecked),
-	}}
+func (apprCkd ApproveChecked) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{
+		BaseVariant: encodbin.BaseVariant{
+			Impl:   apprCkd,
+			TypeID: encodbin.TypeIDFromUint8(Instruction_ApproveChecked),
+		},
+		TokenProgramID: apprCkd.TokenProgramID,
+	}, opts)
 }
 
 // ValidateAndBuild validates the instruction parameters and accounts;
 // if there is a validation error, it returns the error.
 // Otherwise, it builds and returns the instruction.
-func (apprCkd ApproveChecked) ValidateAndBuild() (*Instruction, error) {
+func (apprCkd ApproveChecked) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
 	if err := apprCkd.Validate(); err != nil {
 		return nil, err
 	}
-	return apprCkd.Build(), nil
+	return apprCkd.Build(opts...), nil
 }
 
 func (apprCkd *ApproveChecked) Validate() error {
@@ -177,19 +190,19 @@ func (apprCkd *ApproveChecked) Validate() error {
 	// Check whether all (required) accounts are set:
 	{
 		if apprCkd.Accounts[0] == nil {
-			return errors.New("accounts.Source is not set")
+			return &base.MissingAccountError{Account: "Source"}
 		}
 		if apprCkd.Accounts[1] == nil {
-			return errors.New("accounts.Mint is not set")
+			return &base.MissingAccountError{Account: "Mint"}
 		}
 		if apprCkd.Accounts[2] == nil {
-			return errors.New("accounts.Delegate is not set")
+			return &base.MissingAccountError{Account: "Delegate"}
 		}
 		if apprCkd.Accounts[3] == nil {
-			return errors.New("accounts.Owner is not set")
+			return &base.MissingAccountError{Account: "Owner"}
 		}
 		if !apprCkd.Accounts[3].IsSigner && len(apprCkd.Signers) == 0 {
-			return fmt.Errorf("accounts.Signers is not set")
+			return &base.MissingAccountError{Account: "Signers"}
 		}
 		if len(apprCkd.Signers) > MAX_SIGNERS {
 			return fmt.Errorf("too many signers; got %v, but max is 11", len(apprCkd.Signers))
@@ -42,6 +42,9 @@ type Burn struct {
 	// ··········· M signer accounts.
 	Accounts []*base.AccountMeta `bin:"-" borsh_skip:"true"`
 	Signers  []*base.AccountMeta `bin:"-" borsh_skip:"true"`
+
+	// The token program, base.TokenProgramID when empty
+	TokenProgramID common.Address `bin:"-" borsh_skip:"true"`
 }
 
 func (br *Burn) SetAccounts(accounts []*base.AccountMeta) error {
@@ -64,6 +67,13 @@ func NewBurnInstructionBuilder() *Burn {
 	return nd
 }
 
+// SetTokenProgramID sets the token program of the instruction,
+// e.g. base.Token2022ProgramID for a Token-2022 mint.
+func (br *Burn) SetTokenProgramID(tokenProgramID common.Address) *Burn {
+	br.TokenProgramID = tokenProgramID
+	return br
+}
+
 // SetAmount sets the "amount" parameter.
 // The amount of tokens to burn.
 func (br *Burn) SetAmount(amount uint64) *Burn {
@@ -116,21 +126,24 @@ func (br *Burn) GetOwnerAccount() *base.AccountMeta {
 	return br.Accounts[2]
 }
 
-func (br Burn) Build() *Instruction {
-	return &Instruction{BaseVariant: encodbin.BaseVariant{
-		Impl:   br,
-		TypeID: encodbin.TypeIDFromUint8(Instruction_Burn),
-	}}
+func (br Burn) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{
+		BaseVariant: encodbin.BaseVariant{
+			Impl:   br,
+			TypeID: encodbin.TypeIDFromUint8(Instruction_Burn),
+		},
+		TokenProgramID: br.TokenProgramID,
+	}, opts)
 }
 
 // ValidateAndBuild validates the instruction parameters and accounts;
 // if there is a validation error, it returns the error.
 // Otherwise, it builds and returns the instruction.
-func (br Burn) ValidateAndBuild() (*Instruction, error) {
+func (br Burn) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
 	if err := br.Validate(); err != nil {
 		return nil, err
 	}
-	return br.Build(), nil
+	return br.Build(opts...), nil
 }
 
 func (br *Burn) Validate() error {
@@ -144,16 +157,16 @@ func (br *Burn) Validate() error {
 	// Check whether all (required) accounts are set:
 	{
 		if br.Accounts[0] == nil {
-			return errors.New("accounts.Source is not set")
+			return &base.MissingAccountError{Account: "Source"}
 		}
 		if br.Accounts[1] == nil {
-			return errors.New("accounts.Mint is not set")
+			return &base.MissingAccountError{Account: "Mint"}
 		}
 		if br.Accounts[2] == nil {
-			return errors.New("accounts.Owner is not set")
+			return &base.MissingAccountError{Account: "Owner"}
 		}
 		if !br.Accounts[2].IsSigner && len(br.Signers) == 0 {
-			return fmt.Errorf("accounts.Signers is not set")
+			return &base.MissingAccountError{Account: "Signers"}
 		}
 		if len(br.Signers) > MAX_SIGNERS {
 			return fmt.Errorf("too many signers; got %v, but max is 11", len(br.Signers))
@@ -50,6 +50,9 @@ type BurnChecked struct {
 	// ··········· M signer accounts.
 	Accounts []*base.AccountMeta `bin:"-" borsh_skip:"true"`
 	Signers  []*base.AccountMeta `bin:"-" borsh_skip:"true"`
+
+	// The token program, base.TokenProgramID when empty
+	TokenProgramID common.Address `bin:"-" borsh_skip:"true"`
 }
 
 func (brCkd *BurnChecked) SetAccounts(accounts []*base.AccountMeta) error {
@@ -72,6 +75,13 @@ func NewBurnCheckedInstructionBuilder() *BurnChecked {
 	return nd
 }
 
+// SetTokenProgramID sets the token program of the instruction,
+// e.g. base.Token2022ProgramID for a Token-2022 mint.
+func (brCkd *BurnChecked) SetTokenProgramID(tokenProgramID common.Address) *BurnChecked {
+	brCkd.TokenProgramID = tokenProgramID
+	return brCkd
+}
+
 // SetAmount sets the "amount" parameter.
 // The amount of tokens to burn.
 func (brCkd *BurnChecked) SetAmount(amount uint64) *BurnChecked {
@@ -131,21 +141,24 @@ func (brCkd *BurnChecked) GetOwnerAccount() *base.AccountMeta {
 	return brCkd.Accounts[2]
 }
 
-func (brCkd BurnChecked) Build() *Instruction {
-	return &Instruction{BaseVariant: encodbin.BaseVariant{
-		Impl:   brCkd,
-		TypeID: encodbin.TypeIDFromUint8(Instruction_BurnChecked),
-	}}
+func (brCkd BurnChecked) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{
+		BaseVariant: encodbin.BaseVariant{
+			Impl:   brCkd,
+			TypeID: encodbin.TypeIDFromUint8(Instruction_BurnChecked),
+		},
+		TokenProgramID: brCkd.TokenProgramID,
+	}, opts)
 }
 
 // ValidateAndBuild validates the instruction parameters and accounts;
 // if there is a validation error, it returns the error.
 // Otherwise, it builds and returns the instruction.
-func (brCkd BurnChecked) ValidateAndBuild() (*Instruction, error) {
+func (brCkd BurnChecked) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
 	if err := brCkd.Validate(); err != nil {
 		return nil, err
 	}
-	return brCkd.Build(), nil
+	return brCkd.Build(opts...), nil
 }
 
 func (brCkd *BurnChecked) Validate() error {
@@ -162,16 +175,16 @@ func (brCkd *BurnChecked) Validate() error {
 	// Check whether all (required) accounts are set:
 	{
 		if brCkd.Accounts[0] == nil {
-			return errors.New("accounts.Source is not set")
+			return &base.MissingAccountError{Account: "Source"}
 		}
 		if brCkd.Accounts[1] == nil {
-			return errors.New("accounts.Mint is not set")
+			return &base.MissingAccountError{Account: "Mint"}
 		}
 		if brCkd.Accounts[2] == nil {
-			return errors.New("accounts.Owner is not set")
+			return &base.MissingAccountError{Account: "Owner"}
 		}
 		if !brCkd.Accounts[2].IsSigner && len(brCkd.Signers) == 0 {
-			return fmt.Errorf("accounts.Signers is not set")
+			return &base.MissingAccountError{Account: "Signers"}
 		}
 		if len(brCkd.Signers) > MAX_SIGNERS {
 			return fmt.Errorf("too many signers; got %v, but max is 11", len(brCkd.Signers))
@@ -15,7 +15,6 @@
 package token
 
 import (
-	"errors"
 	"fmt"
 	"github.com/cielu/go-solana/common"
 	"github.com/cielu/go-solana/core"
@@ -40,6 +39,9 @@ type CloseAccount struct {
 	// ··········· M signer accounts.
 	Accounts []*base.AccountMeta `bin:"-" borsh_skip:"true"`
 	Signers  []*base.AccountMeta `bin:"-" borsh_skip:"true"`
+
+	// The token program, base.TokenProgramID when empty
+	TokenProgramID common.Address `bin:"-" borsh_skip:"true"`
 }
 
 func (cloAcc *CloseAccount) SetAccounts(accounts []*base.AccountMeta) error {
@@ -62,6 +64,13 @@ func NewCloseAccountInstructionBuilder() *CloseAccount {
 	return nd
 }
 
+// SetTokenProgramID sets the token program of the instruction,
+// e.g. base.Token2022ProgramID for a Token-2022 mint.
+func (cloAcc *CloseAccount) SetTokenProgramID(tokenProgramID common.Address) *CloseAccount {
+	cloAcc.TokenProgramID = tokenProgramID
+	return cloAcc
+}
+
 // SetAccount sets the "account" account.
 // The account to close.
 func (cloAcc *CloseAccount) SetAccount(account common.Address) *CloseAccount {
@@ -107,37 +116,40 @@ func (cloAcc *CloseAccount) GetOwnerAccount() *base.AccountMeta {
 	return cloAcc.Accounts[2]
 }
 
-func (cloAcc CloseAccount) Build() *Instruction {
-	return &Instruction{BaseVariant: encodbin.BaseVariant{
-		Impl:   cloAcc,
-		TypeID: encodbin.TypeIDFromUint8(Instruction_CloseAccount),
-	}}
+func (cloAcc CloseAccount) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{
+		BaseVariant: encodbin.BaseVariant{
+			Impl:   cloAcc,
+			TypeID: encodbin.TypeIDFromUint8(Instruction_CloseAccount),
+		},
+		TokenProgramID: cloAcc.TokenProgramID,
+	}, opts)
 }
 
 // ValidateAndBuild validates the instruction parameters and accounts;
 // if there is a validation error, it returns the error.
 // Otherwise, it builds and returns the instruction.
-func (cloAcc CloseAccount) ValidateAndBuild() (*Instruction, error) {
+func (cloAcc CloseAccount) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
 	if err := cloAcc.Validate(); err != nil {
 		return nil, err
 	}
-	return cloAcc.Build(), nil
+	return cloAcc.Build(opts...), nil
 }
 
 func (cloAcc *CloseAccount) Validate() error {
 	// Check whether all (required) accounts are set:
 	{
 		if cloAcc.Accounts[0] == nil {
-			return errors.New("accounts.Account is not set")
+			return &base.MissingAccountError{Account: "Account"}
 		}
 		if cloAcc.Accounts[1] == nil {
-			return errors.New("accounts.Destination is not set")
+			return &base.MissingAccountError{Account: "Destination"}
 		}
 		if cloAcc.Accounts[2] == nil {
-			return errors.New("accounts.Owner is not set")
+			return &base.MissingAccountError{Account: "Owner"}
 		}
 		if !cloAcc.Accounts[2].IsSigner && len(cloAcc.Signers) == 0 {
-			return fmt.Errorf("accounts.Signers is not set")
+			return &base.MissingAccountError{Account: "Signers"}
 		}
 		if len(cloAcc.Signers) > MAX_SIGNERS {
 			return fmt.Errorf("too many signers; got %v, but max is 11", len(cloAcc.Signers))
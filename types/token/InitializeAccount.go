@@ -15,7 +15,6 @@
 package token
 
 import (
-	"errors"
 	"github.com/cielu/go-solana/common"
 	"github.com/cielu/go-solana/pkg/encodbin"
 	"github.com/cielu/go-solana/types/base"
@@ -46,6 +45,9 @@ type InitializeAccount struct {
 	// [3] = [] $(SysVarRentPubkey)
 	// ··········· Rent sysvar.
 	base.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+
+	// The token program, base.TokenProgramID when empty
+	TokenProgramID common.Address `bin:"-" borsh_skip:"true"`
 }
 
 // NewInitializeAccountInstructionBuilder creates a new `InitializeAccount` instruction builder.
@@ -57,6 +59,13 @@ func NewInitializeAccountInstructionBuilder() *InitializeAccount {
 	return nd
 }
 
+// SetTokenProgramID sets the token program of the instruction,
+// e.g. base.Token2022ProgramID for a Token-2022 mint.
+func (initAcc *InitializeAccount) SetTokenProgramID(tokenProgramID common.Address) *InitializeAccount {
+	initAcc.TokenProgramID = tokenProgramID
+	return initAcc
+}
+
 // SetAccount sets the "account" account.
 // The account to initialize.
 func (initAcc *InitializeAccount) SetAccount(account common.Address) *InitializeAccount {
@@ -109,37 +118,40 @@ func (initAcc *InitializeAccount) GetSysVarRentPubkeyAccount() *base.AccountMeta
 	return initAcc.AccountMetaSlice[3]
 }
 
-func (initAcc InitializeAccount) Build() *Instruction {
-	return &Instruction{BaseVariant: encodbin.BaseVariant{
-		Impl:   initAcc,
-		TypeID: encodbin.TypeIDFromUint8(Instruction_InitializeAccount),
-	}}
+func (initAcc InitializeAccount) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{
+		BaseVariant: encodbin.BaseVariant{
+			Impl:   initAcc,
+			TypeID: encodbin.TypeIDFromUint8(Instruction_InitializeAccount),
+		},
+		TokenProgramID: initAcc.TokenProgramID,
+	}, opts)
 }
 
 // ValidateAndBuild validates the instruction parameters and accounts;
 // if there is a validation error, it returns the error.
 // Otherwise, it builds and returns the instruction.
-func (initAcc InitializeAccount) ValidateAndBuild() (*Instruction, error) {
+func (initAcc InitializeAccount) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
 	if err := initAcc.Validate(); err != nil {
 		return nil, err
 	}
-	return initAcc.Build(), nil
+	return initAcc.Build(opts...), nil
 }
 
 func (initAcc *InitializeAccount) Validate() error {
 	// Check whether all (required) accounts are set:
 	{
 		if initAcc.AccountMetaSlice[0] == nil {
-			return errors.New("accounts.Account is not set")
+			return &base.MissingAccountError{Account: "Account"}
 		}
 		if initAcc.AccountMetaSlice[1] == nil {
-			return errors.New("accounts.Mint is not set")
+			return &base.MissingAccountError{Account: "Mint"}
 		}
 		if initAcc.AccountMetaSlice[2] == nil {
-			return errors.New("accounts.Owner is not set")
+			return &base.MissingAccountError{Account: "Owner"}
 		}
 		if initAcc.AccountMetaSlice[3] == nil {
-			return errors.New("accounts.SysVarRentPubkey is not set")
+			return &base.MissingAccountError{Account: "SysVarRentPubkey"}
 		}
 	}
 	return nil
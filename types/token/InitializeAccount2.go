@@ -38,6 +38,9 @@ type InitializeAccount2 struct {
 	// [2] = [] $(SysVarRentPubkey)
 	// ··········· Rent sysvar.
 	base.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+
+	// The token program, base.TokenProgramID when empty
+	TokenProgramID common.Address `bin:"-" borsh_skip:"true"`
 }
 
 // NewInitializeAccount2InstructionBuilder creates a new `InitializeAccount2` instruction builder.
@@ -49,6 +52,13 @@ func NewInitializeAccount2InstructionBuilder() *InitializeAccount2 {
 	return nd
 }
 
+// SetTokenProgramID sets the token program of the instruction,
+// e.g. base.Token2022ProgramID for a Token-2022 mint.
+func (initAcc2 *InitializeAccount2) SetTokenProgramID(tokenProgramID common.Address) *InitializeAccount2 {
+	initAcc2.TokenProgramID = tokenProgramID
+	return initAcc2
+}
+
 // SetOwner sets the "owner" parameter.
 // The new account's owner/multisignature.
 func (initAcc2 *InitializeAccount2) SetOwner(owner common.Address) *InitializeAccount2 {
@@ -95,21 +105,24 @@ func (initAcc2 *InitializeAccount2) GetSysVarRentPubkeyAccount() *base.AccountMe
 	return initAcc2.AccountMetaSlice[2]
 }
 
-func (initAcc2 InitializeAccount2) Build() *Instruction {
-	return &Instruction{BaseVariant: encodbin.BaseVariant{
-		Impl:   initAcc2,
-		TypeID: encodbin.TypeIDFromUint8(Instruction_InitializeAccount2),
-	}}
+func (initAcc2 InitializeAccount2) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{
+		BaseVariant: encodbin.BaseVariant{
+			Impl:   initAcc2,
+			TypeID: encodbin.TypeIDFromUint8(Instruction_InitializeAccount2),
+		},
+		TokenProgramID: initAcc2.TokenProgramID,
+	}, opts)
 }
 
 // ValidateAndBuild validates the instruction parameters and accounts;
 // if there is a validation error, it returns the error.
 // Otherwise, it builds and returns the instruction.
-func (initAcc2 InitializeAccount2) ValidateAndBuild() (*Instruction, error) {
+func (initAcc2 InitializeAccount2) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
 	if err := initAcc2.Validate(); err != nil {
 		return nil, err
 	}
-	return initAcc2.Build(), nil
+	return initAcc2.Build(opts...), nil
 }
 
 func (initAcc2 *InitializeAccount2) Validate() error {
@@ -123,13 +136,13 @@ func (initAcc2 *InitializeAccount2) Validate() error {
 	// Check whether all (required) accounts are set:
 	{
 		if initAcc2.AccountMetaSlice[0] == nil {
-			return errors.New("accounts.Account is not set")
+			return &base.MissingAccountError{Account: "Account"}
 		}
 		if initAcc2.AccountMetaSlice[1] == nil {
-			return errors.New("accounts.Mint is not set")
+			return &base.MissingAccountError{Account: "Mint"}
 		}
 		if initAcc2.AccountMetaSlice[2] == nil {
-			return errors.New("accounts.SysVarRentPubkey is not set")
+			return &base.MissingAccountError{Account: "SysVarRentPubkey"}
 		}
 	}
 	return nil
@@ -32,6 +32,9 @@ type InitializeAccount3 struct {
 	// [1] = [] mint
 	// ··········· The mint this account will be associated with.
 	base.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+
+	// The token program, base.TokenProgramID when empty
+	TokenProgramID common.Address `bin:"-" borsh_skip:"true"`
 }
 
 // NewInitializeAccount3InstructionBuilder creates a new `InitializeAccount3` instruction builder.
@@ -42,6 +45,13 @@ func NewInitializeAccount3InstructionBuilder() *InitializeAccount3 {
 	return nd
 }
 
+// SetTokenProgramID sets the token program of the instruction,
+// e.g. base.Token2022ProgramID for a Token-2022 mint.
+func (initAcc3 *InitializeAccount3) SetTokenProgramID(tokenProgramID common.Address) *InitializeAccount3 {
+	initAcc3.TokenProgramID = tokenProgramID
+	return initAcc3
+}
+
 // SetOwner sets the "owner" parameter.
 // The new account's owner/multisignature.
 func (initAcc3 *InitializeAccount3) SetOwner(owner common.Address) *InitializeAccount3 {
@@ -75,21 +85,24 @@ func (initAcc3 *InitializeAccount3) GetMintAccount() *base.AccountMeta {
 	return initAcc3.AccountMetaSlice[1]
 }
 
-func (initAcc3 InitializeAccount3) Build() *Instruction {
-	return &Instruction{BaseVariant: encodbin.BaseVariant{
-		Impl:   initAcc3,
-		TypeID: encodbin.TypeIDFromUint8(Instruction_InitializeAccount3),
-	}}
+func (initAcc3 InitializeAccount3) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{
+		BaseVariant: encodbin.BaseVariant{
+			Impl:   initAcc3,
+			TypeID: encodbin.TypeIDFromUint8(Instruction_InitializeAccount3),
+		},
+		TokenProgramID: initAcc3.TokenProgramID,
+	}, opts)
 }
 
 // ValidateAndBuild validates the instruction parameters and accounts;
 // if there is a validation error, it returns the error.
 // Otherwise, it builds and returns the instruction.
-func (initAcc3 InitializeAccount3) ValidateAndBuild() (*Instruction, error) {
+func (initAcc3 InitializeAccount3) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
 	if err := initAcc3.Validate(); err != nil {
 		return nil, err
 	}
-	return initAcc3.Build(), nil
+	return initAcc3.Build(opts...), nil
 }
 
 func (initAcc3 *InitializeAccount3) Validate() error {
@@ -103,10 +116,10 @@ func (initAcc3 *InitializeAccount3) Validate() error {
 	// Check whether all (required) accounts are set:
 	{
 		if initAcc3.AccountMetaSlice[0] == nil {
-			return errors.New("accounts.Account is not set")
+			return &base.MissingAccountError{Account: "Account"}
 		}
 		if initAcc3.AccountMetaSlice[1] == nil {
-			return errors.New("accounts.Mint is not set")
+			return &base.MissingAccountError{Account: "Mint"}
 		}
 	}
 	return nil
@@ -37,7 +37,7 @@ type InitializeMint struct {
 	MintAuthority *common.Address
 
 	// The freeze authority/multisignature of the mint.
-	FreezeAuthority *common.Address `bin:"optional"`
+	FreezeAuthority *common.Address `bin:"optional=borsh"`
 
 	// [0] = [WRITE] mint
 	// ··········· The mint to initialize.
@@ -45,6 +45,9 @@ type InitializeMint struct {
 	// [1] = [] $(SysVarRentPubkey)
 	// ··········· Rent sysvar.
 	base.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+
+	// The token program, base.TokenProgramID when empty
+	TokenProgramID common.Address `bin:"-" borsh_skip:"true"`
 }
 
 // NewInitializeMintInstructionBuilder creates a new `InitializeMint` instruction builder.
@@ -56,6 +59,13 @@ func NewInitializeMintInstructionBuilder() *InitializeMint {
 	return nd
 }
 
+// SetTokenProgramID sets the token program of the instruction,
+// e.g. base.Token2022ProgramID for a Token-2022 mint.
+func (initMint *InitializeMint) SetTokenProgramID(tokenProgramID common.Address) *InitializeMint {
+	initMint.TokenProgramID = tokenProgramID
+	return initMint
+}
+
 // SetDecimals sets the "decimals" parameter.
 // Number of base 10 digits to the right of the decimal place.
 func (initMint *InitializeMint) SetDecimals(decimals uint8) *InitializeMint {
@@ -103,21 +113,24 @@ func (initMint *InitializeMint) GetSysVarRentPubkeyAccount() *base.AccountMeta {
 	return initMint.AccountMetaSlice[1]
 }
 
-func (initMint InitializeMint) Build() *Instruction {
-	return &Instruction{BaseVariant: encodbin.BaseVariant{
-		Impl:   initMint,
-		TypeID: encodbin.TypeIDFromUint8(Instruction_InitializeMint),
-	}}
+func (initMint InitializeMint) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{
+		BaseVariant: encodbin.BaseVariant{
+			Impl:   initMint,
+			TypeID: encodbin.TypeIDFromUint8(Instruction_InitializeMint),
+		},
+		TokenProgramID: initMint.TokenProgramID,
+	}, opts)
 }
 
 // ValidateAndBuild validates the instruction parameters and accounts;
 // if there is a validation error, it returns the error.
 // Otherwise, it builds and returns the instruction.
-func (initMint InitializeMint) ValidateAndBuild() (*Instruction, error) {
+func (initMint InitializeMint) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
 	if err := initMint.Validate(); err != nil {
 		return nil, err
 	}
-	return initMint.Build(), nil
+	return initMint.Build(opts...), nil
 }
 
 func (initMint *InitializeMint) Validate() error {
@@ -134,10 +147,10 @@ func (initMint *InitializeMint) Validate() error {
 	// Check whether all (required) accounts are set:
 	{
 		if initMint.AccountMetaSlice[0] == nil {
-			return errors.New("accounts.Mint is not set")
+			return &base.MissingAccountError{Account: "Mint"}
 		}
 		if initMint.AccountMetaSlice[1] == nil {
-			return errors.New("accounts.SysVarRentPubkey is not set")
+			return &base.MissingAccountError{Account: "SysVarRentPubkey"}
 		}
 	}
 	return nil
@@ -157,12 +170,12 @@ func (initMint InitializeMint) MarshalWithEncoder(encoder *encodbin.Encoder) (er
 	// Serialize `FreezeAuthority` param (optional):
 	{
 		if initMint.FreezeAuthority == nil {
-			err = encoder.WriteBool(false)
+			err = encoder.WriteOption(false, encodbin.OptionKindBorsh)
 			if err != nil {
 				return err
 			}
 		} else {
-			err = encoder.WriteBool(true)
+			err = encoder.WriteOption(true, encodbin.OptionKindBorsh)
 			if err != nil {
 				return err
 			}
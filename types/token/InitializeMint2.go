@@ -30,11 +30,14 @@ type InitializeMint2 struct {
 	MintAuthority *common.Address
 
 	// The freeze authority/multisignature of the mint.
-	FreezeAuthority *common.Address `bin:"optional"`
+	FreezeAuthority *common.Address `bin:"optional=borsh"`
 
 	// [0] = [WRITE] mint
 	// ··········· The mint to initialize.
 	base.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+
+	// The token program, base.TokenProgramID when empty
+	TokenProgramID common.Address `bin:"-" borsh_skip:"true"`
 }
 
 // NewInitializeMint2InstructionBuilder creates a new `InitializeMint2` instruction builder.
@@ -45,6 +48,13 @@ func NewInitializeMint2InstructionBuilder() *InitializeMint2 {
 	return nd
 }
 
+// SetTokenProgramID sets the token program of the instruction,
+// e.g. base.Token2022ProgramID for a Token-2022 mint.
+func (initMint *InitializeMint2) SetTokenProgramID(tokenProgramID common.Address) *InitializeMint2 {
+	initMint.TokenProgramID = tokenProgramID
+	return initMint
+}
+
 // SetDecimals sets the "decimals" parameter.
 // Number of base 10 digits to the right of the decimal place.
 func (initMint *InitializeMint2) SetDecimals(decimals uint8) *InitializeMint2 {
@@ -79,21 +89,24 @@ func (initMint *InitializeMint2) GetMintAccount() *base.AccountMeta {
 	return initMint.AccountMetaSlice[0]
 }
 
-func (initMint InitializeMint2) Build() *Instruction {
-	return &Instruction{BaseVariant: encodbin.BaseVariant{
-		Impl:   initMint,
-		TypeID: encodbin.TypeIDFromUint8(Instruction_InitializeMint2),
-	}}
+func (initMint InitializeMint2) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{
+		BaseVariant: encodbin.BaseVariant{
+			Impl:   initMint,
+			TypeID: encodbin.TypeIDFromUint8(Instruction_InitializeMint2),
+		},
+		TokenProgramID: initMint.TokenProgramID,
+	}, opts)
 }
 
 // ValidateAndBuild validates the instruction parameters and accounts;
 // if there is a validation error, it returns the error.
 // Otherwise, it builds and returns the instruction.
-func (initMint InitializeMint2) ValidateAndBuild() (*Instruction, error) {
+func (initMint InitializeMint2) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
 	if err := initMint.Validate(); err != nil {
 		return nil, err
 	}
-	return initMint.Build(), nil
+	return initMint.Build(opts...), nil
 }
 
 func (initMint *InitializeMint2) Validate() error {
@@ -110,7 +123,7 @@ func (initMint *InitializeMint2) Validate() error {
 	// Check whether all (required) accounts are set:
 	{
 		if initMint.AccountMetaSlice[0] == nil {
-			return errors.New("accounts.Mint is not set")
+			return &base.MissingAccountError{Account: "Mint"}
 		}
 	}
 	return nil
@@ -130,12 +143,12 @@ func (initMint InitializeMint2) MarshalWithEncoder(encoder *encodbin.Encoder) (e
 	// Serialize `FreezeAuthority` param (optional):
 	{
 		if initMint.FreezeAuthority == nil {
-			err = encoder.WriteBool(false)
+			err = encoder.WriteOption(false, encodbin.OptionKindBorsh)
 			if err != nil {
 				return err
 			}
 		} else {
-			err = encoder.WriteBool(true)
+			err = encoder.WriteOption(true, encodbin.OptionKindBorsh)
 			if err != nil {
 				return err
 			}
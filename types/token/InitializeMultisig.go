@@ -50,6 +50,9 @@ type InitializeMultisig struct {
 	// ··········· ..2+N The signer accounts, must equal to N where 1 <= N <=11
 	Accounts []*base.AccountMeta `bin:"-" borsh_skip:"true"`
 	Signers  []*base.AccountMeta `bin:"-" borsh_skip:"true"`
+
+	// The token program, base.TokenProgramID when empty
+	TokenProgramID common.Address `bin:"-" borsh_skip:"true"`
 }
 
 func (initMs *InitializeMultisig) SetAccounts(accounts []*base.AccountMeta) error {
@@ -73,6 +76,13 @@ func NewInitializeMultisigInstructionBuilder() *InitializeMultisig {
 	return nd
 }
 
+// SetTokenProgramID sets the token program of the instruction,
+// e.g. base.Token2022ProgramID for a Token-2022 mint.
+func (initMs *InitializeMultisig) SetTokenProgramID(tokenProgramID common.Address) *InitializeMultisig {
+	initMs.TokenProgramID = tokenProgramID
+	return initMs
+}
+
 // SetM sets the "m" parameter.
 // The number of signers (M) required to validate this multisignature
 // account.
@@ -116,21 +126,24 @@ func (initMs *InitializeMultisig) AddSigners(signers ...common.Address) *Initial
 	return initMs
 }
 
-func (initMs InitializeMultisig) Build() *Instruction {
-	return &Instruction{BaseVariant: encodbin.BaseVariant{
-		Impl:   initMs,
-		TypeID: encodbin.TypeIDFromUint8(Instruction_InitializeMultisig),
-	}}
+func (initMs InitializeMultisig) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{
+		BaseVariant: encodbin.BaseVariant{
+			Impl:   initMs,
+			TypeID: encodbin.TypeIDFromUint8(Instruction_InitializeMultisig),
+		},
+		TokenProgramID: initMs.TokenProgramID,
+	}, opts)
 }
 
 // ValidateAndBuild validates the instruction parameters and accounts;
 // if there is a validation error, it returns the error.
 // Otherwise, it builds and returns the instruction.
-func (initMs InitializeMultisig) ValidateAndBuild() (*Instruction, error) {
+func (initMs InitializeMultisig) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
 	if err := initMs.Validate(); err != nil {
 		return nil, err
 	}
-	return initMs.Build(), nil
+	return initMs.Build(opts...), nil
 }
 
 func (initMs *InitializeMultisig) Validate() error {
@@ -144,13 +157,13 @@ func (initMs *InitializeMultisig) Validate() error {
 	// Check whether all (required) accounts are set:
 	{
 		if initMs.Accounts[0] == nil {
-			return fmt.Errorf("accounts.Account is not set")
+			return &base.MissingAccountError{Account: "Account"}
 		}
 		if initMs.Accounts[1] == nil {
-			return fmt.Errorf("accounts.SysVarRentPubkey is not set")
+			return &base.MissingAccountError{Account: "SysVarRentPubkey"}
 		}
 		if len(initMs.Signers) == 0 {
-			return fmt.Errorf("accounts.Signers is not set")
+			return &base.MissingAccountError{Account: "Signers"}
 		}
 		if len(initMs.Signers) > MAX_SIGNERS {
 			return fmt.Errorf("too many signers; got %v, but max is 11", len(initMs.Signers))
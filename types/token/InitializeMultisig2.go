@@ -35,6 +35,9 @@ type InitializeMultisig2 struct {
 	// ··········· The signer accounts, must equal to N where 1 <= N <= 11.
 	Accounts []*base.AccountMeta `bin:"-" borsh_skip:"true"`
 	Signers  []*base.AccountMeta `bin:"-" borsh_skip:"true"`
+
+	// The token program, base.TokenProgramID when empty
+	TokenProgramID common.Address `bin:"-" borsh_skip:"true"`
 }
 
 func (initMs *InitializeMultisig2) SetAccounts(accounts []*base.AccountMeta) error {
@@ -57,6 +60,13 @@ func NewInitializeMultisig2InstructionBuilder() *InitializeMultisig2 {
 	return nd
 }
 
+// SetTokenProgramID sets the token program of the instruction,
+// e.g. base.Token2022ProgramID for a Token-2022 mint.
+func (initMs *InitializeMultisig2) SetTokenProgramID(tokenProgramID common.Address) *InitializeMultisig2 {
+	initMs.TokenProgramID = tokenProgramID
+	return initMs
+}
+
 // SetM sets the "m" parameter.
 // The number of signers (M) required to validate this multisignature account.
 func (initMs *InitializeMultisig2) SetM(m uint8) *InitializeMultisig2 {
@@ -86,21 +96,24 @@ func (initMs *InitializeMultisig2) AddSigners(signers ...common.Address) *Initia
 	return initMs
 }
 
-func (initMs InitializeMultisig2) Build() *Instruction {
-	return &Instruction{BaseVariant: encodbin.BaseVariant{
-		Impl:   initMs,
-		TypeID: encodbin.TypeIDFromUint8(Instruction_InitializeMultisig2),
-	}}
+func (initMs InitializeMultisig2) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{
+		BaseVariant: encodbin.BaseVariant{
+			Impl:   initMs,
+			TypeID: encodbin.TypeIDFromUint8(Instruction_InitializeMultisig2),
+		},
+		TokenProgramID: initMs.TokenProgramID,
+	}, opts)
 }
 
 // ValidateAndBuild validates the instruction parameters and accounts;
 // if there is a validation error, it returns the error.
 // Otherwise, it builds and returns the instruction.
-func (initMs InitializeMultisig2) ValidateAndBuild() (*Instruction, error) {
+func (initMs InitializeMultisig2) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
 	if err := initMs.Validate(); err != nil {
 		return nil, err
 	}
-	return initMs.Build(), nil
+	return initMs.Build(opts...), nil
 }
 
 func (initMs *InitializeMultisig2) Validate() error {
@@ -114,10 +127,10 @@ func (initMs *InitializeMultisig2) Validate() error {
 	// Check whether all (required) accounts are set:
 	{
 		if initMs.Accounts[0] == nil {
-			return errors.New("accounts.Account is not set")
+			return &base.MissingAccountError{Account: "Account"}
 		}
 		if len(initMs.Signers) == 0 {
-			return fmt.Errorf("accounts.Signers is not set")
+			return &base.MissingAccountError{Account: "Signers"}
 		}
 		if len(initMs.Signers) > MAX_SIGNERS {
 			return fmt.Errorf("too many signers; got %v, but max is 11", len(initMs.Signers))
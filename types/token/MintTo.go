@@ -42,6 +42,9 @@ type MintTo struct {
 	// ··········· M signer accounts.
 	Accounts []*base.AccountMeta `bin:"-" borsh_skip:"true"`
 	Signers  []*base.AccountMeta `bin:"-" borsh_skip:"true"`
+
+	// The token program, base.TokenProgramID when empty
+	TokenProgramID common.Address `bin:"-" borsh_skip:"true"`
 }
 
 func (mto *MintTo) SetAccounts(accounts []*base.AccountMeta) error {
@@ -64,6 +67,13 @@ func NewMintToInstructionBuilder() *MintTo {
 	return nd
 }
 
+// SetTokenProgramID sets the token program of the instruction,
+// e.g. base.Token2022ProgramID for a Token-2022 mint.
+func (mto *MintTo) SetTokenProgramID(tokenProgramID common.Address) *MintTo {
+	mto.TokenProgramID = tokenProgramID
+	return mto
+}
+
 // SetAmount sets the "amount" parameter.
 // The amount of new tokens to mint.
 func (mto *MintTo) SetAmount(amount uint64) *MintTo {
@@ -116,21 +126,24 @@ func (mto *MintTo) GetAuthorityAccount() *base.AccountMeta {
 	return mto.Accounts[2]
 }
 
-func (mto MintTo) Build() *Instruction {
-	return &Instruction{BaseVariant: encodbin.BaseVariant{
-		Impl:   mto,
-		TypeID: encodbin.TypeIDFromUint8(Instruction_MintTo),
-	}}
+func (mto MintTo) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{
+		BaseVariant: encodbin.BaseVariant{
+			Impl:   mto,
+			TypeID: encodbin.TypeIDFromUint8(Instruction_MintTo),
+		},
+		TokenProgramID: mto.TokenProgramID,
+	}, opts)
 }
 
 // ValidateAndBuild validates the instruction parameters and accounts;
 // if there is a validation error, it returns the error.
 // Otherwise, it builds and returns the instruction.
-func (mto MintTo) ValidateAndBuild() (*Instruction, error) {
+func (mto MintTo) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
 	if err := mto.Validate(); err != nil {
 		return nil, err
 	}
-	return mto.Build(), nil
+	return mto.Build(opts...), nil
 }
 
 func (mto *MintTo) Validate() error {
@@ -144,16 +157,16 @@ func (mto *MintTo) Validate() error {
 	// Check whether all (required) accounts are set:
 	{
 		if mto.Accounts[0] == nil {
-			return errors.New("accounts.Mint is not set")
+			return &base.MissingAccountError{Account: "Mint"}
 		}
 		if mto.Accounts[1] == nil {
-			return errors.New("accounts.Destination is not set")
+			return &base.MissingAccountError{Account: "Destination"}
 		}
 		if mto.Accounts[2] == nil {
-			return errors.New("accounts.Authority is not set")
+			return &base.MissingAccountError{Account: "Authority"}
 		}
 		if !mto.Accounts[2].IsSigner && len(mto.Signers) == 0 {
-			return fmt.Errorf("accounts.Signers is not set")
+			return &base.MissingAccountError{Account: "Signers"}
 		}
 		if len(mto.Signers) > MAX_SIGNERS {
 			return fmt.Errorf("too many signers; got %v, but max is 11", len(mto.Signers))
@@ -48,6 +48,9 @@ type MintToChecked struct {
 	// ··········· M signer accounts.
 	Accounts []*base.AccountMeta `bin:"-" borsh_skip:"true"`
 	Signers  []*base.AccountMeta `bin:"-" borsh_skip:"true"`
+
+	// The token program, base.TokenProgramID when empty
+	TokenProgramID common.Address `bin:"-" borsh_skip:"true"`
 }
 
 func (mCkd *MintToChecked) SetAccounts(accounts []*base.AccountMeta) error {
@@ -70,6 +73,13 @@ func NewMintToCheckedInstructionBuilder() *MintToChecked {
 	return nd
 }
 
+// SetTokenProgramID sets the token program of the instruction,
+// e.g. base.Token2022ProgramID for a Token-2022 mint.
+func (mCkd *MintToChecked) SetTokenProgramID(tokenProgramID common.Address) *MintToChecked {
+	mCkd.TokenProgramID = tokenProgramID
+	return mCkd
+}
+
 // SetAmount sets the "amount" parameter.
 // The amount of new tokens to mint.
 func (mCkd *MintToChecked) SetAmount(amount uint64) *MintToChecked {
@@ -129,21 +139,24 @@ func (mCkd *MintToChecked) GetAuthorityAccount() *base.AccountMeta {
 	return mCkd.Accounts[2]
 }
 
-func (mCkd MintToChecked) Build() *Instruction {
-	return &Instruction{BaseVariant: encodbin.BaseVariant{
-		Impl:   mCkd,
-		TypeID: encodbin.TypeIDFromUint8(Instruction_MintToChecked),
-	}}
+func (mCkd MintToChecked) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{
+		BaseVariant: encodbin.BaseVariant{
+			Impl:   mCkd,
+			TypeID: encodbin.TypeIDFromUint8(Instruction_MintToChecked),
+		},
+		TokenProgramID: mCkd.TokenProgramID,
+	}, opts)
 }
 
 // ValidateAndBuild validates the instruction parameters and accounts;
 // if there is a validation error, it returns the error.
 // Otherwise, it builds and returns the instruction.
-func (mCkd MintToChecked) ValidateAndBuild() (*Instruction, error) {
+func (mCkd MintToChecked) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
 	if err := mCkd.Validate(); err != nil {
 		return nil, err
 	}
-	return mCkd.Build(), nil
+	return mCkd.Build(opts...), nil
 }
 
 func (mCkd *MintToChecked) Validate() error {
@@ -160,16 +173,16 @@ func (mCkd *MintToChecked) Validate() error {
 	// Check whether all (required) accounts are set:
 	{
 		if mCkd.Accounts[0] == nil {
-			return errors.New("accounts.Mint is not set")
+			return &base.MissingAccountError{Account: "Mint"}
 		}
 		if mCkd.Accounts[1] == nil {
-			return errors.New("accounts.Destination is not set")
+			return &base.MissingAccountError{Account: "Destination"}
 		}
 		if mCkd.Accounts[2] == nil {
-			return errors.New("accounts.Authority is not set")
+			return &base.MissingAccountError{Account: "Authority"}
 		}
 		if !mCkd.Accounts[2].IsSigner && len(mCkd.Signers) == 0 {
-			return fmt.Errorf("accounts.Signers is not set")
+			return &base.MissingAccountError{Account: "Signers"}
 		}
 		if len(mCkd.Signers) > MAX_SIGNERS {
 			return fmt.Errorf("too many signers; got %v, but max is 11", len(mCkd.Signers))
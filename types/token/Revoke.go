@@ -15,7 +15,6 @@
 package token
 
 import (
-	"errors"
 	"fmt"
 	"github.com/cielu/go-solana/common"
 	"github.com/cielu/go-solana/core"
@@ -36,6 +35,9 @@ type Revoke struct {
 	// ··········· M signer accounts.
 	Accounts []*base.AccountMeta `bin:"-" borsh_skip:"true"`
 	Signers  []*base.AccountMeta `bin:"-" borsh_skip:"true"`
+
+	// The token program, base.TokenProgramID when empty
+	TokenProgramID common.Address `bin:"-" borsh_skip:"true"`
 }
 
 func (rvk *Revoke) SetAccounts(accounts []*base.AccountMeta) error {
@@ -58,6 +60,13 @@ func NewRevokeInstructionBuilder() *Revoke {
 	return nd
 }
 
+// SetTokenProgramID sets the token program of the instruction,
+// e.g. base.Token2022ProgramID for a Token-2022 mint.
+func (rvk *Revoke) SetTokenProgramID(tokenProgramID common.Address) *Revoke {
+	rvk.TokenProgramID = tokenProgramID
+	return rvk
+}
+
 // SetSourceAccount sets the "source" account.
 // The source account.
 func (rvk *Revoke) SetSourceAccount(source common.Address) *Revoke {
@@ -90,34 +99,37 @@ func (rvk *Revoke) GetOwnerAccount() *base.AccountMeta {
 	return rvk.Accounts[1]
 }
 
-func (rvk Revoke) Build() *Instruction {
-	return &Instruction{BaseVariant: encodbin.BaseVariant{
-		Impl:   rvk,
-		TypeID: encodbin.TypeIDFromUint8(Instruction_Revoke),
-	}}
+func (rvk Revoke) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{
+		BaseVariant: encodbin.BaseVariant{
+			Impl:   rvk,
+			TypeID: encodbin.TypeIDFromUint8(Instruction_Revoke),
+		},
+		TokenProgramID: rvk.TokenProgramID,
+	}, opts)
 }
 
 // ValidateAndBuild validates the instruction parameters and accounts;
 // if there is a validation error, it returns the error.
 // Otherwise, it builds and returns the instruction.
-func (rvk Revoke) ValidateAndBuild() (*Instruction, error) {
+func (rvk Revoke) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
 	if err := rvk.Validate(); err != nil {
 		return nil, err
 	}
-	return rvk.Build(), nil
+	return rvk.Build(opts...), nil
 }
 
 func (rvk *Revoke) Validate() error {
 	// Check whether all (required) accounts are set:
 	{
 		if rvk.Accounts[0] == nil {
-			return errors.New("accounts.Source is not set")
+			return &base.MissingAccountError{Account: "Source"}
 		}
 		if rvk.Accounts[1] == nil {
-			return errors.New("accounts.Owner is not set")
+			return &base.MissingAccountError{Account: "Owner"}
 		}
 		if !rvk.Accounts[1].IsSigner && len(rvk.Signers) == 0 {
-			return fmt.Errorf("accounts.Signers is not set")
+			return &base.MissingAccountError{Account: "Signers"}
 		}
 		if len(rvk.Signers) > MAX_SIGNERS {
 			return fmt.Errorf("too many signers; got %v, but max is 11", len(rvk.Signers))
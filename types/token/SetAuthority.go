@@ -29,7 +29,7 @@ type SetAuthority struct {
 	AuthorityType *AuthorityType
 
 	// The new authority.
-	NewAuthority *common.Address `bin:"optional"`
+	NewAuthority *common.Address `bin:"optional=borsh"`
 
 	// [0] = [WRITE] subject
 	// ··········· The mint or account to change the authority of.
@@ -41,6 +41,9 @@ type SetAuthority struct {
 	// ··········· M signer accounts.
 	Accounts []*base.AccountMeta `bin:"-" borsh_skip:"true"`
 	Signers  []*base.AccountMeta `bin:"-" borsh_skip:"true"`
+
+	// The token program, base.TokenProgramID when empty
+	TokenProgramID common.Address `bin:"-" borsh_skip:"true"`
 }
 
 func (sAut *SetAuthority) SetAccounts(accounts []*base.AccountMeta) error {
@@ -63,6 +66,13 @@ func NewSetAuthorityInstructionBuilder() *SetAuthority {
 	return nd
 }
 
+// SetTokenProgramID sets the token program of the instruction,
+// e.g. base.Token2022ProgramID for a Token-2022 mint.
+func (sAut *SetAuthority) SetTokenProgramID(tokenProgramID common.Address) *SetAuthority {
+	sAut.TokenProgramID = tokenProgramID
+	return sAut
+}
+
 // SetAuthorityType sets the "authority_type" parameter.
 // The type of authority to update.
 func (sAut *SetAuthority) SetAuthorityType(authority_type AuthorityType) *SetAuthority {
@@ -109,21 +119,24 @@ func (sAut *SetAuthority) GetAuthorityAccount() *base.AccountMeta {
 	return sAut.Accounts[1]
 }
 
-func (sAut SetAuthority) Build() *Instruction {
-	return &Instruction{BaseVariant: encodbin.BaseVariant{
-		Impl:   sAut,
-		TypeID: encodbin.TypeIDFromUint8(Instruction_SetAuthority),
-	}}
+func (sAut SetAuthority) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{
+		BaseVariant: encodbin.BaseVariant{
+			Impl:   sAut,
+			TypeID: encodbin.TypeIDFromUint8(Instruction_SetAuthority),
+		},
+		TokenProgramID: sAut.TokenProgramID,
+	}, opts)
 }
 
 // ValidateAndBuild validates the instruction parameters and accounts;
 // if there is a validation error, it returns the error.
 // Otherwise, it builds and returns the instruction.
-func (sAut SetAuthority) ValidateAndBuild() (*Instruction, error) {
+func (sAut SetAuthority) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
 	if err := sAut.Validate(); err != nil {
 		return nil, err
 	}
-	return sAut.Build(), nil
+	return sAut.Build(opts...), nil
 }
 
 func (sAut *SetAuthority) Validate() error {
@@ -137,13 +150,13 @@ func (sAut *SetAuthority) Validate() error {
 	// Check whether all (required) accounts are set:
 	{
 		if sAut.Accounts[0] == nil {
-			return errors.New("accounts.Subject is not set")
+			return &base.MissingAccountError{Account: "Subject"}
 		}
 		if sAut.Accounts[1] == nil {
-			return errors.New("accounts.Authority is not set")
+			return &base.MissingAccountError{Account: "Authority"}
 		}
 		if !sAut.Accounts[1].IsSigner && len(sAut.Signers) == 0 {
-			return fmt.Errorf("accounts.Signers is not set")
+			return &base.MissingAccountError{Account: "Signers"}
 		}
 		if len(sAut.Signers) > MAX_SIGNERS {
 			return fmt.Errorf("too many signers; got %v, but max is 11", len(sAut.Signers))
@@ -161,12 +174,12 @@ func (sAut SetAuthority) MarshalWithEncoder(encoder *encodbin.Encoder) (err erro
 	// Serialize `NewAuthority` param (optional):
 	{
 		if sAut.NewAuthority == nil {
-			err = encoder.WriteBool(false)
+			err = encoder.WriteOption(false, encodbin.OptionKindBorsh)
 			if err != nil {
 				return err
 			}
 		} else {
-			err = encoder.WriteBool(true)
+			err = encoder.WriteOption(true, encodbin.OptionKindBorsh)
 			if err != nil {
 				return err
 			}
@@ -15,7 +15,6 @@
 package token
 
 import (
-	"errors"
 	"github.com/cielu/go-solana/common"
 	"github.com/cielu/go-solana/pkg/encodbin"
 	"github.com/cielu/go-solana/types/base"
@@ -31,6 +30,9 @@ type SyncNative struct {
 	// [0] = [WRITE] tokenAccount
 	// ··········· The native token account to sync with its underlying lamports.
 	base.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+
+	// The token program, base.TokenProgramID when empty
+	TokenProgramID common.Address `bin:"-" borsh_skip:"true"`
 }
 
 // NewSyncNativeInstructionBuilder creates a new `SyncNative` instruction builder.
@@ -41,6 +43,13 @@ func NewSyncNativeInstructionBuilder() *SyncNative {
 	return nd
 }
 
+// SetTokenProgramID sets the token program of the instruction,
+// e.g. base.Token2022ProgramID for a Token-2022 mint.
+func (sNative *SyncNative) SetTokenProgramID(tokenProgramID common.Address) *SyncNative {
+	sNative.TokenProgramID = tokenProgramID
+	return sNative
+}
+
 func (sync SyncNative) GetAccounts() (accounts []*base.AccountMeta) {
 	accounts = append(accounts, sync.AccountMetaSlice...)
 	return
@@ -59,28 +68,31 @@ func (sNative *SyncNative) GetTokenAccount() *base.AccountMeta {
 	return sNative.AccountMetaSlice[0]
 }
 
-func (sNative SyncNative) Build() *Instruction {
-	return &Instruction{BaseVariant: encodbin.BaseVariant{
-		Impl:   sNative,
-		TypeID: encodbin.TypeIDFromUint8(Instruction_SyncNative),
-	}}
+func (sNative SyncNative) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{
+		BaseVariant: encodbin.BaseVariant{
+			Impl:   sNative,
+			TypeID: encodbin.TypeIDFromUint8(Instruction_SyncNative),
+		},
+		TokenProgramID: sNative.TokenProgramID,
+	}, opts)
 }
 
 // ValidateAndBuild validates the instruction parameters and accounts;
 // if there is a validation error, it returns the error.
 // Otherwise, it builds and returns the instruction.
-func (sNative SyncNative) ValidateAndBuild() (*Instruction, error) {
+func (sNative SyncNative) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
 	if err := sNative.Validate(); err != nil {
 		return nil, err
 	}
-	return sNative.Build(), nil
+	return sNative.Build(opts...), nil
 }
 
 func (sNative *SyncNative) Validate() error {
 	// Check whether all (required) accounts are set:
 	{
 		if sNative.AccountMetaSlice[0] == nil {
-			return errors.New("accounts.TokenAccount is not set")
+			return &base.MissingAccountError{Account: "TokenAccount"}
 		}
 	}
 	return nil
@@ -15,7 +15,6 @@
 package token
 
 import (
-	"errors"
 	"fmt"
 	"github.com/cielu/go-solana/common"
 	"github.com/cielu/go-solana/core"
@@ -39,6 +38,9 @@ type ThawAccount struct {
 	// ··········· M signer accounts.
 	Accounts []*base.AccountMeta `bin:"-" borsh_skip:"true"`
 	Signers  []*base.AccountMeta `bin:"-" borsh_skip:"true"`
+
+	// The token program, base.TokenProgramID when empty
+	TokenProgramID common.Address `bin:"-" borsh_skip:"true"`
 }
 
 func (tAcc *ThawAccount) SetAccounts(accounts []*base.AccountMeta) error {
@@ -61,6 +63,13 @@ func NewThawAccountInstructionBuilder() *ThawAccount {
 	return nd
 }
 
+// SetTokenProgramID sets the token program of the instruction,
+// e.g. base.Token2022ProgramID for a Token-2022 mint.
+func (tAcc *ThawAccount) SetTokenProgramID(tokenProgramID common.Address) *ThawAccount {
+	tAcc.TokenProgramID = tokenProgramID
+	return tAcc
+}
+
 // SetAccount sets the "account" account.
 // The account to thaw.
 func (tAcc *ThawAccount) SetAccount(account common.Address) *ThawAccount {
@@ -106,37 +115,40 @@ func (tAcc *ThawAccount) GetAuthorityAccount() *base.AccountMeta {
 	return tAcc.Accounts[2]
 }
 
-func (tAcc ThawAccount) Build() *Instruction {
-	return &Instruction{BaseVariant: encodbin.BaseVariant{
-		Impl:   tAcc,
-		TypeID: encodbin.TypeIDFromUint8(Instruction_ThawAccount),
-	}}
+func (tAcc ThawAccount) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{
+		BaseVariant: encodbin.BaseVariant{
+			Impl:   tAcc,
+			TypeID: encodbin.TypeIDFromUint8(Instruction_ThawAccount),
+		},
+		TokenProgramID: tAcc.TokenProgramID,
+	}, opts)
 }
 
 // ValidateAndBuild validates the instruction parameters and accounts;
 // if there is a validation error, it returns the error.
 // Otherwise, it builds and returns the instruction.
-func (tAcc ThawAccount) ValidateAndBuild() (*Instruction, error) {
+func (tAcc ThawAccount) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
 	if err := tAcc.Validate(); err != nil {
 		return nil, err
 	}
-	return tAcc.Build(), nil
+	return tAcc.Build(opts...), nil
 }
 
 func (tAcc *ThawAccount) Validate() error {
 	// Check whether all (required) accounts are set:
 	{
 		if tAcc.Accounts[0] == nil {
-			return errors.New("accounts.Account is not set")
+			return &base.MissingAccountError{Account: "Account"}
 		}
 		if tAcc.Accounts[1] == nil {
-			return errors.New("accounts.Mint is not set")
+			return &base.MissingAccountError{Account: "Mint"}
 		}
 		if tAcc.Accounts[2] == nil {
-			return errors.New("accounts.Authority is not set")
+			return &base.MissingAccountError{Account: "Authority"}
 		}
 		if !tAcc.Accounts[2].IsSigner && len(tAcc.Signers) == 0 {
-			return fmt.Errorf("accounts.Signers is not set")
+			return &base.MissingAccountError{Account: "Signers"}
 		}
 		if len(tAcc.Signers) > MAX_SIGNERS {
 			return fmt.Errorf("too many signers; got %v, but max is 11", len(tAcc.Signers))
@@ -0,0 +1,214 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token
+
+import (
+	"errors"
+	"fmt"
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/core"
+	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/types/base"
+)
+
+// Transfer Transfers tokens from one account to another either directly or via a
+// delegate.  If this account is associated with the native mint then equal
+// amounts of SOL and Tokens will be transferred to the destination
+// account.
+//
+// The mint and decimals are not checked, prefer TransferChecked unless the
+// program requires the unchecked form.
+type Transfer struct {
+	// The amount of tokens to transfer.
+	Amount *uint64
+
+	// [0] = [WRITE] source
+	// ··········· The source account.
+	//
+	// [1] = [WRITE] destination
+	// ··········· The destination account.
+	//
+	// [2] = [] owner
+	// ··········· The source account owner/delegate.
+	//
+	// [3...] = [SIGNER] signers
+	// ··········· M signer accounts.
+	Accounts []*base.AccountMeta `bin:"-" borsh_skip:"true"`
+	Signers  []*base.AccountMeta `bin:"-" borsh_skip:"true"`
+
+	// The token program, base.TokenProgramID when empty
+	TokenProgramID common.Address `bin:"-" borsh_skip:"true"`
+}
+
+func (trans *Transfer) SetAccounts(accounts []*base.AccountMeta) error {
+	trans.Accounts, trans.Signers = core.SliceSplitFrom(accounts, 3)
+	return nil
+}
+
+func (trans Transfer) GetAccounts() (accounts []*base.AccountMeta) {
+	accounts = append(accounts, trans.Accounts...)
+	accounts = append(accounts, trans.Signers...)
+	return
+}
+
+// NewTransferInstructionBuilder creates a new `Transfer` instruction builder.
+//
+// Deprecated: the unchecked transfer does not verify the mint decimals,
+// use NewTransferCheckedInstructionBuilder instead.
+func NewTransferInstructionBuilder() *Transfer {
+	nd := &Transfer{
+		Accounts: make([]*base.AccountMeta, 3),
+		Signers:  make([]*base.AccountMeta, 0),
+	}
+	return nd
+}
+
+// SetTokenProgramID sets the token program of the instruction,
+// e.g. base.Token2022ProgramID for a Token-2022 mint.
+func (trans *Transfer) SetTokenProgramID(tokenProgramID common.Address) *Transfer {
+	trans.TokenProgramID = tokenProgramID
+	return trans
+}
+
+// SetAmount sets the "amount" parameter.
+// The amount of tokens to transfer.
+func (trans *Transfer) SetAmount(amount uint64) *Transfer {
+	trans.Amount = &amount
+	return trans
+}
+
+// SetSourceAccount sets the "source" account.
+// The source account.
+func (trans *Transfer) SetSourceAccount(source common.Address) *Transfer {
+	trans.Accounts[0] = base.Meta(source).WRITE()
+	return trans
+}
+
+// GetSourceAccount gets the "source" account.
+// The source account.
+func (trans *Transfer) GetSourceAccount() *base.AccountMeta {
+	return trans.Accounts[0]
+}
+
+// SetDestinationAccount sets the "destination" account.
+// The destination account.
+func (trans *Transfer) SetDestinationAccount(destination common.Address) *Transfer {
+	trans.Accounts[1] = base.Meta(destination).WRITE()
+	return trans
+}
+
+// GetDestinationAccount gets the "destination" account.
+// The destination account.
+func (trans *Transfer) GetDestinationAccount() *base.AccountMeta {
+	return trans.Accounts[1]
+}
+
+// SetOwnerAccount sets the "owner" account.
+// The source account owner/delegate.
+func (trans *Transfer) SetOwnerAccount(owner common.Address, multisigSigners ...common.Address) *Transfer {
+	trans.Accounts[2] = base.Meta(owner)
+	if len(multisigSigners) == 0 {
+		trans.Accounts[2].SIGNER()
+	}
+	for _, signer := range multisigSigners {
+		trans.Signers = append(trans.Signers, base.Meta(signer).SIGNER())
+	}
+	return trans
+}
+
+// GetOwnerAccount gets the "owner" account.
+// The source account owner/delegate.
+func (trans *Transfer) GetOwnerAccount() *base.AccountMeta {
+	return trans.Accounts[2]
+}
+
+func (trans Transfer) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{
+		BaseVariant: encodbin.BaseVariant{
+			Impl:   trans,
+			TypeID: encodbin.TypeIDFromUint8(Instruction_Transfer),
+		},
+		TokenProgramID: trans.TokenProgramID,
+	}, opts)
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (trans Transfer) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
+	if err := trans.Validate(); err != nil {
+		return nil, err
+	}
+	return trans.Build(opts...), nil
+}
+
+func (trans *Transfer) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if trans.Amount == nil {
+			return errors.New("Amount parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if trans.Accounts[0] == nil {
+			return &base.MissingAccountError{Account: "Source"}
+		}
+		if trans.Accounts[1] == nil {
+			return &base.MissingAccountError{Account: "Destination"}
+		}
+		if trans.Accounts[2] == nil {
+			return &base.MissingAccountError{Account: "Owner"}
+		}
+		if !trans.Accounts[2].IsSigner && len(trans.Signers) == 0 {
+			return &base.MissingAccountError{Account: "Signers"}
+		}
+		if len(trans.Signers) > MAX_SIGNERS {
+			return fmt.Errorf("too many signers; got %v, but max is 11", len(trans.Signers))
+		}
+	}
+	return nil
+}
+
+func (trans Transfer) MarshalWithEncoder(encoder *encodbin.Encoder) (err error) {
+	// Serialize `Amount` param:
+	err = encoder.Encode(trans.Amount)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewTransferInstruction declares a new Transfer instruction with the provided parameters and accounts.
+//
+// Deprecated: the unchecked transfer does not verify the mint decimals, which
+// silently moves the wrong amount when the decimals are mismatched.
+// Use NewTransferCheckedInstruction unless the program requires the unchecked form.
+func NewTransferInstruction(
+	// Parameters:
+	amount uint64,
+	// Accounts:
+	source common.Address,
+	destination common.Address,
+	owner common.Address,
+	multisigSigners []common.Address,
+) *Transfer {
+	return NewTransferInstructionBuilder().
+		SetAmount(amount).
+		SetSourceAccount(source).
+		SetDestinationAccount(destination).
+		SetOwnerAccount(owner, multisigSigners...)
+}
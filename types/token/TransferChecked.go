@@ -30,6 +30,9 @@ type TransferChecked struct {
 	// ··········· M signer accounts.
 	Accounts []*base.AccountMeta `bin:"-" borsh_skip:"true"`
 	Signers  []*base.AccountMeta `bin:"-" borsh_skip:"true"`
+
+	// The token program, base.TokenProgramID when empty
+	TokenProgramID common.Address `bin:"-" borsh_skip:"true"`
 }
 
 func (tc *TransferChecked) SetAccounts(accounts []*base.AccountMeta) error {
@@ -52,6 +55,13 @@ func NewTransferCheckedInstructionBuilder() *TransferChecked {
 	return nd
 }
 
+// SetTokenProgramID sets the token program of the instruction,
+// e.g. base.Token2022ProgramID for a Token-2022 mint.
+func (tc *TransferChecked) SetTokenProgramID(tokenProgramID common.Address) *TransferChecked {
+	tc.TokenProgramID = tokenProgramID
+	return tc
+}
+
 // SetAmount sets the "amount" parameter.
 // The amount of tokens to transfer.
 func (tc *TransferChecked) SetAmount(amount uint64) *TransferChecked {
@@ -124,11 +134,14 @@ func (tc *TransferChecked) GetOwnerAccount() *base.AccountMeta {
 	return tc.Accounts[3]
 }
 
-func (tc TransferChecked) Build() *Instruction {
-	return &Instruction{BaseVariant: encodbin.BaseVariant{
-		Impl:   tc,
-		TypeID: encodbin.TypeIDFromUint8(Instruction_TransferChecked),
-	}}
+func (tc TransferChecked) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{
+		BaseVariant: encodbin.BaseVariant{
+			Impl:   tc,
+			TypeID: encodbin.TypeIDFromUint8(Instruction_TransferChecked),
+		},
+		TokenProgramID: tc.TokenProgramID,
+	}, opts)
 }
 
 func (tc TransferChecked) MarshalWithEncoder(encoder encodbin.Encoder) (err error) {
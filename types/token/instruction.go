@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/cielu/go-solana/common"
 	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/types"
 	"github.com/cielu/go-solana/types/base"
 )
 
@@ -142,6 +143,15 @@ type Instruction struct {
 	TokenProgramID common.Address
 }
 
+// withBuildOptions applies the options of Build to the instruction, base.WithProgramID
+// overrides the token program
+func withBuildOptions(inst *Instruction, opts []base.BuildOption) *Instruction {
+	if programID := base.NewBuildOptions(opts...).ProgramID; !programID.IsEmpty() {
+		inst.TokenProgramID = programID
+	}
+	return inst
+}
+
 func (inst *Instruction) ProgramID() common.Address {
 	if inst.TokenProgramID.IsEmpty() {
 		return base.TokenProgramID
@@ -172,3 +182,70 @@ func (inst *Instruction) MarshalWithEncoder(encoder *encodbin.Encoder) error {
 	}
 	return encoder.Encode(inst.Impl)
 }
+
+func init() {
+	types.RegisterInstructionDecoder(base.TokenProgramID, DecodeInstruction)
+	types.RegisterInstructionDecoder(base.Token2022ProgramID, DecodeInstruction)
+}
+
+// DecodeInstruction decodes the accounts and data of a token program instruction
+// into its typed instruction, e.g. a *MintTo. The Token-2022 extension instructions are not supported
+func DecodeInstruction(accounts []*base.AccountMeta, data []byte) (interface{}, error) {
+	decoder := encodbin.NewBinDecoder(data)
+	typeID, err := decoder.ReadUint8()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read instruction type: %w", err)
+	}
+	var inst base.AccountsSettable
+	switch typeID {
+	case Instruction_InitializeMint:
+		inst = new(InitializeMint)
+	case Instruction_InitializeAccount:
+		inst = new(InitializeAccount)
+	case Instruction_InitializeMultisig:
+		inst = new(InitializeMultisig)
+	case Instruction_Transfer:
+		inst = new(Transfer)
+	case Instruction_Approve:
+		inst = new(Approve)
+	case Instruction_Revoke:
+		inst = new(Revoke)
+	case Instruction_SetAuthority:
+		inst = new(SetAuthority)
+	case Instruction_MintTo:
+		inst = new(MintTo)
+	case Instruction_Burn:
+		inst = new(Burn)
+	case Instruction_CloseAccount:
+		inst = new(CloseAccount)
+	case Instruction_ThawAccount:
+		inst = new(ThawAccount)
+	case Instruction_TransferChecked:
+		inst = new(TransferChecked)
+	case Instruction_ApproveChecked:
+		inst = new(ApproveChecked)
+	case Instruction_MintToChecked:
+		inst = new(MintToChecked)
+	case Instruction_BurnChecked:
+		inst = new(BurnChecked)
+	case Instruction_InitializeAccount2:
+		inst = new(InitializeAccount2)
+	case Instruction_SyncNative:
+		inst = new(SyncNative)
+	case Instruction_InitializeAccount3:
+		inst = new(InitializeAccount3)
+	case Instruction_InitializeMultisig2:
+		inst = new(InitializeMultisig2)
+	case Instruction_InitializeMint2:
+		inst = new(InitializeMint2)
+	default:
+		return nil, fmt.Errorf("unsupported token instruction: %d", typeID)
+	}
+	if err = decoder.Decode(inst); err != nil {
+		return nil, fmt.Errorf("unable to decode token instruction %d: %w", typeID, err)
+	}
+	if err = inst.SetAccounts(accounts); err != nil {
+		return nil, err
+	}
+	return inst, nil
+}
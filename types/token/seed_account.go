@@ -0,0 +1,32 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package token
+
+import (
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/types"
+	"github.com/cielu/go-solana/types/base"
+	"github.com/cielu/go-solana/types/native"
+)
+
+// CreateAccountWithSeedInstructions returns the instructions creating a token account
+// at the address derived from the base account and seed, then initializing it for the
+// mint and owner. The base account must sign the transaction, rentLamports should be
+// the rent exemption of ACCOUNT_SIZE bytes.
+func CreateAccountWithSeedInstructions(payer, baseAccount common.Address, seed string, mint, owner common.Address, rentLamports uint64) ([]types.Instruction, common.Address) {
+	account := base.CreateWithSeed(baseAccount, seed, base.TokenProgramID)
+	return []types.Instruction{
+		native.NewCreateAccountWithSeedInstruction(
+			baseAccount,
+			seed,
+			rentLamports,
+			ACCOUNT_SIZE,
+			base.TokenProgramID,
+			payer,
+			account,
+			baseAccount,
+		).Build(),
+		NewInitializeAccountInstruction(account, mint, owner).Build(),
+	}, account
+}
@@ -0,0 +1,46 @@
+package token
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/types/base"
+)
+
+func TestCreateAccountWithSeedInstructions(t *testing.T) {
+	var (
+		payer = common.StrToAddress("F8HCC3DyoR6KN9SSK9NL1V6weRgsEvp8hjL26EnTxNTF")
+		mint  = common.StrToAddress("BZYExy8yxFZF6jTp4h7X98dPLBcbQDFhvHXPdTjDb2ag")
+		owner = common.StrToAddress("6vG61wtqP7aRgabnECQ2pYBHToJEmPtafvQrxYwmqsAL")
+		seed  = "token-1"
+	)
+	instructions, account := CreateAccountWithSeedInstructions(payer, payer, seed, mint, owner, 2039280)
+
+	hash := sha256.Sum256(append(append(payer.Bytes(), seed...), base.TokenProgramID.Bytes()...))
+	if want := common.BytesToAddress(hash[:]); account != want {
+		t.Errorf("derived address Err ==> Got %s, Want: %s", account, want)
+	}
+	if len(instructions) != 2 {
+		t.Fatalf("instructions length Err ==> Got %d, Want: %d", len(instructions), 2)
+	}
+	// create the account first, with the system program
+	if instructions[0].ProgramID() != base.SystemProgramID {
+		t.Errorf("instructions[0] ProgramID Err ==> Got %s, Want: %s", instructions[0].ProgramID(), base.SystemProgramID)
+	}
+	if created := instructions[0].Accounts()[1]; created.PublicKey != account || !created.IsWritable {
+		t.Errorf("created account Err ==> Got %v, Want: %s", created, account)
+	}
+	// then initialize it, with the token program
+	if instructions[1].ProgramID() != base.TokenProgramID {
+		t.Errorf("instructions[1] ProgramID Err ==> Got %s, Want: %s", instructions[1].ProgramID(), base.TokenProgramID)
+	}
+	accounts := instructions[1].Accounts()
+	if accounts[0].PublicKey != account || accounts[1].PublicKey != mint || accounts[2].PublicKey != owner {
+		t.Errorf("InitializeAccount accounts Err ==> Got %v", accounts)
+	}
+	data, err := instructions[1].Data()
+	if err != nil || len(data) != 1 || data[0] != Instruction_InitializeAccount {
+		t.Errorf("InitializeAccount data Err ==> Got %v, %v", data, err)
+	}
+}
@@ -0,0 +1,305 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package token
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/types"
+	"github.com/cielu/go-solana/types/base"
+)
+
+// AccountType the account type byte following the base state of a Token-2022 account with extensions
+type AccountType uint8
+
+const (
+	AccountTypeUninitialized AccountType = iota
+	AccountTypeMint
+	AccountTypeAccount
+)
+
+// ExtensionType the type of a Token-2022 extension
+type ExtensionType uint16
+
+const (
+	ExtensionUninitialized ExtensionType = iota
+	ExtensionTransferFeeConfig
+	ExtensionTransferFeeAmount
+	ExtensionMintCloseAuthority
+	ExtensionConfidentialTransferMint
+	ExtensionConfidentialTransferAccount
+	ExtensionDefaultAccountState
+	ExtensionImmutableOwner
+	ExtensionMemoTransfer
+	ExtensionNonTransferable
+	ExtensionInterestBearingConfig
+	ExtensionCpiGuard
+	ExtensionPermanentDelegate
+	ExtensionNonTransferableAccount
+	ExtensionTransferHook
+	ExtensionTransferHookAccount
+	ExtensionConfidentialTransferFeeConfig
+	ExtensionConfidentialTransferFeeAmount
+	ExtensionMetadataPointer
+	ExtensionTokenMetadata
+	ExtensionGroupPointer
+	ExtensionTokenGroup
+	ExtensionGroupMemberPointer
+	ExtensionTokenGroupMember
+)
+
+// MintState the base state of a mint, shared by the token and Token-2022 programs
+type MintState struct {
+	// Authority used to mint new tokens, nil when the supply is fixed
+	MintAuthority *common.Address
+	// Total supply of tokens
+	Supply uint64
+	// Number of base 10 digits to the right of the decimal place
+	Decimals uint8
+	// Is `true` if this structure has been initialized
+	IsInitialized bool
+	// Authority used to freeze token accounts, nil when not set
+	FreezeAuthority *common.Address
+}
+
+// TokenAccountState the base state of a token account, shared by the token and Token-2022 programs
+type TokenAccountState struct {
+	// The mint associated with this account
+	Mint common.Address
+	// The owner of this account
+	Owner common.Address
+	// The amount of tokens this account holds
+	Amount uint64
+	// The delegate of the DelegatedAmount, nil when not set
+	Delegate *common.Address
+	// The account's state
+	State AccountState
+	// The rent-exempt reserve of a native account, nil when not native
+	IsNative *uint64
+	// The amount delegated
+	DelegatedAmount uint64
+	// Optional authority to close the account
+	CloseAuthority *common.Address
+}
+
+// Extension a Token-2022 extension, Data is the raw value of the TLV entry
+type Extension struct {
+	Type ExtensionType
+	Data []byte
+}
+
+// TransferFee the fee of a transfer, from the epoch
+type TransferFee struct {
+	Epoch uint64
+	// Maximum fee assessed on transfers, in token amount
+	MaximumFee uint64
+	// Amount of transfer collected as fees, expressed as basis points of the transfer amount
+	TransferFeeBasisPoints uint16
+}
+
+// TransferFeeConfig the TransferFeeConfig extension of a mint
+type TransferFeeConfig struct {
+	// Optional authority to set the fee, empty when not set
+	TransferFeeConfigAuthority common.Address
+	// Withdraw from mint instructions must be signed by this key, empty when not set
+	WithdrawWithheldAuthority common.Address
+	// Withheld transfer fee tokens that have been moved to the mint for withdrawal
+	WithheldAmount uint64
+	// Older transfer fee, used if the current epoch < NewerTransferFee.Epoch
+	OlderTransferFee TransferFee
+	// Newer transfer fee, used if the current epoch >= NewerTransferFee.Epoch
+	NewerTransferFee TransferFee
+}
+
+// DecodeMint decode a mint of the token or Token-2022 program, with its extensions
+func DecodeMint(data []byte) (*MintState, []Extension, error) {
+	if len(data) < MINT_SIZE {
+		return nil, nil, fmt.Errorf("invalid mint size: %d", len(data))
+	}
+	mint, err := decodeMintState(data[:MINT_SIZE])
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to decode mint: %w", err)
+	}
+	if len(data) == MINT_SIZE {
+		return mint, nil, nil
+	}
+	// the base mint is padded to the size of an account, then followed by the account type
+	if len(data) <= ACCOUNT_SIZE {
+		return nil, nil, fmt.Errorf("invalid extended mint size: %d", len(data))
+	}
+	for _, b := range data[MINT_SIZE:ACCOUNT_SIZE] {
+		if b != 0 {
+			return nil, nil, fmt.Errorf("invalid mint padding")
+		}
+	}
+	extensions, err := decodeExtensions(data, AccountTypeMint)
+	if err != nil {
+		return nil, nil, err
+	}
+	return mint, extensions, nil
+}
+
+// DecodeTokenAccount decode a token account of the token or Token-2022 program, with its extensions
+func DecodeTokenAccount(data []byte) (*TokenAccountState, []Extension, error) {
+	if len(data) < ACCOUNT_SIZE {
+		return nil, nil, fmt.Errorf("invalid token account size: %d", len(data))
+	}
+	account, err := decodeTokenAccountState(data[:ACCOUNT_SIZE])
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to decode token account: %w", err)
+	}
+	if len(data) == ACCOUNT_SIZE {
+		return account, nil, nil
+	}
+	extensions, err := decodeExtensions(data, AccountTypeAccount)
+	if err != nil {
+		return nil, nil, err
+	}
+	return account, extensions, nil
+}
+
+// DecodeTokenAccountAuto decode the token account with the decoder of its owner program, only the
+// Token-2022 accounts have extensions. The account data must be binary encoded
+func DecodeTokenAccountAuto(info types.AccountInfo) (*TokenAccountState, []Extension, error) {
+	data := info.Data.RawData
+	switch info.Owner {
+	case base.TokenProgramID:
+		if len(data) != ACCOUNT_SIZE {
+			return nil, nil, fmt.Errorf("invalid token account size: %d", len(data))
+		}
+		account, err := decodeTokenAccountState(data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to decode token account: %w", err)
+		}
+		return account, nil, nil
+	case base.Token2022ProgramID:
+		return DecodeTokenAccount(data)
+	}
+	return nil, nil, fmt.Errorf("account owner %s is not a token program", info.Owner)
+}
+
+// decodeMintState decode the 82 bytes base state of a mint
+func decodeMintState(data []byte) (*MintState, error) {
+	mintAuthority, err := decodeCOption(data[0:36])
+	if err != nil {
+		return nil, err
+	}
+	freezeAuthority, err := decodeCOption(data[46:82])
+	if err != nil {
+		return nil, err
+	}
+	mint := &MintState{
+		Supply:        binary.LittleEndian.Uint64(data[36:44]),
+		Decimals:      data[44],
+		IsInitialized: data[45] != 0,
+	}
+	if mintAuthority != nil {
+		mint.MintAuthority = new(common.Address)
+		*mint.MintAuthority = common.BytesToAddress(mintAuthority)
+	}
+	if freezeAuthority != nil {
+		mint.FreezeAuthority = new(common.Address)
+		*mint.FreezeAuthority = common.BytesToAddress(freezeAuthority)
+	}
+	return mint, nil
+}
+
+// decodeTokenAccountState decode the 165 bytes base state of a token account
+func decodeTokenAccountState(data []byte) (*TokenAccountState, error) {
+	delegate, err := decodeCOption(data[72:108])
+	if err != nil {
+		return nil, err
+	}
+	isNative, err := decodeCOption(data[109:121])
+	if err != nil {
+		return nil, err
+	}
+	closeAuthority, err := decodeCOption(data[129:165])
+	if err != nil {
+		return nil, err
+	}
+	account := &TokenAccountState{
+		Mint:            common.BytesToAddress(data[0:32]),
+		Owner:           common.BytesToAddress(data[32:64]),
+		Amount:          binary.LittleEndian.Uint64(data[64:72]),
+		State:           AccountState(data[108]),
+		DelegatedAmount: binary.LittleEndian.Uint64(data[121:129]),
+	}
+	if delegate != nil {
+		account.Delegate = new(common.Address)
+		*account.Delegate = common.BytesToAddress(delegate)
+	}
+	if isNative != nil {
+		account.IsNative = new(uint64)
+		*account.IsNative = binary.LittleEndian.Uint64(isNative)
+	}
+	if closeAuthority != nil {
+		account.CloseAuthority = new(common.Address)
+		*account.CloseAuthority = common.BytesToAddress(closeAuthority)
+	}
+	return account, nil
+}
+
+// decodeCOption decode a COption<T> of the spl programs, the value follows a u32 tag and
+// takes its full size even when absent. Returns nil when absent
+func decodeCOption(data []byte) ([]byte, error) {
+	switch tag := binary.LittleEndian.Uint32(data); tag {
+	case 0:
+		return nil, nil
+	case 1:
+		return data[4:], nil
+	default:
+		return nil, fmt.Errorf("invalid option tag: %d", tag)
+	}
+}
+
+// decodeExtensions check the account type byte and walk the TLV entries following it
+func decodeExtensions(data []byte, accountType AccountType) ([]Extension, error) {
+	if got := AccountType(data[ACCOUNT_SIZE]); got != accountType {
+		return nil, fmt.Errorf("invalid account type: %d", got)
+	}
+	var (
+		extensions []Extension
+		rest       = data[ACCOUNT_SIZE+1:]
+	)
+	for len(rest) >= 4 {
+		extType := ExtensionType(binary.LittleEndian.Uint16(rest))
+		length := int(binary.LittleEndian.Uint16(rest[2:]))
+		// the remaining space is not used
+		if extType == ExtensionUninitialized && length == 0 {
+			break
+		}
+		if length > len(rest)-4 {
+			return nil, fmt.Errorf("invalid length %d of extension %d", length, extType)
+		}
+		extensions = append(extensions, Extension{Type: extType, Data: rest[4 : 4+length]})
+		rest = rest[4+length:]
+	}
+	return extensions, nil
+}
+
+// FindExtension returns the extension of the type
+func FindExtension(extensions []Extension, extType ExtensionType) (Extension, bool) {
+	for _, ext := range extensions {
+		if ext.Type == extType {
+			return ext, true
+		}
+	}
+	return Extension{}, false
+}
+
+// TransferFeeConfig decode the TransferFeeConfig extension
+func (ext Extension) TransferFeeConfig() (*TransferFeeConfig, error) {
+	if ext.Type != ExtensionTransferFeeConfig {
+		return nil, fmt.Errorf("extension %d is not TransferFeeConfig", ext.Type)
+	}
+	config := new(TransferFeeConfig)
+	if err := encodbin.UnmarshalBin(config, ext.Data); err != nil {
+		return nil, fmt.Errorf("unable to decode TransferFeeConfig: %w", err)
+	}
+	return config, nil
+}
@@ -0,0 +1,185 @@
+package token
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/types"
+	"github.com/cielu/go-solana/types/base"
+)
+
+func TestDecodeMintTransferFeeConfig(t *testing.T) {
+	var (
+		authority = common.StrToAddress("F8HCC3DyoR6KN9SSK9NL1V6weRgsEvp8hjL26EnTxNTF")
+		withdraw  = common.StrToAddress("BZYExy8yxFZF6jTp4h7X98dPLBcbQDFhvHXPdTjDb2ag")
+		mint      = MintState{MintAuthority: &authority, Supply: 1_000_000_000_000, Decimals: 6, IsInitialized: true}
+		config    = TransferFeeConfig{
+			TransferFeeConfigAuthority: authority,
+			WithdrawWithheldAuthority:  withdraw,
+			WithheldAmount:             42,
+			OlderTransferFee:           TransferFee{Epoch: 500, MaximumFee: 5_000, TransferFeeBasisPoints: 50},
+			NewerTransferFee:           TransferFee{Epoch: 600, MaximumFee: 10_000, TransferFeeBasisPoints: 100},
+		}
+	)
+	// COption<Pubkey> mint authority, supply, decimals, is_initialized, absent freeze authority
+	base82 := append([]byte{1, 0, 0, 0}, authority[:]...)
+	base82 = append(base82, make([]byte, 8)...)
+	binary.LittleEndian.PutUint64(base82[36:], mint.Supply)
+	base82 = append(base82, mint.Decimals, 1)
+	base82 = append(base82, make([]byte, 36)...)
+	configData, err := encodbin.MarshalBin(&config)
+	if err != nil || len(configData) != 108 {
+		t.Fatalf("MarshalBin TransferFeeConfig Err ==> Got %d bytes, %v", len(configData), err)
+	}
+	tlv := func(extType ExtensionType, value []byte) []byte {
+		entry := make([]byte, 4, 4+len(value))
+		binary.LittleEndian.PutUint16(entry, uint16(extType))
+		binary.LittleEndian.PutUint16(entry[2:], uint16(len(value)))
+		return append(entry, value...)
+	}
+	data := append(base82, make([]byte, ACCOUNT_SIZE-MINT_SIZE)...)
+	data = append(data, byte(AccountTypeMint))
+	data = append(data, tlv(ExtensionTransferFeeConfig, configData)...)
+	data = append(data, tlv(ExtensionMintCloseAuthority, authority[:])...)
+
+	got, extensions, err := DecodeMint(data)
+	if err != nil {
+		t.Fatalf("DecodeMint Failed: %s", err)
+	}
+	if got.MintAuthority == nil || *got.MintAuthority != authority || got.Supply != mint.Supply || got.Decimals != 6 || got.FreezeAuthority != nil {
+		t.Errorf("DecodeMint Err ==> Got %+v", got)
+	}
+	if len(extensions) != 2 || extensions[1].Type != ExtensionMintCloseAuthority {
+		t.Fatalf("extensions Err ==> Got %+v", extensions)
+	}
+	ext, ok := FindExtension(extensions, ExtensionTransferFeeConfig)
+	if !ok {
+		t.Fatalf("FindExtension TransferFeeConfig not found")
+	}
+	gotConfig, err := ext.TransferFeeConfig()
+	if err != nil {
+		t.Fatalf("TransferFeeConfig Failed: %s", err)
+	}
+	if *gotConfig != config {
+		t.Errorf("TransferFeeConfig Err ==> Got %+v, Want: %+v", *gotConfig, config)
+	}
+	if _, err = extensions[1].TransferFeeConfig(); err == nil {
+		t.Errorf("TransferFeeConfig of MintCloseAuthority should fail")
+	}
+
+	// a classic mint has no extensions
+	if _, extensions, err = DecodeMint(base82); err != nil || extensions != nil {
+		t.Errorf("DecodeMint classic Err ==> Got %v, %v", extensions, err)
+	}
+	// a truncated extension
+	if _, _, err = DecodeMint(data[:len(data)-1]); err == nil {
+		t.Errorf("DecodeMint with truncated extension should fail")
+	}
+	// an account is not a mint
+	data[ACCOUNT_SIZE] = byte(AccountTypeAccount)
+	if _, _, err = DecodeMint(data); err == nil {
+		t.Errorf("DecodeMint with account type should fail")
+	}
+}
+
+func TestDecodeTokenAccount(t *testing.T) {
+	var (
+		mint  = common.StrToAddress("6vG61wtqP7aRgabnECQ2pYBHToJEmPtafvQrxYwmqsAL")
+		owner = common.StrToAddress("F8HCC3DyoR6KN9SSK9NL1V6weRgsEvp8hjL26EnTxNTF")
+		data  = make([]byte, ACCOUNT_SIZE+1, ACCOUNT_SIZE+5)
+	)
+	copy(data, mint[:])
+	copy(data[32:], owner[:])
+	binary.LittleEndian.PutUint64(data[64:], 500)
+	// absent delegate, initialized, absent is_native, absent close authority
+	data[108] = byte(Initialized)
+	data[ACCOUNT_SIZE] = byte(AccountTypeAccount)
+	// ImmutableOwner has no value
+	data = append(data, byte(ExtensionImmutableOwner), 0, 0, 0)
+
+	got, extensions, err := DecodeTokenAccount(data)
+	if err != nil {
+		t.Fatalf("DecodeTokenAccount Failed: %s", err)
+	}
+	want := TokenAccountState{Mint: mint, Owner: owner, Amount: 500, State: Initialized}
+	if *got != want {
+		t.Errorf("DecodeTokenAccount Err ==> Got %+v, Want: %+v", *got, want)
+	}
+	if _, ok := FindExtension(extensions, ExtensionImmutableOwner); !ok || len(extensions) != 1 {
+		t.Errorf("extensions Err ==> Got %+v", extensions)
+	}
+}
+
+func TestDecodeTokenAccountAuto(t *testing.T) {
+	var (
+		mint  = common.StrToAddress("6vG61wtqP7aRgabnECQ2pYBHToJEmPtafvQrxYwmqsAL")
+		owner = common.StrToAddress("F8HCC3DyoR6KN9SSK9NL1V6weRgsEvp8hjL26EnTxNTF")
+		data  = make([]byte, ACCOUNT_SIZE)
+		info  = types.AccountInfo{Owner: base.TokenProgramID}
+	)
+	copy(data, mint[:])
+	copy(data[32:], owner[:])
+	binary.LittleEndian.PutUint64(data[64:], 500)
+	data[108] = byte(Initialized)
+	want := TokenAccountState{Mint: mint, Owner: owner, Amount: 500, State: Initialized}
+
+	// classic token account
+	info.Data.SetSolData(data, "base64")
+	got, extensions, err := DecodeTokenAccountAuto(info)
+	if err != nil {
+		t.Fatalf("DecodeTokenAccountAuto Failed: %s", err)
+	}
+	if *got != want || extensions != nil {
+		t.Errorf("DecodeTokenAccountAuto Err ==> Got %+v %+v, Want: %+v", *got, extensions, want)
+	}
+
+	// Token-2022 account with the ImmutableOwner and MemoTransfer extensions
+	extended := append(append([]byte{}, data...), byte(AccountTypeAccount))
+	extended = append(extended, byte(ExtensionImmutableOwner), 0, 0, 0)
+	extended = append(extended, byte(ExtensionMemoTransfer), 0, 1, 0, 1)
+	info.Data.SetSolData(extended, "base64")
+	if _, _, err = DecodeTokenAccountAuto(info); err == nil {
+		t.Errorf("DecodeTokenAccountAuto Err ==> Got nil, Want: extensions of a classic token account")
+	}
+	info.Owner = base.Token2022ProgramID
+	got, extensions, err = DecodeTokenAccountAuto(info)
+	if err != nil {
+		t.Fatalf("DecodeTokenAccountAuto Failed: %s", err)
+	}
+	if *got != want || len(extensions) != 2 || extensions[0].Type != ExtensionImmutableOwner ||
+		extensions[1].Type != ExtensionMemoTransfer || len(extensions[1].Data) != 1 || extensions[1].Data[0] != 1 {
+		t.Errorf("DecodeTokenAccountAuto Err ==> Got %+v %+v, Want: %+v", *got, extensions, want)
+	}
+
+	// not a token account
+	info.Owner = base.SystemProgramID
+	if _, _, err = DecodeTokenAccountAuto(info); err == nil {
+		t.Errorf("DecodeTokenAccountAuto Err ==> Got nil, Want: owner error")
+	}
+}
+
+func TestToken2022Instruction(t *testing.T) {
+	var (
+		source = common.StrToAddress("BZYExy8yxFZF6jTp4h7X98dPLBcbQDFhvHXPdTjDb2ag")
+		mint   = common.StrToAddress("6vG61wtqP7aRgabnECQ2pYBHToJEmPtafvQrxYwmqsAL")
+		dest   = common.StrToAddress("EXC6EAnN7HMXbTWomY6j7tQZY1cfZ52LRJpwZ6i3CY66")
+		owner  = common.StrToAddress("F8HCC3DyoR6KN9SSK9NL1V6weRgsEvp8hjL26EnTxNTF")
+	)
+	classic := NewTransferCheckedInstruction(1, 6, source, mint, dest, owner, nil).Build()
+	if classic.ProgramID() != base.TokenProgramID {
+		t.Errorf("ProgramID Err ==> Got %s, Want: %s", classic.ProgramID(), base.TokenProgramID)
+	}
+	inst := NewTransferCheckedInstruction(1, 6, source, mint, dest, owner, nil).
+		SetTokenProgramID(base.Token2022ProgramID).
+		Build()
+	if inst.ProgramID() != base.Token2022ProgramID {
+		t.Errorf("ProgramID Err ==> Got %s, Want: %s", inst.ProgramID(), base.Token2022ProgramID)
+	}
+	classicData, _ := classic.Data()
+	data, _ := inst.Data()
+	if string(data) != string(classicData) {
+		t.Errorf("Data Err ==> Got %v, Want: %v", data, classicData)
+	}
+}
@@ -1,4 +1,4 @@
-package token
+package token_test
 
 import (
 	"context"
@@ -8,6 +8,8 @@ import (
 	"github.com/cielu/go-solana/crypto"
 	"github.com/cielu/go-solana/solclient"
 	"github.com/cielu/go-solana/types"
+	"github.com/cielu/go-solana/types/base"
+	"github.com/cielu/go-solana/types/token"
 	"testing"
 )
 
@@ -28,7 +30,7 @@ func TestTokenTransfer(t *testing.T) {
 
 	payer := common.StrToAddress("F8HCC3DyoR6KN9SSK9NL1V6weRgsEvp8hjL26EnTxNTF")
 
-	instruction := NewTransferCheckedInstruction(
+	instruction := token.NewTransferCheckedInstruction(
 		1e9,
 		9,
 		common.StrToAddress("BZYExy8yxFZF6jTp4h7X98dPLBcbQDFhvHXPdTjDb2ag"),
@@ -53,7 +55,7 @@ func TestTokenTransfer(t *testing.T) {
 
 	key, _ := crypto.AccountFromBase58Key("3HE29Pg2c2tjbCkVxJpDKhLZuqPLEfoeF3gwjE8MTP3WzvQmLFCxHtKHkGnqNMBPPgFwTWP4vmb9b9a7hGybgtDb")
 
-	signErr := transaction.Sign([]crypto.Account{key})
+	_, signErr := transaction.Sign([]crypto.Account{key})
 
 	if signErr != nil {
 		fmt.Println("sign error:", signErr)
@@ -68,3 +70,35 @@ func TestTokenTransfer(t *testing.T) {
 	}
 	println(res.String())
 }
+
+func TestDecodeInstruction(t *testing.T) {
+	var (
+		mint        = common.StrToAddress("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")
+		destination = common.StrToAddress("6XViKPqw7t47tZz8UJR1bJFVzxjnQbuKtN2TBgnfZmo4")
+		authority   = common.StrToAddress("F8HCC3DyoR6KN9SSK9NL1V6weRgsEvp8hjL26EnTxNTF")
+	)
+	inst := token.NewMintToInstruction(1_000_000, mint, destination, authority, nil).Build()
+	data, err := inst.Data()
+	if err != nil {
+		t.Fatalf("MintTo Data Failed: %s", err)
+	}
+	decoded, err := types.DecodeInstruction(inst.ProgramID(), data, inst.Accounts())
+	if err != nil {
+		t.Fatalf("DecodeInstruction Failed: %s", err)
+	}
+	mintTo, ok := decoded.(*token.MintTo)
+	if !ok {
+		t.Fatalf("DecodeInstruction Err ==> Got %T, Want: *token.MintTo", decoded)
+	}
+	if *mintTo.Amount != 1_000_000 || mintTo.GetMintAccount().PublicKey != mint ||
+		mintTo.GetDestinationAccount().PublicKey != destination || !mintTo.GetAuthorityAccount().IsSigner {
+		t.Errorf("MintTo Err ==> Got %d %v", *mintTo.Amount, mintTo.GetAccounts())
+	}
+	// the optional freeze authority of InitializeMint
+	initInst := token.NewInitializeMintInstruction(6, authority, destination, mint).Build()
+	data, _ = initInst.Data()
+	decoded, err = types.DecodeInstruction(base.Token2022ProgramID, data, initInst.Accounts())
+	if initMint, ok := decoded.(*token.InitializeMint); err != nil || !ok || *initMint.Decimals != 6 || *initMint.FreezeAuthority != destination {
+		t.Errorf("InitializeMint Err ==> Got %+v, %v", decoded, err)
+	}
+}
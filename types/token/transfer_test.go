@@ -0,0 +1,80 @@
+package token
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/types/base"
+)
+
+func TestTransferEncode(t *testing.T) {
+	var (
+		source      = common.StrToAddress("BZYExy8yxFZF6jTp4h7X98dPLBcbQDFhvHXPdTjDb2ag")
+		destination = common.StrToAddress("6vG61wtqP7aRgabnECQ2pYBHToJEmPtafvQrxYwmqsAL")
+		owner       = common.StrToAddress("F8HCC3DyoR6KN9SSK9NL1V6weRgsEvp8hjL26EnTxNTF")
+	)
+	inst, err := NewTransferInstruction(1e9, source, destination, owner, nil).ValidateAndBuild()
+	if err != nil {
+		t.Fatalf("ValidateAndBuild Failed: %s", err)
+	}
+	data, err := inst.Data()
+	if err != nil {
+		t.Fatalf("Data Failed: %s", err)
+	}
+	want := []byte{3, 0x00, 0xca, 0x9a, 0x3b, 0, 0, 0, 0}
+	if !bytes.Equal(data, want) {
+		t.Errorf("Data Err ==> Got %v, Want: %v", data, want)
+	}
+	if inst.ProgramID() != base.TokenProgramID {
+		t.Errorf("ProgramID Err ==> Got %s, Want: %s", inst.ProgramID(), base.TokenProgramID)
+	}
+	wantAccounts := []*base.AccountMeta{
+		base.Meta(source).WRITE(),
+		base.Meta(destination).WRITE(),
+		base.Meta(owner).SIGNER(),
+	}
+	accounts := inst.Accounts()
+	if len(accounts) != len(wantAccounts) {
+		t.Fatalf("Accounts length Err ==> Got %d, Want: %d", len(accounts), len(wantAccounts))
+	}
+	for i, acc := range accounts {
+		if *acc != *wantAccounts[i] {
+			t.Errorf("Accounts[%d] Err ==> Got %v, Want: %v", i, acc, wantAccounts[i])
+		}
+	}
+	// missing owner signature
+	if _, err = NewTransferInstructionBuilder().SetAmount(1).SetSourceAccount(source).SetDestinationAccount(destination).ValidateAndBuild(); err == nil {
+		t.Errorf("ValidateAndBuild without owner should fail")
+	}
+}
+
+func TestBuildWithProgramID(t *testing.T) {
+	var (
+		source      = common.StrToAddress("BZYExy8yxFZF6jTp4h7X98dPLBcbQDFhvHXPdTjDb2ag")
+		destination = common.StrToAddress("6vG61wtqP7aRgabnECQ2pYBHToJEmPtafvQrxYwmqsAL")
+		owner       = common.StrToAddress("F8HCC3DyoR6KN9SSK9NL1V6weRgsEvp8hjL26EnTxNTF")
+		proxy       = common.StrToAddress("9xQeWvG816bUx9EPjHmaT23yvVM2ZWbrrpZb9PusVFin")
+	)
+	builder := NewTransferInstruction(1e9, source, destination, owner, nil)
+	inst, err := builder.ValidateAndBuild(base.WithProgramID(proxy))
+	if err != nil {
+		t.Fatalf("ValidateAndBuild Failed: %s", err)
+	}
+	if inst.ProgramID() != proxy {
+		t.Errorf("ProgramID Err ==> Got %s, Want: %s", inst.ProgramID(), proxy)
+	}
+	// the override takes precedence over the token program
+	if inst = builder.SetTokenProgramID(base.Token2022ProgramID).Build(base.WithProgramID(proxy)); inst.ProgramID() != proxy {
+		t.Errorf("ProgramID Err ==> Got %s, Want: %s", inst.ProgramID(), proxy)
+	}
+	if inst = builder.Build(); inst.ProgramID() != base.Token2022ProgramID {
+		t.Errorf("ProgramID Err ==> Got %s, Want: %s", inst.ProgramID(), base.Token2022ProgramID)
+	}
+	// the data and accounts are unchanged
+	data, _ := inst.Data()
+	proxied, _ := builder.Build(base.WithProgramID(proxy)).Data()
+	if !bytes.Equal(data, proxied) {
+		t.Errorf("Data Err ==> Got %v, Want: %v", proxied, data)
+	}
+}
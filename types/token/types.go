@@ -16,6 +16,9 @@ package token
 
 const MINT_SIZE = 82
 
+// ACCOUNT_SIZE size of a token account
+const ACCOUNT_SIZE = 165
+
 // Maximum number of multisignature signers (max N)
 const MAX_SIGNERS = 11
 
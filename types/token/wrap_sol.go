@@ -0,0 +1,31 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package token
+
+import (
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/types"
+	"github.com/cielu/go-solana/types/base"
+	"github.com/cielu/go-solana/types/native"
+)
+
+// WrapSOL returns the instructions wrapping amount lamports into the new native token account:
+// create the account funded with rentLamports + amount, initialize it for the SOL mint and owner,
+// then sync its amount. The account must sign the transaction, rentLamports should be the rent
+// exemption of ACCOUNT_SIZE bytes.
+func WrapSOL(payer, account, owner common.Address, amount, rentLamports uint64) []types.Instruction {
+	return []types.Instruction{
+		native.NewCreateAccountInstruction(rentLamports+amount, ACCOUNT_SIZE, base.TokenProgramID, payer, account).Build(),
+		NewInitializeAccountInstruction(account, base.SolMint, owner).Build(),
+		NewSyncNativeInstruction(account).Build(),
+	}
+}
+
+// UnwrapSOL returns the instruction closing the native token account, its lamports
+// (the wrapped amount and the rent) go to the destination.
+func UnwrapSOL(account, destination, owner common.Address) []types.Instruction {
+	return []types.Instruction{
+		NewCloseAccountInstruction(account, destination, owner, nil).Build(),
+	}
+}
@@ -0,0 +1,70 @@
+package token
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/types/base"
+)
+
+func TestWrapSOL(t *testing.T) {
+	var (
+		payer   = common.StrToAddress("F8HCC3DyoR6KN9SSK9NL1V6weRgsEvp8hjL26EnTxNTF")
+		account = common.StrToAddress("BZYExy8yxFZF6jTp4h7X98dPLBcbQDFhvHXPdTjDb2ag")
+		owner   = common.StrToAddress("6vG61wtqP7aRgabnECQ2pYBHToJEmPtafvQrxYwmqsAL")
+	)
+	instructions := WrapSOL(payer, account, owner, 1_000_000_000, 2039280)
+	if len(instructions) != 3 {
+		t.Fatalf("instructions length Err ==> Got %d, Want: %d", len(instructions), 3)
+	}
+	// create the account with the wrapped amount and the rent
+	if instructions[0].ProgramID() != base.SystemProgramID {
+		t.Errorf("instructions[0] ProgramID Err ==> Got %s, Want: %s", instructions[0].ProgramID(), base.SystemProgramID)
+	}
+	data, err := instructions[0].Data()
+	if err != nil || len(data) != 52 {
+		t.Fatalf("CreateAccount data Err ==> Got %v, %v", data, err)
+	}
+	if lamports, space := binary.LittleEndian.Uint64(data[4:]), binary.LittleEndian.Uint64(data[12:]); lamports != 1_002_039_280 || space != ACCOUNT_SIZE {
+		t.Errorf("CreateAccount Err ==> Got %d lamports %d space, Want: 1002039280 %d", lamports, space, ACCOUNT_SIZE)
+	}
+	if owner := common.BytesToAddress(data[20:]); owner != base.TokenProgramID {
+		t.Errorf("CreateAccount owner Err ==> Got %s, Want: %s", owner, base.TokenProgramID)
+	}
+	// initialize it for the SOL mint
+	accounts := instructions[1].Accounts()
+	if accounts[0].PublicKey != account || accounts[1].PublicKey != base.SolMint || accounts[2].PublicKey != owner {
+		t.Errorf("InitializeAccount accounts Err ==> Got %v", accounts)
+	}
+	// then sync the native amount
+	if instructions[2].ProgramID() != base.TokenProgramID {
+		t.Errorf("instructions[2] ProgramID Err ==> Got %s, Want: %s", instructions[2].ProgramID(), base.TokenProgramID)
+	}
+	if data, err = instructions[2].Data(); err != nil || len(data) != 1 || data[0] != 17 {
+		t.Errorf("SyncNative data Err ==> Got %v, %v", data, err)
+	}
+	if accounts = instructions[2].Accounts(); len(accounts) != 1 || accounts[0].PublicKey != account || !accounts[0].IsWritable {
+		t.Errorf("SyncNative accounts Err ==> Got %v", accounts)
+	}
+}
+
+func TestUnwrapSOL(t *testing.T) {
+	var (
+		account = common.StrToAddress("BZYExy8yxFZF6jTp4h7X98dPLBcbQDFhvHXPdTjDb2ag")
+		owner   = common.StrToAddress("6vG61wtqP7aRgabnECQ2pYBHToJEmPtafvQrxYwmqsAL")
+	)
+	instructions := UnwrapSOL(account, owner, owner)
+	if len(instructions) != 1 || instructions[0].ProgramID() != base.TokenProgramID {
+		t.Fatalf("instructions Err ==> Got %v, Want: a token program CloseAccount", instructions)
+	}
+	data, err := instructions[0].Data()
+	if err != nil || len(data) != 1 || data[0] != 9 {
+		t.Errorf("CloseAccount data Err ==> Got %v, %v", data, err)
+	}
+	accounts := instructions[0].Accounts()
+	if len(accounts) != 3 || accounts[0].PublicKey != account || !accounts[0].IsWritable ||
+		accounts[1].PublicKey != owner || !accounts[1].IsWritable || accounts[2].PublicKey != owner || !accounts[2].IsSigner {
+		t.Errorf("CloseAccount accounts Err ==> Got %v", accounts)
+	}
+}
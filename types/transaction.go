@@ -6,14 +6,19 @@ import (
 	"errors"
 	"fmt"
 	"github.com/cielu/go-solana/common"
-	"github.com/cielu/go-solana/core"
 	"github.com/cielu/go-solana/crypto"
 	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/pkg/encoding"
 	"github.com/cielu/go-solana/types/base"
-	"github.com/mr-tron/base58"
 	"sort"
 )
 
+var (
+	ErrTxNoInstructions = errors.New("transaction message requires at-least one instruction")
+	ErrTxNoBlockhash    = errors.New("transaction message requires a recent blockhash")
+	ErrTxInvalidMessage = errors.New("invalid transaction message")
+)
+
 type Transaction struct {
 	// A list of base-58 encoded signatures applied to the transaction.
 	// The list is always of length `message.header.numRequiredSignatures` and not empty.
@@ -40,143 +45,29 @@ type CompiledInstruction struct {
 	Data common.Base58 `json:"data"`
 }
 
+// NewTransaction creates a transaction with the instructions, the fee payer falls back to
+// the first signer of the first instruction when empty. See TransactionBuilder.
 func NewTransaction(instructions []Instruction, recentBlockHash common.Hash, payer common.Address) (*Transaction, error) {
-	if len(instructions) == 0 {
-		return nil, fmt.Errorf("requires at-least one instruction to create a transaction")
-	}
-
-	feePayer := payer
-	if feePayer.IsEmpty() {
-		found := false
-		for _, act := range instructions[0].Accounts() {
-			if act.IsSigner {
-				feePayer = act.PublicKey
-				found = true
-				break
-			}
-		}
-		if !found {
-			return nil, fmt.Errorf("cannot determine fee payer. You can ether pass the fee payer via the 'TransactionWithInstructions' option parameter or it falls back to the first instruction's first signer")
-		}
-	}
-
-	programIDs := make([]common.Address, 0)
-	var accounts []*base.AccountMeta
-	for _, instruction := range instructions {
-		accounts = append(accounts, instruction.Accounts()...)
-		programIDs = core.UniqueAppend(programIDs, instruction.ProgramID())
-	}
-
-	// Add programID to the account list
-	for _, programID := range programIDs {
-		accounts = append(accounts, &base.AccountMeta{
-			PublicKey:  programID,
-			IsSigner:   false,
-			IsWritable: false,
-		})
-	}
-
-	// Sort. Prioritizing first by signer, then by writable
-	sort.SliceStable(accounts, func(i, j int) bool {
-		return accounts[i].Less(accounts[j])
-	})
-
-	var (
-		uniqAccounts    []*base.AccountMeta
-		uniqAccountsMap = map[common.Address]uint64{}
-	)
-	for _, acc := range accounts {
-		if index, found := uniqAccountsMap[acc.PublicKey]; found {
-			uniqAccounts[index].IsWritable = uniqAccounts[index].IsWritable || acc.IsWritable
-			continue
-		}
-		uniqAccounts = append(uniqAccounts, acc)
-		uniqAccountsMap[acc.PublicKey] = uint64(len(uniqAccounts) - 1)
-	}
-
-	// Move fee payer to the front
-	feePayerIndex := -1
-	for idx, acc := range uniqAccounts {
-		if acc.PublicKey == feePayer {
-			feePayerIndex = idx
-		}
-	}
-
-	accountCount := len(uniqAccounts)
-	if feePayerIndex < 0 {
-		// fee payer is not part of accounts we want to add it
-		accountCount++
-	}
-	finalAccounts := make([]*base.AccountMeta, accountCount)
-
-	itr := 1
-	for idx, uniqAccount := range uniqAccounts {
-		if idx == feePayerIndex {
-			uniqAccount.IsSigner = true
-			uniqAccount.IsWritable = true
-			finalAccounts[0] = uniqAccount
-			continue
-		}
-		finalAccounts[itr] = uniqAccount
-		itr++
-	}
-
-	if feePayerIndex < 0 {
-		// fee payer is not part of accounts we want to add it
-		feePayerAccount := &base.AccountMeta{
-			PublicKey:  feePayer,
-			IsSigner:   true,
-			IsWritable: true,
-		}
-		finalAccounts[0] = feePayerAccount
-	}
-
-	message := Message{
-		RecentBlockhash: recentBlockHash,
-	}
-	accountKeyIndex := map[string]uint16{}
-	for idx, acc := range finalAccounts {
-		message.AccountKeys = append(message.AccountKeys, acc.PublicKey)
-		accountKeyIndex[acc.PublicKey.String()] = uint16(idx)
-		if acc.IsSigner {
-			message.Header.NumRequiredSignatures++
-			if !acc.IsWritable {
-				message.Header.NumReadonlySignedAccounts++
-			}
-			continue
-		}
-
-		if !acc.IsWritable {
-			message.Header.NumReadonlyUnsignedAccounts++
-		}
-	}
-
-	for txIdx, instruction := range instructions {
-		accounts = instruction.Accounts()
-		accountIndex := make([]uint16, len(accounts))
-		for idx, acc := range accounts {
-			accountIndex[idx] = accountKeyIndex[acc.PublicKey.String()]
-		}
-		data, err := instruction.Data()
-		if err != nil {
-			return nil, fmt.Errorf("unable to encode instructions [%d]: %w", txIdx, err)
-		}
-		message.Instructions = append(message.Instructions, CompiledInstruction{
-			ProgramIDIndex: accountKeyIndex[instruction.ProgramID().String()],
-			Accounts:       accountIndex,
-			Data:           data,
-		})
-	}
-
-	return &Transaction{
-		Message: message,
-	}, nil
+	return NewTransactionBuilder().
+		AddInstruction(instructions...).
+		SetRecentBlockhash(recentBlockHash).
+		SetFeePayer(payer).
+		Build()
 }
 
 func (tx *Transaction) MarshalBinary() ([]byte, error) {
+	if len(tx.Message.Instructions) == 0 {
+		return nil, ErrTxNoInstructions
+	}
+	if tx.Message.RecentBlockhash.IsEmpty() {
+		return nil, ErrTxNoBlockhash
+	}
 	if len(tx.Signatures) == 0 || len(tx.Signatures) != int(tx.Message.Header.NumRequiredSignatures) {
 		return nil, errors.New("signature verification failed")
 	}
+	if err := tx.Validate(); err != nil {
+		return nil, err
+	}
 
 	messageContent, err := tx.Message.MarshalBinary()
 	if err != nil {
@@ -195,6 +86,48 @@ func (tx *Transaction) MarshalBinary() ([]byte, error) {
 	return output, nil
 }
 
+// Validate checks the message is well-formed: the header counts partition AccountKeys with a writable
+// signer fee payer, the account keys are unique, the instruction indexes are in range of the account keys
+// and the looked up addresses, and the programs are neither signers nor writable. Errors wrap ErrTxInvalidMessage
+func (tx *Transaction) Validate() error {
+	var (
+		msg       = &tx.Message
+		h         = msg.Header
+		numStatic = msg.numStaticAccountKeys()
+		// the lookups are not in AccountKeys until resolved
+		numKeys = numStatic + msg.addressTableLookups.NumLookups()
+	)
+	if h.NumRequiredSignatures == 0 || h.NumReadonlySignedAccounts >= h.NumRequiredSignatures {
+		return fmt.Errorf("%w: the fee payer must be a writable signer", ErrTxInvalidMessage)
+	}
+	if int(h.NumRequiredSignatures)+int(h.NumReadonlyUnsignedAccounts) > numStatic {
+		return fmt.Errorf("%w: header requires %d signed and %d readonly unsigned accounts, got %d account keys",
+			ErrTxInvalidMessage, h.NumRequiredSignatures, h.NumReadonlyUnsignedAccounts, numStatic)
+	}
+	seen := make(map[common.Address]struct{}, len(msg.AccountKeys))
+	for _, key := range msg.AccountKeys {
+		if _, ok := seen[key]; ok {
+			return fmt.Errorf("%w: duplicate account key %s", ErrTxInvalidMessage, key)
+		}
+		seen[key] = struct{}{}
+	}
+	for i, instruction := range msg.Instructions {
+		programIndex := int(instruction.ProgramIDIndex)
+		if programIndex >= numStatic {
+			return fmt.Errorf("%w: instruction %d program index %d out of range of %d account keys", ErrTxInvalidMessage, i, programIndex, numStatic)
+		}
+		if programIndex < int(h.NumRequiredSignatures) || msg.isWritableIndex(programIndex) {
+			return fmt.Errorf("%w: instruction %d program %s is a signer or writable", ErrTxInvalidMessage, i, msg.AccountKeys[programIndex])
+		}
+		for _, index := range instruction.Accounts {
+			if int(index) >= numKeys {
+				return fmt.Errorf("%w: instruction %d account index %d out of range of %d accounts", ErrTxInvalidMessage, i, index, numKeys)
+			}
+		}
+	}
+	return nil
+}
+
 func (tx *Transaction) NoSignedMarshalBinary() ([]byte, error) {
 
 	messageContent, err := tx.Message.MarshalBinary()
@@ -214,6 +147,14 @@ func (tx *Transaction) NoSignedMarshalBinary() ([]byte, error) {
 	return output, nil
 }
 
+// Version returns the version of the transaction message, 0 for a v0 message
+func (tx *Transaction) Version() TxVersion {
+	if tx.Message.IsVersioned() {
+		return 0
+	}
+	return LegacyTransactionVersion
+}
+
 // UnmarshalJSON parses the transaction Content
 func (tx *Transaction) UnmarshalJSON(input []byte) error {
 	// Unmarshal data to []byte
@@ -232,11 +173,12 @@ func (tx *Transaction) UnmarshalJSON(input []byte) error {
 			return nil
 		}
 		// decode to string
+		encoded, _ := v[0].(string)
 		switch v[1] {
 		case "base58":
-			tx.UnmarshalBase58(v[0].(string))
+			return tx.UnmarshalBase58(encoded)
 		case "base64":
-			tx.UnmarshalBase64(v[0].(string))
+			return tx.UnmarshalBase64(encoded)
 		default:
 			return fmt.Errorf("UnmarshalDataByEncoding Err: %s", v[1])
 		}
@@ -263,7 +205,13 @@ func (tx *Transaction) UnmarshalJSON(input []byte) error {
 					if ins["stackHeight"] != nil {
 						stackHeight = ins["stackHeight"].(*uint16)
 					}
-					insData, _ := base58.Decode(ins["data"].(string))
+					var insData []byte
+					if encoded, _ := ins["data"].(string); encoded != "" {
+						var err error
+						if insData, err = encoding.DecodeBase58(encoded); err != nil {
+							return fmt.Errorf("unable to decode instruction data: %w", err)
+						}
+					}
 					// Instructions
 					tx.Message.Instructions = append(tx.Message.Instructions, CompiledInstruction{
 						StackHeight:    stackHeight,
@@ -282,6 +230,14 @@ func (tx *Transaction) UnmarshalJSON(input []byte) error {
 					}
 				}
 				tx.Message.RecentBlockhash = common.Base58ToHash(message["recentBlockhash"].(string))
+				// v0 message, lookups of the accounts loaded from address tables
+				if lookups, ok := message["addressTableLookups"].([]interface{}); ok {
+					tx.Message.version = MessageVersionV0
+					tx.Message.addressTableLookups = parseAddressTableLookups(lookups)
+				}
+			// transactionDetails "accounts", account keys without the message
+			case "accountKeys":
+				tx.Message.AccountKeys, tx.Message.Header = parseAccountKeys(vv.([]interface{}))
 			case "signatures":
 				for _, sig := range vv.([]interface{}) {
 					tx.Signatures = append(tx.Signatures, common.Base58ToSignature(sig.(string)))
@@ -294,6 +250,73 @@ func (tx *Transaction) UnmarshalJSON(input []byte) error {
 	return nil
 }
 
+// parseAddressTableLookups parse the addressTableLookups of a json encoded message
+func parseAddressTableLookups(lookups []interface{}) MessageAddressTableLookupSlice {
+	indexes := func(v interface{}) []uint8 {
+		list, _ := v.([]interface{})
+		out := make([]uint8, 0, len(list))
+		for _, index := range list {
+			n, _ := index.(float64)
+			out = append(out, uint8(n))
+		}
+		return out
+	}
+	out := make(MessageAddressTableLookupSlice, 0, len(lookups))
+	for _, lookup := range lookups {
+		lookupMap, ok := lookup.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		accountKey, _ := lookupMap["accountKey"].(string)
+		out = append(out, MessageAddressTableLookup{
+			AccountKey:      common.Base58ToAddress(accountKey),
+			WritableIndexes: indexes(lookupMap["writableIndexes"]),
+			ReadonlyIndexes: indexes(lookupMap["readonlyIndexes"]),
+		})
+	}
+	return out
+}
+
+// parseAccountKeys parse the account keys objects returned with transactionDetails "accounts",
+// the keys loaded from lookup tables are skipped as in the message account keys
+func parseAccountKeys(keys []interface{}) (accountKeys []common.Address, header MessageHeader) {
+	for _, key := range keys {
+		keyMap, ok := key.(map[string]interface{})
+		if !ok || keyMap["source"] == "lookupTable" {
+			continue
+		}
+		pubkey, _ := keyMap["pubkey"].(string)
+		signer, _ := keyMap["signer"].(bool)
+		writable, _ := keyMap["writable"].(bool)
+		accountKeys = append(accountKeys, common.Base58ToAddress(pubkey))
+		switch {
+		case signer && !writable:
+			header.NumRequiredSignatures++
+			header.NumReadonlySignedAccounts++
+		case signer:
+			header.NumRequiredSignatures++
+		case !writable:
+			header.NumReadonlyUnsignedAccounts++
+		}
+	}
+	return
+}
+
+// WithLoadedAddresses returns a copy of the message with the addresses loaded from
+// lookup tables appended to the account keys, writable first as the runtime orders them,
+// so IsWritable, Writable and Signers account for them.
+// The meta is the one fetched with the transaction, by getBlock or getTransaction.
+func (tx *Transaction) WithLoadedAddresses(meta TransactionMeta) Message {
+	message := tx.Message
+	numStatic := message.numStaticAccountKeys()
+	message.AccountKeys = make([]common.Address, 0, numStatic+len(meta.LoadedAddresses.Writable)+len(meta.LoadedAddresses.ReadOnly))
+	message.AccountKeys = append(message.AccountKeys, tx.Message.AccountKeys[:numStatic]...)
+	message.AccountKeys = append(message.AccountKeys, meta.LoadedAddresses.Writable...)
+	message.AccountKeys = append(message.AccountKeys, meta.LoadedAddresses.ReadOnly...)
+	message.loadedAddresses = meta.LoadedAddresses
+	return message
+}
+
 // UnmarshalBase64 decodes a base64 encoded transaction.
 func (tx *Transaction) UnmarshalBase64(b64 string) error {
 	b, err := base64.StdEncoding.DecodeString(b64)
@@ -305,7 +328,10 @@ func (tx *Transaction) UnmarshalBase64(b64 string) error {
 
 // UnmarshalBase58 decodes a base58 encoded transaction.
 func (tx *Transaction) UnmarshalBase58(b58 string) error {
-	b, _ := base58.Decode(b58)
+	b, err := encoding.DecodeBase58(b58)
+	if err != nil {
+		return err
+	}
 	return tx.UnmarshalWithDecoder(encodbin.NewBinDecoder(b))
 }
 
@@ -333,8 +359,14 @@ func (tx *Transaction) UnmarshalWithDecoder(decoder *encodbin.Decoder) (err erro
 	return nil
 }
 
-// Sign accounts
+// Sign accounts, see SignWith
 func (tx *Transaction) Sign(accounts []crypto.Account) ([]byte, error) {
+	return tx.SignWith(crypto.Signers(accounts))
+}
+
+// SignWith signs the transaction with the signers, e.g. a hardware wallet or a remote signer,
+// every signer key of the message must be present. Returns the signed transaction
+func (tx *Transaction) SignWith(signers []crypto.Signer) ([]byte, error) {
 
 	messageContent, err := tx.Message.MarshalBinary()
 	if err != nil {
@@ -345,10 +377,13 @@ func (tx *Transaction) Sign(accounts []crypto.Account) ([]byte, error) {
 
 signerMatch:
 	for _, key := range signerKeys {
-		for _, signer := range accounts {
-			if key == signer.Address {
-				s := signer.Sign(messageContent)
-				tx.Signatures = append(tx.Signatures, common.BytesToSignature(s))
+		for _, signer := range signers {
+			if key == signer.PublicKey() {
+				s, err := signer.SignMessage(messageContent)
+				if err != nil {
+					return nil, fmt.Errorf("unable to sign with %s: %w", key, err)
+				}
+				tx.Signatures = append(tx.Signatures, s)
 				continue signerMatch
 			}
 		}
@@ -358,6 +393,177 @@ signerMatch:
 	return tx.MarshalBinary()
 }
 
+// AddSignature place the signature of pubkey at the index of the signer in message account keys.
+// Signatures of the other signers are zero-filled until they are added.
+func (tx *Transaction) AddSignature(pubkey common.Address, sig common.Signature) error {
+	for idx, key := range tx.Message.signerKeys() {
+		if key == pubkey {
+			tx.fillSignatures()
+			tx.Signatures[idx] = sig
+			return nil
+		}
+	}
+	return fmt.Errorf("signer key %q not found in the message signer keys", pubkey.String())
+}
+
+// RequiredSigners returns the accounts which must sign the transaction, the fee payer first
+func (tx *Transaction) RequiredSigners() []common.Address {
+	numSigners := tx.Message.NumSigners()
+	if numSigners > len(tx.Message.AccountKeys) {
+		numSigners = len(tx.Message.AccountKeys)
+	}
+	signers := make([]common.Address, numSigners)
+	copy(signers, tx.Message.AccountKeys)
+	return signers
+}
+
+// SetFeePayer makes the payer the fee payer of the transaction, e.g. a fee relayer.
+// The payer is moved, or added, at index 0 as a writable signer, the other account keys
+// keep their signer then writable ordering and the instruction account indices are recompiled.
+// The signatures are cleared since the message changed.
+func (tx *Transaction) SetFeePayer(payer common.Address) error {
+	if payer.IsEmpty() {
+		return errors.New("fee payer is empty")
+	}
+	m := &tx.Message
+	numStatic := m.numStaticAccountKeys()
+	if int(m.Header.NumRequiredSignatures) > numStatic {
+		return fmt.Errorf("invalid message header: %d signers for %d account keys", m.Header.NumRequiredSignatures, numStatic)
+	}
+
+	metas := make([]*base.AccountMeta, 0, numStatic)
+	oldIndex := make(map[common.Address]int, numStatic)
+	for idx, key := range m.AccountKeys[:numStatic] {
+		oldIndex[key] = idx
+		if key == payer {
+			continue
+		}
+		metas = append(metas, &base.AccountMeta{
+			PublicKey:  key,
+			IsSigner:   idx < int(m.Header.NumRequiredSignatures),
+			IsWritable: m.isWritableIndex(idx),
+		})
+	}
+	sort.SliceStable(metas, func(i, j int) bool {
+		return metas[i].Less(metas[j])
+	})
+	metas = append([]*base.AccountMeta{{PublicKey: payer, IsSigner: true, IsWritable: true}}, metas...)
+
+	var (
+		header     MessageHeader
+		keys       = make([]common.Address, 0, len(metas)+len(m.AccountKeys)-numStatic)
+		indexRemap = make([]uint16, len(m.AccountKeys))
+	)
+	for idx, meta := range metas {
+		keys = append(keys, meta.PublicKey)
+		if old, ok := oldIndex[meta.PublicKey]; ok {
+			indexRemap[old] = uint16(idx)
+		}
+		switch {
+		case meta.IsSigner && !meta.IsWritable:
+			header.NumRequiredSignatures++
+			header.NumReadonlySignedAccounts++
+		case meta.IsSigner:
+			header.NumRequiredSignatures++
+		case !meta.IsWritable:
+			header.NumReadonlyUnsignedAccounts++
+		}
+	}
+	// the loaded addresses follow the static account keys
+	for idx := numStatic; idx < len(m.AccountKeys); idx++ {
+		indexRemap[idx] = uint16(len(keys))
+		keys = append(keys, m.AccountKeys[idx])
+	}
+
+	// unresolved loaded addresses of a v0 message are shifted when the payer is added
+	shift := uint16(len(keys) - len(m.AccountKeys))
+	remap := func(idx uint16) uint16 {
+		if int(idx) >= len(indexRemap) {
+			return idx + shift
+		}
+		return indexRemap[idx]
+	}
+	instructions := make([]CompiledInstruction, len(m.Instructions))
+	for i, instruction := range m.Instructions {
+		accounts := make([]uint16, len(instruction.Accounts))
+		for j, idx := range instruction.Accounts {
+			accounts[j] = remap(idx)
+		}
+		instruction.ProgramIDIndex = remap(instruction.ProgramIDIndex)
+		instruction.Accounts = accounts
+		instructions[i] = instruction
+	}
+
+	m.AccountKeys = keys
+	m.Header = header
+	m.Instructions = instructions
+	tx.Signatures = nil
+	return nil
+}
+
+// PartialSign sign the transaction with available accounts, see PartialSignWith
+func (tx *Transaction) PartialSign(accounts []crypto.Account) error {
+	return tx.PartialSignWith(crypto.Signers(accounts))
+}
+
+// PartialSignWith sign the transaction with available signers,
+// the signatures of missing signers remain zero-filled placeholders.
+func (tx *Transaction) PartialSignWith(signers []crypto.Signer) error {
+
+	messageContent, err := tx.Message.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("unable to encode message for signing: %w", err)
+	}
+
+	tx.fillSignatures()
+
+	for idx, key := range tx.Message.signerKeys() {
+		for _, signer := range signers {
+			if key == signer.PublicKey() {
+				if tx.Signatures[idx], err = signer.SignMessage(messageContent); err != nil {
+					return fmt.Errorf("unable to sign with %s: %w", key, err)
+				}
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// VerifySignatures verify every present signature against the serialized message,
+// returns the signers whose signature is still missing.
+func (tx *Transaction) VerifySignatures() ([]common.Address, error) {
+
+	messageContent, err := tx.Message.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode message for verifying: %w", err)
+	}
+
+	var missing []common.Address
+
+	for idx, key := range tx.Message.signerKeys() {
+		if idx >= len(tx.Signatures) || tx.Signatures[idx] == (common.Signature{}) {
+			missing = append(missing, key)
+			continue
+		}
+		if !tx.Signatures[idx].VerifySignature(key, messageContent) {
+			return missing, fmt.Errorf("invalid signature for signer %q", key.String())
+		}
+	}
+	return missing, nil
+}
+
+// fillSignatures resize the signatures to NumRequiredSignatures with zero-filled placeholders
+func (tx *Transaction) fillSignatures() {
+	numSigners := int(tx.Message.Header.NumRequiredSignatures)
+	if len(tx.Signatures) == numSigners {
+		return
+	}
+	signatures := make([]common.Signature, numSigners)
+	copy(signatures, tx.Signatures)
+	tx.Signatures = signatures
+}
+
 func (tx Transaction) ToBase64() (string, error) {
 	out, err := tx.MarshalBinary()
 	if err != nil {
@@ -371,5 +577,5 @@ func (tx Transaction) ToBase58() (string, error) {
 	if err != nil {
 		return "", err
 	}
-	return base58.Encode(out), nil
+	return encoding.EncodeBase58(out), nil
 }
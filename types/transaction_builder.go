@@ -0,0 +1,241 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package types
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/crypto"
+	"github.com/cielu/go-solana/types/base"
+	computebudget "github.com/cielu/go-solana/types/compute-budget"
+)
+
+// MaxComputeUnitLimit the maximum compute unit limit of a transaction
+const MaxComputeUnitLimit = 1_400_000
+
+// TransactionBuilder builds a legacy transaction from instructions, it de-duplicates
+// the accounts, moves the fee payer first and computes the message header.
+type TransactionBuilder struct {
+	instructions     []Instruction
+	feePayer         common.Address
+	recentBlockhash  common.Hash
+	computeUnitLimit uint32
+	computeUnitPrice uint64
+}
+
+// NewTransactionBuilder creates a new transaction builder.
+func NewTransactionBuilder() *TransactionBuilder {
+	return &TransactionBuilder{}
+}
+
+// AddInstruction append the instructions to the transaction
+func (b *TransactionBuilder) AddInstruction(instructions ...Instruction) *TransactionBuilder {
+	b.instructions = append(b.instructions, instructions...)
+	return b
+}
+
+// SetFeePayer set the account paying the fees, it does not need to sign any instruction.
+// When empty, it falls back to the first signer of the first instruction
+func (b *TransactionBuilder) SetFeePayer(payer common.Address) *TransactionBuilder {
+	b.feePayer = payer
+	return b
+}
+
+// SetRecentBlockhash set the recent blockhash of the transaction
+func (b *TransactionBuilder) SetRecentBlockhash(recentBlockhash common.Hash) *TransactionBuilder {
+	b.recentBlockhash = recentBlockhash
+	return b
+}
+
+// AddComputeBudget prepend the SetComputeUnitLimit and SetComputeUnitPrice instructions,
+// a zero units or microLamports skips the instruction, as does an instruction of the same
+// kind already added with AddInstruction
+func (b *TransactionBuilder) AddComputeBudget(units uint32, microLamports uint64) *TransactionBuilder {
+	b.computeUnitLimit = units
+	b.computeUnitPrice = microLamports
+	return b
+}
+
+// ComputeBudgetEstimator estimates the compute units and the priority fee of a transaction,
+// it is implemented by solclient.Client
+type ComputeBudgetEstimator interface {
+	EstimateComputeUnits(ctx context.Context, tx *Transaction) (uint64, error)
+	GetRecentPrioritizationFees(ctx context.Context, args ...interface{}) ([]RpcPrioritizationFee, error)
+}
+
+// WithAutoComputeBudget simulates the transaction to estimate its compute units, then sets the compute
+// budget like AddComputeBudget: the unit limit is the consumed units plus marginPct percent, the unit price
+// is the median of the recent prioritization fees of the writable accounts. On error the builder is unchanged
+func (b *TransactionBuilder) WithAutoComputeBudget(ctx context.Context, estimator ComputeBudgetEstimator, marginPct uint64) error {
+	// simulate a copy with the maximum limit, so the compute budget instructions are accounted for
+	sim := *b
+	sim.computeUnitLimit, sim.computeUnitPrice = MaxComputeUnitLimit, 1
+	tx, err := sim.Build()
+	if err != nil {
+		return err
+	}
+	consumed, err := estimator.EstimateComputeUnits(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("unable to estimate compute units: %w", err)
+	}
+	writable := tx.Message.Writable()
+	// getRecentPrioritizationFees accepts up to 128 accounts
+	if len(writable) > 128 {
+		writable = writable[:128]
+	}
+	fees, err := estimator.GetRecentPrioritizationFees(ctx, writable)
+	if err != nil {
+		return fmt.Errorf("unable to get recent prioritization fees: %w", err)
+	}
+	units := consumed * (100 + marginPct) / 100
+	if units > MaxComputeUnitLimit {
+		units = MaxComputeUnitLimit
+	}
+	b.computeUnitLimit = uint32(units)
+	b.computeUnitPrice = RpcPrioritizationFees(fees).Median()
+	return nil
+}
+
+// Build compiles the instructions into the transaction message
+func (b *TransactionBuilder) Build() (*Transaction, error) {
+	var instructions []Instruction
+	if b.computeUnitLimit > 0 && !b.hasComputeBudgetInstruction(computebudget.Instruction_SetComputeUnitLimit) {
+		instructions = append(instructions, computebudget.NewSetComputeUnitLimitInstruction(b.computeUnitLimit).Build())
+	}
+	if b.computeUnitPrice > 0 && !b.hasComputeBudgetInstruction(computebudget.Instruction_SetComputeUnitPrice) {
+		instructions = append(instructions, computebudget.NewSetComputeUnitPriceInstruction(b.computeUnitPrice).Build())
+	}
+	instructions = append(instructions, b.instructions...)
+
+	if len(b.instructions) == 0 {
+		return nil, fmt.Errorf("requires at-least one instruction to create a transaction")
+	}
+
+	feePayer := b.feePayer
+	if feePayer.IsEmpty() {
+		found := false
+		for _, act := range b.instructions[0].Accounts() {
+			if act.IsSigner {
+				feePayer = act.PublicKey
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("cannot determine fee payer. You can ether pass the fee payer via SetFeePayer or it falls back to the first instruction's first signer")
+		}
+	}
+
+	finalAccounts := compileAccounts(instructions, feePayer)
+
+	message := Message{
+		RecentBlockhash: b.recentBlockhash,
+	}
+	accountKeyIndex := map[common.Address]uint16{}
+	for idx, acc := range finalAccounts {
+		message.AccountKeys = append(message.AccountKeys, acc.PublicKey)
+		accountKeyIndex[acc.PublicKey] = uint16(idx)
+		if acc.IsSigner {
+			message.Header.NumRequiredSignatures++
+			if !acc.IsWritable {
+				message.Header.NumReadonlySignedAccounts++
+			}
+			continue
+		}
+
+		if !acc.IsWritable {
+			message.Header.NumReadonlyUnsignedAccounts++
+		}
+	}
+
+	for txIdx, instruction := range instructions {
+		accounts := instruction.Accounts()
+		accountIndex := make([]uint16, len(accounts))
+		for idx, acc := range accounts {
+			accountIndex[idx] = accountKeyIndex[acc.PublicKey]
+		}
+		data, err := instruction.Data()
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode instructions [%d]: %w", txIdx, err)
+		}
+		message.Instructions = append(message.Instructions, CompiledInstruction{
+			ProgramIDIndex: accountKeyIndex[instruction.ProgramID()],
+			Accounts:       accountIndex,
+			Data:           data,
+		})
+	}
+
+	return &Transaction{
+		Message: message,
+	}, nil
+}
+
+// Sign builds the transaction and signs it with the accounts, every signer must be present
+func (b *TransactionBuilder) Sign(accounts ...crypto.Account) (*Transaction, error) {
+	return b.SignWith(crypto.Signers(accounts)...)
+}
+
+// SignWith builds the transaction and signs it with the signers, every signer must be present
+func (b *TransactionBuilder) SignWith(signers ...crypto.Signer) (*Transaction, error) {
+	tx, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+	if _, err = tx.SignWith(signers); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// hasComputeBudgetInstruction reports whether a compute budget instruction of the kind was added
+func (b *TransactionBuilder) hasComputeBudgetInstruction(kind uint8) bool {
+	for _, instruction := range b.instructions {
+		if instruction.ProgramID() != base.ComputeBudgetProgramID {
+			continue
+		}
+		data, err := instruction.Data()
+		if err == nil && isComputeBudgetInstruction(instruction.ProgramID(), data, kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// compileAccounts returns the de-duplicated accounts of the instructions and their programs, with the
+// fee payer first. As the compiled keys of solana-web3.js, the flags of an account are merged over all
+// its occurrences, then the accounts are grouped by writable signers, readonly signers, writable
+// non-signers and readonly non-signers, in the order of their first appearance. The program of
+// an instruction appears before its accounts
+func compileAccounts(instructions []Instruction, feePayer common.Address) []*base.AccountMeta {
+	var (
+		accounts     = []*base.AccountMeta{{PublicKey: feePayer, IsSigner: true, IsWritable: true}}
+		accountIndex = map[common.Address]int{feePayer: 0}
+	)
+	getOrInsert := func(account common.Address) *base.AccountMeta {
+		if idx, ok := accountIndex[account]; ok {
+			return accounts[idx]
+		}
+		accountIndex[account] = len(accounts)
+		accounts = append(accounts, &base.AccountMeta{PublicKey: account})
+		return accounts[len(accounts)-1]
+	}
+	for _, instruction := range instructions {
+		getOrInsert(instruction.ProgramID())
+		for _, acc := range instruction.Accounts() {
+			// the metas of the instructions must not be modified
+			meta := getOrInsert(acc.PublicKey)
+			meta.IsSigner = meta.IsSigner || acc.IsSigner
+			meta.IsWritable = meta.IsWritable || acc.IsWritable
+		}
+	}
+
+	// Sort. Prioritizing first by signer, then by writable, the fee payer stays first
+	sort.SliceStable(accounts, func(i, j int) bool {
+		return accounts[i].Less(accounts[j])
+	})
+	return accounts
+}
@@ -0,0 +1,1098 @@
+package types
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/crypto"
+	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/types/base"
+	computebudget "github.com/cielu/go-solana/types/compute-budget"
+)
+
+type testInstruction struct {
+	programID common.Address
+	accounts  []*base.AccountMeta
+	data      []byte
+}
+
+func (inst *testInstruction) ProgramID() common.Address     { return inst.programID }
+func (inst *testInstruction) Accounts() []*base.AccountMeta { return inst.accounts }
+func (inst *testInstruction) Data() ([]byte, error)         { return inst.data, nil }
+
+func mustAccount(t *testing.T) crypto.Account {
+	account, err := crypto.GenerateAccount()
+	if err != nil {
+		t.Fatalf("GenerateAccount Failed: %s", err.Error())
+	}
+	return account
+}
+
+// recordingSigner a remote signer recording the signed messages
+type recordingSigner struct {
+	account  crypto.Account
+	messages [][]byte
+	err      error
+}
+
+func (s *recordingSigner) PublicKey() common.Address { return s.account.Address }
+
+func (s *recordingSigner) SignMessage(message []byte) (common.Signature, error) {
+	s.messages = append(s.messages, message)
+	if s.err != nil {
+		return common.Signature{}, s.err
+	}
+	return common.BytesToSignature(s.account.Sign(message)), nil
+}
+
+func TestTransactionSignWith(t *testing.T) {
+	var (
+		payer     = mustAccount(t)
+		remote    = &recordingSigner{account: mustAccount(t)}
+		blockHash = common.Base58ToHash("EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N")
+	)
+	inst := &testInstruction{
+		programID: base.SystemProgramID,
+		accounts: []*base.AccountMeta{
+			base.Meta(remote.account.Address).WRITE().SIGNER(),
+			base.Meta(payer.Address).WRITE(),
+		},
+		data: []byte{2, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0},
+	}
+	tx, err := NewTransactionBuilder().AddInstruction(inst).SetFeePayer(payer.Address).SetRecentBlockhash(blockHash).
+		SignWith(payer, remote)
+	if err != nil {
+		t.Fatalf("SignWith Failed: %s", err)
+	}
+	message, err := tx.Message.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary Failed: %s", err)
+	}
+	if len(remote.messages) != 1 || !bytes.Equal(remote.messages[0], message) {
+		t.Errorf("SignMessage Err ==> Got %x, Want: %x", remote.messages, message)
+	}
+	if missing, err := tx.VerifySignatures(); err != nil || len(missing) != 0 {
+		t.Errorf("VerifySignatures Err ==> Got %v %v, Want: all signed", missing, err)
+	}
+	// the signer error is returned
+	remote.err = errors.New("device locked")
+	tx.Signatures = nil
+	if _, err = tx.SignWith([]crypto.Signer{payer, remote}); !errors.Is(err, remote.err) {
+		t.Errorf("SignWith Err ==> Got %v, Want: %s", err, remote.err)
+	}
+	tx.Signatures = nil
+	if err = tx.PartialSignWith([]crypto.Signer{remote}); !errors.Is(err, remote.err) {
+		t.Errorf("PartialSignWith Err ==> Got %v, Want: %s", err, remote.err)
+	}
+}
+
+func TestTransactionPartialSign(t *testing.T) {
+	var (
+		payer     = mustAccount(t)
+		owner     = mustAccount(t)
+		blockHash = common.Base58ToHash("EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N")
+	)
+	inst := &testInstruction{
+		programID: base.SystemProgramID,
+		accounts: []*base.AccountMeta{
+			base.Meta(owner.Address).WRITE().SIGNER(),
+			base.Meta(payer.Address).WRITE(),
+		},
+		data: []byte{2, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0},
+	}
+	tx, err := NewTransaction([]Instruction{inst}, blockHash, payer.Address)
+	if err != nil {
+		t.Fatalf("NewTransaction Failed: %s", err.Error())
+	}
+	if tx.Message.Header.NumRequiredSignatures != 2 {
+		t.Fatalf("NumRequiredSignatures Err ==> Got %d, Want: %d", tx.Message.Header.NumRequiredSignatures, 2)
+	}
+	// payer signs first
+	if err = tx.PartialSign([]crypto.Account{payer}); err != nil {
+		t.Fatalf("PartialSign Failed: %s", err.Error())
+	}
+	missing, err := tx.VerifySignatures()
+	if err != nil {
+		t.Fatalf("VerifySignatures Failed: %s", err.Error())
+	}
+	if len(missing) != 1 || missing[0] != owner.Address {
+		t.Fatalf("VerifySignatures missing Err ==> Got %v, Want: [%s]", missing, owner.Address)
+	}
+	if _, err = tx.MarshalBinary(); err != nil {
+		t.Errorf("MarshalBinary with placeholder Failed: %s", err.Error())
+	}
+	// owner signs on another device
+	messageContent, err := tx.Message.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Message MarshalBinary Failed: %s", err.Error())
+	}
+	ownerSig := common.BytesToSignature(owner.Sign(messageContent))
+	if err = tx.AddSignature(owner.Address, ownerSig); err != nil {
+		t.Fatalf("AddSignature Failed: %s", err.Error())
+	}
+	if tx.Signatures[1] != ownerSig {
+		t.Errorf("AddSignature index Err ==> Got %s, Want: %s", tx.Signatures[1], ownerSig)
+	}
+	missing, err = tx.VerifySignatures()
+	if err != nil {
+		t.Fatalf("VerifySignatures Failed: %s", err.Error())
+	}
+	if len(missing) != 0 {
+		t.Errorf("VerifySignatures missing Err ==> Got %v, Want: []", missing)
+	}
+	// not a signer
+	if err = tx.AddSignature(mustAccount(t).Address, ownerSig); err == nil {
+		t.Errorf("AddSignature with unknown signer should fail")
+	}
+	// wrong signature
+	if err = tx.AddSignature(payer.Address, ownerSig); err != nil {
+		t.Fatalf("AddSignature Failed: %s", err.Error())
+	}
+	if _, err = tx.VerifySignatures(); err == nil {
+		t.Errorf("VerifySignatures with invalid signature should fail")
+	}
+}
+
+func TestTransactionMarshalEmpty(t *testing.T) {
+	var (
+		payer     = mustAccount(t)
+		blockHash = common.Base58ToHash("EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N")
+	)
+	// empty transaction
+	if _, err := (&Transaction{}).MarshalBinary(); !errors.Is(err, ErrTxNoInstructions) {
+		t.Errorf("MarshalBinary Err ==> Got %v, Want: %v", err, ErrTxNoInstructions)
+	}
+	// missing blockhash
+	inst := &testInstruction{
+		programID: base.MemoProgramID,
+		accounts:  []*base.AccountMeta{base.Meta(payer.Address).WRITE().SIGNER()},
+		data:      []byte("memo"),
+	}
+	tx, err := NewTransaction([]Instruction{inst}, common.Hash{}, payer.Address)
+	if err != nil {
+		t.Fatalf("NewTransaction Failed: %s", err.Error())
+	}
+	if _, err = tx.Sign([]crypto.Account{payer}); !errors.Is(err, ErrTxNoBlockhash) {
+		t.Errorf("MarshalBinary Err ==> Got %v, Want: %v", err, ErrTxNoBlockhash)
+	}
+	// valid
+	tx, _ = NewTransaction([]Instruction{inst}, blockHash, payer.Address)
+	if _, err = tx.Sign([]crypto.Account{payer}); err != nil {
+		t.Errorf("MarshalBinary Failed: %s", err)
+	}
+}
+
+func TestTransactionValidate(t *testing.T) {
+	var (
+		payer     = mustAccount(t)
+		receiver  = mustAccount(t)
+		blockHash = common.Base58ToHash("EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N")
+	)
+	inst := &testInstruction{
+		programID: base.SystemProgramID,
+		accounts: []*base.AccountMeta{
+			base.Meta(payer.Address).WRITE().SIGNER(),
+			base.Meta(receiver.Address).WRITE(),
+		},
+		data: []byte{2, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0},
+	}
+	tests := []struct {
+		name      string
+		malformed func(msg *Message)
+	}{
+		{"valid", func(msg *Message) {}},
+		{"readonly fee payer", func(msg *Message) { msg.Header.NumReadonlySignedAccounts = 1 }},
+		{"header exceeds account keys", func(msg *Message) { msg.Header.NumReadonlyUnsignedAccounts = 3 }},
+		{"duplicate account key", func(msg *Message) { msg.AccountKeys[1] = payer.Address }},
+		{"program index out of range", func(msg *Message) { msg.Instructions[0].ProgramIDIndex = 3 }},
+		{"account index out of range", func(msg *Message) { msg.Instructions[0].Accounts[1] = 3 }},
+		{"signer program", func(msg *Message) { msg.Instructions[0].ProgramIDIndex = 0 }},
+		{"writable program", func(msg *Message) { msg.Header.NumReadonlyUnsignedAccounts = 0 }},
+	}
+	for _, tt := range tests {
+		tx, err := NewTransaction([]Instruction{inst}, blockHash, payer.Address)
+		if err != nil {
+			t.Fatalf("NewTransaction Failed: %s", err)
+		}
+		tt.malformed(&tx.Message)
+		tx.Signatures = []common.Signature{{1}}
+		err = tx.Validate()
+		if tt.name == "valid" {
+			if err != nil {
+				t.Errorf("%s: Validate Err ==> Got %v, Want: nil", tt.name, err)
+			}
+			continue
+		}
+		if !errors.Is(err, ErrTxInvalidMessage) {
+			t.Errorf("%s: Validate Err ==> Got %v, Want: %s", tt.name, err, ErrTxInvalidMessage)
+		}
+		if _, err = tx.MarshalBinary(); !errors.Is(err, ErrTxInvalidMessage) {
+			t.Errorf("%s: MarshalBinary Err ==> Got %v, Want: %s", tt.name, err, ErrTxInvalidMessage)
+		}
+	}
+}
+
+func TestTransactionBuilderFeePayer(t *testing.T) {
+	var (
+		payer     = mustAccount(t)
+		owner     = mustAccount(t)
+		receiver  = mustAccount(t)
+		blockHash = common.Base58ToHash("EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N")
+	)
+	ownerMeta := base.Meta(owner.Address).WRITE().SIGNER()
+	inst := &testInstruction{
+		programID: base.SystemProgramID,
+		accounts: []*base.AccountMeta{
+			ownerMeta,
+			base.Meta(receiver.Address).WRITE(),
+		},
+		data: []byte{2, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0},
+	}
+	tx, err := NewTransactionBuilder().
+		AddInstruction(inst).
+		SetFeePayer(payer.Address).
+		SetRecentBlockhash(blockHash).
+		AddComputeBudget(200_000, 1000).
+		Sign(owner, payer)
+	if err != nil {
+		t.Fatalf("TransactionBuilder Sign Failed: %s", err)
+	}
+	msg := tx.Message
+	wantKeys := []common.Address{payer.Address, owner.Address, receiver.Address}
+	for i, key := range wantKeys {
+		if msg.AccountKeys[i] != key {
+			t.Errorf("AccountKeys[%d] Err ==> Got %s, Want: %s", i, msg.AccountKeys[i], key)
+		}
+	}
+	wantHeader := MessageHeader{NumRequiredSignatures: 2, NumReadonlySignedAccounts: 0, NumReadonlyUnsignedAccounts: 2}
+	if msg.Header != wantHeader {
+		t.Errorf("Header Err ==> Got %+v, Want: %+v", msg.Header, wantHeader)
+	}
+	if len(msg.Instructions) != 3 {
+		t.Fatalf("Instructions length Err ==> Got %d, Want: %d", len(msg.Instructions), 3)
+	}
+	for i := 0; i < 2; i++ {
+		if program := msg.AccountKeys[msg.Instructions[i].ProgramIDIndex]; program != base.ComputeBudgetProgramID {
+			t.Errorf("Instructions[%d] program Err ==> Got %s, Want: %s", i, program, base.ComputeBudgetProgramID)
+		}
+	}
+	if missing, err := tx.VerifySignatures(); err != nil || len(missing) != 0 {
+		t.Errorf("VerifySignatures Err ==> Got %v, %v", missing, err)
+	}
+	// the instruction account metas are left untouched
+	if !ownerMeta.IsSigner || !ownerMeta.IsWritable || inst.accounts[1].IsSigner {
+		t.Errorf("instruction accounts were modified")
+	}
+	// a missing signer fails
+	if _, err = NewTransactionBuilder().AddInstruction(inst).SetFeePayer(payer.Address).SetRecentBlockhash(blockHash).Sign(payer); err == nil {
+		t.Errorf("Sign without the owner should fail")
+	}
+}
+
+func TestTransactionWithLoadedAddresses(t *testing.T) {
+	var (
+		payer          = mustAccount(t)
+		receiver       = mustAccount(t).Address
+		table          = mustAccount(t).Address
+		loadedWritable = mustAccount(t).Address
+		loadedReadonly = mustAccount(t).Address
+		blockHash      = common.Base58ToHash("EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N")
+	)
+	// v0 transaction: [payer, receiver, system program] + lookup (writable 0, readonly 1)
+	raw := []byte{1}
+	raw = append(raw, make([]byte, 64)...)
+	raw = append(raw, 0x80, 1, 0, 1, 3)
+	raw = append(raw, payer.Address[:]...)
+	raw = append(raw, receiver[:]...)
+	raw = append(raw, base.SystemProgramID[:]...)
+	raw = append(raw, blockHash[:]...)
+	raw = append(raw, 1, 2, 4, 0, 1, 3, 4, 0)
+	raw = append(raw, 1)
+	raw = append(raw, table[:]...)
+	raw = append(raw, 1, 0, 1, 1)
+
+	var tx Transaction
+	if err := tx.UnmarshalWithDecoder(encodbin.NewBinDecoder(raw)); err != nil {
+		t.Fatalf("UnmarshalWithDecoder Failed: %s", err)
+	}
+	meta := TransactionMeta{LoadedAddresses: LoadedAddresses{
+		Writable: []common.Address{loadedWritable},
+		ReadOnly: []common.Address{loadedReadonly},
+	}}
+	if tx.Message.IsWritable(loadedWritable) {
+		t.Errorf("IsWritable without loaded addresses Err ==> Got true, Want: false")
+	}
+	message := tx.WithLoadedAddresses(meta)
+	if len(message.AccountKeys) != 5 || message.AccountKeys[3] != loadedWritable || message.AccountKeys[4] != loadedReadonly {
+		t.Fatalf("AccountKeys Err ==> Got %v", message.AccountKeys)
+	}
+	want := map[common.Address]bool{
+		payer.Address:        true,
+		receiver:             true,
+		base.SystemProgramID: false,
+		loadedWritable:       true,
+		loadedReadonly:       false,
+	}
+	for account, writable := range want {
+		if got := message.IsWritable(account); got != writable {
+			t.Errorf("IsWritable(%s) Err ==> Got %v, Want: %v", account, got, writable)
+		}
+	}
+	if signers := message.Signers(); len(signers) != 1 || signers[0] != payer.Address {
+		t.Errorf("Signers Err ==> Got %v, Want: [%s]", signers, payer.Address)
+	}
+	// the transaction is left untouched
+	if len(tx.Message.AccountKeys) != 3 {
+		t.Errorf("transaction AccountKeys Err ==> Got %d, Want: %d", len(tx.Message.AccountKeys), 3)
+	}
+}
+
+func TestMessageResolveLookups(t *testing.T) {
+	var (
+		payer     = mustAccount(t)
+		receiver  = mustAccount(t).Address
+		tableA    = mustAccount(t).Address
+		tableB    = mustAccount(t).Address
+		blockHash = common.Base58ToHash("EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N")
+		addresses = make([]common.Address, 6)
+	)
+	for i := range addresses {
+		addresses[i] = mustAccount(t).Address
+	}
+	// v0 message: [payer, receiver, system program]
+	// + lookup A (writable 1, readonly 0, 2) + lookup B (writable 0, readonly 1)
+	raw := []byte{0x80, 1, 0, 1, 3}
+	raw = append(raw, payer.Address[:]...)
+	raw = append(raw, receiver[:]...)
+	raw = append(raw, base.SystemProgramID[:]...)
+	raw = append(raw, blockHash[:]...)
+	raw = append(raw, 1, 2, 4, 0, 3, 5, 6, 0)
+	raw = append(raw, 2)
+	raw = append(raw, tableA[:]...)
+	raw = append(raw, 1, 1, 2, 0, 2)
+	raw = append(raw, tableB[:]...)
+	raw = append(raw, 1, 0, 1, 1)
+
+	var message Message
+	if err := message.UnmarshalWithDecoder(encodbin.NewBinDecoder(raw)); err != nil {
+		t.Fatalf("UnmarshalWithDecoder Failed: %s", err)
+	}
+	if err := message.ResolveLookups(); err == nil {
+		t.Errorf("ResolveLookups without address tables should fail")
+	}
+	message.SetAddressTables(map[common.Address][]common.Address{
+		tableA: addresses[:3],
+		tableB: addresses[3:],
+	})
+	if err := message.ResolveLookups(); err != nil {
+		t.Fatalf("ResolveLookups Failed: %s", err)
+	}
+	want := []common.Address{
+		payer.Address, receiver, base.SystemProgramID,
+		addresses[1], addresses[3],
+		addresses[0], addresses[2], addresses[4],
+	}
+	if len(message.AccountKeys) != len(want) {
+		t.Fatalf("AccountKeys Err ==> Got %v, Want: %v", message.AccountKeys, want)
+	}
+	for i := range want {
+		if message.AccountKeys[i] != want[i] {
+			t.Errorf("AccountKeys[%d] Err ==> Got %s, Want: %s", i, message.AccountKeys[i], want[i])
+		}
+	}
+	for i, account := range want {
+		writable := i < 2 || i == 3 || i == 4
+		if got := message.IsWritable(account); got != writable {
+			t.Errorf("IsWritable(%d) Err ==> Got %v, Want: %v", i, got, writable)
+		}
+		if got := message.IsSigner(account); got != (i == 0) {
+			t.Errorf("IsSigner(%d) Err ==> Got %v, Want: %v", i, got, i == 0)
+		}
+	}
+	// resolving twice is a no-op, the message is still encoded with its static keys
+	if err := message.ResolveLookups(); err != nil || len(message.AccountKeys) != len(want) {
+		t.Errorf("ResolveLookups twice Err ==> Got %d keys, %v", len(message.AccountKeys), err)
+	}
+	if data, err := message.MarshalBinary(); err != nil || data[0] != 0x80 || data[4] != 3 {
+		t.Errorf("MarshalBinary Err ==> Got %v, %v", data, err)
+	}
+}
+
+func TestBlockTransactionResolvedAccountKeys(t *testing.T) {
+	var (
+		payer     = mustAccount(t)
+		table     = mustAccount(t).Address
+		blockHash = common.Base58ToHash("EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N")
+		loaded    = make([]common.Address, 5)
+	)
+	for i := range loaded {
+		loaded[i] = mustAccount(t).Address
+	}
+	// v0 transaction: [payer, memo program] + lookup (writable 0, 1, readonly 2, 3, 4),
+	// the memo instruction refers to the payer, a writable and a readonly loaded address
+	raw := []byte{1}
+	raw = append(raw, make([]byte, 64)...)
+	raw = append(raw, 0x80, 1, 0, 1, 2)
+	raw = append(raw, payer.Address[:]...)
+	raw = append(raw, base.MemoProgramID[:]...)
+	raw = append(raw, blockHash[:]...)
+	raw = append(raw, 1, 1, 3, 0, 3, 6, 4, 'm', 'e', 'm', 'o')
+	raw = append(raw, 1)
+	raw = append(raw, table[:]...)
+	raw = append(raw, 2, 0, 1, 3, 2, 3, 4)
+
+	resp := fmt.Sprintf(`{
+		"slot": 1,
+		"meta": {"err": null, "loadedAddresses": {"writable": ["%s", "%s"], "readonly": ["%s", "%s", "%s"]}},
+		"transaction": ["%s", "base64"],
+		"version": 0
+	}`, loaded[0], loaded[1], loaded[2], loaded[3], loaded[4], base64.StdEncoding.EncodeToString(raw))
+	var bt BlockTransaction
+	if err := json.Unmarshal([]byte(resp), &bt); err != nil {
+		t.Fatalf("Unmarshal BlockTransaction Failed: %s", err)
+	}
+	keys := bt.ResolvedAccountKeys()
+	want := append([]common.Address{payer.Address, base.MemoProgramID}, loaded...)
+	if len(keys) != len(want) {
+		t.Fatalf("ResolvedAccountKeys Err ==> Got %v, Want: %v", keys, want)
+	}
+	for i := range want {
+		if keys[i] != want[i] {
+			t.Errorf("ResolvedAccountKeys[%d] Err ==> Got %s, Want: %s", i, keys[i], want[i])
+		}
+	}
+	inst := bt.Transaction.Message.Instructions[0]
+	if keys[inst.ProgramIDIndex] != base.MemoProgramID || keys[inst.Accounts[1]] != loaded[1] || keys[inst.Accounts[2]] != loaded[4] {
+		t.Errorf("instruction accounts Err ==> Got %v", inst)
+	}
+	// the message account keys are left untouched
+	if len(bt.Transaction.Message.AccountKeys) != 2 {
+		t.Errorf("AccountKeys Err ==> Got %d, Want: %d", len(bt.Transaction.Message.AccountKeys), 2)
+	}
+	// an invalid encoded transaction
+	if err := json.Unmarshal([]byte(`{"transaction": ["AQ==", "base64"]}`), &bt); err == nil {
+		t.Errorf("Unmarshal invalid transaction should fail")
+	}
+}
+
+// failingEstimator a ComputeBudgetEstimator whose simulation fails
+type failingEstimator struct{}
+
+func (failingEstimator) EstimateComputeUnits(context.Context, *Transaction) (uint64, error) {
+	return 0, errors.New("simulation failed")
+}
+
+func (failingEstimator) GetRecentPrioritizationFees(context.Context, ...interface{}) ([]RpcPrioritizationFee, error) {
+	return nil, nil
+}
+
+func TestTransactionBuilderAutoComputeBudgetError(t *testing.T) {
+	payer := mustAccount(t)
+	builder := NewTransactionBuilder().
+		AddInstruction(&testInstruction{
+			programID: base.MemoProgramID,
+			accounts:  []*base.AccountMeta{base.Meta(payer.Address).WRITE().SIGNER()},
+			data:      []byte("memo"),
+		}).
+		SetRecentBlockhash(common.Base58ToHash("EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N")).
+		AddComputeBudget(0, 1000)
+	before, err := builder.Build()
+	if err != nil {
+		t.Fatalf("TransactionBuilder Build Failed: %s", err)
+	}
+	if err = builder.WithAutoComputeBudget(context.Background(), failingEstimator{}, 10); err == nil {
+		t.Fatalf("WithAutoComputeBudget with a failed simulation should fail")
+	}
+	after, err := builder.Build()
+	if err != nil {
+		t.Fatalf("TransactionBuilder Build Failed: %s", err)
+	}
+	want, _ := before.Message.MarshalBinary()
+	got, _ := after.Message.MarshalBinary()
+	if !bytes.Equal(got, want) {
+		t.Errorf("Build after failed WithAutoComputeBudget Err ==> Got %v, Want: %v", after.Message.Instructions, before.Message.Instructions)
+	}
+}
+
+func TestTransactionBuilderExistingComputeBudget(t *testing.T) {
+	var (
+		payer     = mustAccount(t)
+		blockHash = common.Base58ToHash("EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N")
+	)
+	inst := &testInstruction{
+		programID: base.MemoProgramID,
+		accounts:  []*base.AccountMeta{base.Meta(payer.Address).WRITE().SIGNER()},
+		data:      []byte("memo"),
+	}
+	tx, err := NewTransactionBuilder().
+		AddInstruction(computebudget.NewSetComputeUnitLimitInstruction(300_000).Build(), inst).
+		SetFeePayer(payer.Address).
+		SetRecentBlockhash(blockHash).
+		AddComputeBudget(200_000, 1000).
+		Build()
+	if err != nil {
+		t.Fatalf("TransactionBuilder Build Failed: %s", err)
+	}
+	msg := tx.Message
+	if !msg.HasComputeBudgetInstruction(computebudget.Instruction_SetComputeUnitLimit) ||
+		!msg.HasComputeBudgetInstruction(computebudget.Instruction_SetComputeUnitPrice) {
+		t.Errorf("HasComputeBudgetInstruction Err ==> Got false, Want: true")
+	}
+	if msg.HasComputeBudgetInstruction(computebudget.Instruction_RequestHeapFrame) {
+		t.Errorf("HasComputeBudgetInstruction RequestHeapFrame Err ==> Got true, Want: false")
+	}
+	// the existing limit is kept, only the price is prepended
+	if len(msg.Instructions) != 3 {
+		t.Fatalf("Instructions length Err ==> Got %d, Want: %d", len(msg.Instructions), 3)
+	}
+	limits := 0
+	for _, instruction := range msg.Instructions {
+		if isComputeBudgetInstruction(msg.AccountKeys[instruction.ProgramIDIndex], instruction.Data, computebudget.Instruction_SetComputeUnitLimit) {
+			limits++
+			if units := binary.LittleEndian.Uint32(instruction.Data[1:]); units != 300_000 {
+				t.Errorf("compute unit limit Err ==> Got %d, Want: %d", units, 300_000)
+			}
+		}
+	}
+	if limits != 1 {
+		t.Errorf("SetComputeUnitLimit count Err ==> Got %d, Want: %d", limits, 1)
+	}
+}
+
+func TestTransactionSetFeePayer(t *testing.T) {
+	var (
+		owner     = mustAccount(t)
+		relayer   = mustAccount(t)
+		receiver  = mustAccount(t).Address
+		blockHash = common.Base58ToHash("EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N")
+	)
+	inst := &testInstruction{
+		programID: base.SystemProgramID,
+		accounts: []*base.AccountMeta{
+			base.Meta(owner.Address).WRITE().SIGNER(),
+			base.Meta(receiver).WRITE(),
+		},
+		data: []byte{2, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0},
+	}
+	tx, err := NewTransactionBuilder().AddInstruction(inst).SetRecentBlockhash(blockHash).Sign(owner)
+	if err != nil {
+		t.Fatalf("TransactionBuilder Sign Failed: %s", err)
+	}
+	if err = tx.SetFeePayer(relayer.Address); err != nil {
+		t.Fatalf("SetFeePayer Failed: %s", err)
+	}
+	msg := tx.Message
+	wantKeys := []common.Address{relayer.Address, owner.Address, receiver, base.SystemProgramID}
+	if len(msg.AccountKeys) != len(wantKeys) {
+		t.Fatalf("AccountKeys Err ==> Got %v, Want: %v", msg.AccountKeys, wantKeys)
+	}
+	for i, key := range wantKeys {
+		if msg.AccountKeys[i] != key {
+			t.Errorf("AccountKeys[%d] Err ==> Got %s, Want: %s", i, msg.AccountKeys[i], key)
+		}
+	}
+	wantHeader := MessageHeader{NumRequiredSignatures: 2, NumReadonlySignedAccounts: 0, NumReadonlyUnsignedAccounts: 1}
+	if msg.Header != wantHeader {
+		t.Errorf("Header Err ==> Got %+v, Want: %+v", msg.Header, wantHeader)
+	}
+	if len(tx.Signatures) != 0 {
+		t.Errorf("Signatures Err ==> Got %d, Want: %d", len(tx.Signatures), 0)
+	}
+	// the instruction still points to the same accounts
+	compiled := msg.Instructions[0]
+	if msg.AccountKeys[compiled.ProgramIDIndex] != base.SystemProgramID {
+		t.Errorf("ProgramIDIndex Err ==> Got %s, Want: %s", msg.AccountKeys[compiled.ProgramIDIndex], base.SystemProgramID)
+	}
+	for i, meta := range inst.accounts {
+		if got := msg.AccountKeys[compiled.Accounts[i]]; got != meta.PublicKey {
+			t.Errorf("Accounts[%d] Err ==> Got %s, Want: %s", i, got, meta.PublicKey)
+		}
+	}
+	// both the relayer and the owner sign
+	if _, err = tx.Sign([]crypto.Account{owner, relayer}); err != nil {
+		t.Fatalf("Sign Failed: %s", err)
+	}
+	if missing, err := tx.VerifySignatures(); err != nil || len(missing) != 0 {
+		t.Errorf("VerifySignatures Err ==> Got %v, %v", missing, err)
+	}
+}
+
+func TestTransactionRequiredSigners(t *testing.T) {
+	var (
+		payer     = mustAccount(t)
+		owner     = mustAccount(t)
+		delegate  = mustAccount(t)
+		blockHash = common.Base58ToHash("EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N")
+	)
+	inst := &testInstruction{
+		programID: base.MemoProgramID,
+		accounts: []*base.AccountMeta{
+			base.Meta(owner.Address).WRITE().SIGNER(),
+			base.Meta(delegate.Address).SIGNER(),
+			base.Meta(mustAccount(t).Address).WRITE(),
+		},
+		data: []byte("memo"),
+	}
+	tx, err := NewTransactionBuilder().AddInstruction(inst).SetFeePayer(payer.Address).SetRecentBlockhash(blockHash).Build()
+	if err != nil {
+		t.Fatalf("TransactionBuilder Build Failed: %s", err)
+	}
+	if n := tx.Message.NumSigners(); n != 3 {
+		t.Errorf("NumSigners Err ==> Got %d, Want: %d", n, 3)
+	}
+	signers := tx.RequiredSigners()
+	want := []common.Address{payer.Address, owner.Address, delegate.Address}
+	if len(signers) != len(want) {
+		t.Fatalf("RequiredSigners Err ==> Got %v, Want: %v", signers, want)
+	}
+	for i := range want {
+		if signers[i] != want[i] {
+			t.Errorf("RequiredSigners[%d] Err ==> Got %s, Want: %s", i, signers[i], want[i])
+		}
+	}
+	// the returned slice is a copy
+	signers[0] = common.Address{}
+	if tx.Message.AccountKeys[0] != payer.Address {
+		t.Errorf("RequiredSigners modified the account keys")
+	}
+}
+
+type describeTransfer struct {
+	Lamports uint64
+}
+
+func TestMessageDescribe(t *testing.T) {
+	var (
+		payer     = mustAccount(t)
+		recipient = mustAccount(t)
+		blockHash = common.Base58ToHash("EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N")
+	)
+	// restore the system decoder replaced by the test
+	instructionDecoders.RLock()
+	previous, registered := instructionDecoders.m[base.SystemProgramID]
+	instructionDecoders.RUnlock()
+	t.Cleanup(func() {
+		if registered {
+			RegisterInstructionDecoder(base.SystemProgramID, previous)
+			return
+		}
+		instructionDecoders.Lock()
+		delete(instructionDecoders.m, base.SystemProgramID)
+		instructionDecoders.Unlock()
+	})
+	RegisterInstructionDecoder(base.SystemProgramID, func(accounts []*base.AccountMeta, data []byte) (interface{}, error) {
+		if len(data) != 12 || binary.LittleEndian.Uint32(data) != 2 {
+			return nil, errors.New("not a transfer")
+		}
+		if len(accounts) != 2 || !accounts[0].IsSigner || !accounts[1].IsWritable {
+			return nil, fmt.Errorf("invalid transfer accounts: %v", accounts)
+		}
+		return &describeTransfer{Lamports: binary.LittleEndian.Uint64(data[4:])}, nil
+	})
+	transfer := &testInstruction{
+		programID: base.SystemProgramID,
+		accounts: []*base.AccountMeta{
+			base.Meta(payer.Address).WRITE().SIGNER(),
+			base.Meta(recipient.Address).WRITE(),
+		},
+		data: []byte{2, 0, 0, 0, 0x40, 0x42, 0x0f, 0, 0, 0, 0, 0},
+	}
+	price := computebudget.NewSetComputeUnitPriceInstruction(1000).Build()
+	tx, err := NewTransaction([]Instruction{price, transfer}, blockHash, payer.Address)
+	if err != nil {
+		t.Fatalf("NewTransaction Failed: %s", err)
+	}
+	desc := tx.Message.Describe(map[common.Address]string{
+		payer.Address:        "my wallet",
+		base.SystemProgramID: "System Program",
+	})
+	if desc.FeePayer != payer.Address || desc.RecentBlockhash != blockHash || len(desc.Instructions) != 2 {
+		t.Fatalf("Describe Err ==> Got %+v", desc)
+	}
+	// the compute budget decoder is registered by the types package
+	if ins := desc.Instructions[0]; ins.ProgramID != base.ComputeBudgetProgramID || ins.Type != "SetComputeUnitPrice" || len(ins.Accounts) != 0 {
+		t.Errorf("Describe compute budget Err ==> Got %+v", ins)
+	}
+	if decoded, ok := desc.Instructions[0].Decoded.(*computebudget.SetComputeUnitPrice); !ok || decoded.MicroLamports != 1000 {
+		t.Errorf("Describe compute budget decoded Err ==> Got %#v, Want: 1000 micro lamports", desc.Instructions[0].Decoded)
+	}
+	ins := desc.Instructions[1]
+	if ins.ProgramID != base.SystemProgramID || ins.ProgramLabel != "System Program" || ins.Type != "describeTransfer" {
+		t.Errorf("Describe transfer Err ==> Got %s %s %s", ins.ProgramID, ins.ProgramLabel, ins.Type)
+	}
+	if decoded, ok := ins.Decoded.(*describeTransfer); !ok || decoded.Lamports != 1_000_000 {
+		t.Errorf("Describe decoded Err ==> Got %#v, Want: 1000000 lamports", ins.Decoded)
+	}
+	want := []AccountDescription{
+		{Address: payer.Address, Label: "my wallet", Signer: true, Writable: true},
+		{Address: recipient.Address, Writable: true},
+	}
+	if len(ins.Accounts) != len(want) || ins.Accounts[0] != want[0] || ins.Accounts[1] != want[1] {
+		t.Errorf("Describe accounts Err ==> Got %+v, Want: %+v", ins.Accounts, want)
+	}
+}
+
+func TestMessageMarshalV0(t *testing.T) {
+	var (
+		payer     = mustAccount(t)
+		receiver  = mustAccount(t).Address
+		table     = mustAccount(t).Address
+		blockHash = common.Base58ToHash("EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N")
+	)
+	// the expected v0 message: [payer, receiver, system program] + lookup (writable 0, readonly 1, 2)
+	want := []byte{0x80, 1, 0, 1, 3}
+	want = append(want, payer.Address[:]...)
+	want = append(want, receiver[:]...)
+	want = append(want, base.SystemProgramID[:]...)
+	want = append(want, blockHash[:]...)
+	want = append(want, 1, 2, 2, 0, 3, 2, 4, 0)
+	want = append(want, 1)
+	want = append(want, table[:]...)
+	want = append(want, 1, 0, 2, 1, 2)
+
+	message := Message{
+		AccountKeys:     []common.Address{payer.Address, receiver, base.SystemProgramID},
+		Header:          MessageHeader{NumRequiredSignatures: 1, NumReadonlyUnsignedAccounts: 1},
+		RecentBlockhash: blockHash,
+		Instructions:    []CompiledInstruction{{ProgramIDIndex: 2, Accounts: []uint16{0, 3}, Data: []byte{4, 0}}},
+	}
+	legacy, err := message.MarshalBinary()
+	if err != nil || legacy[0] != 1 {
+		t.Fatalf("MarshalBinary legacy Err ==> Got %v, %v", legacy, err)
+	}
+	message.SetAddressTableLookups(MessageAddressTableLookupSlice{
+		{AccountKey: table, WritableIndexes: []uint8{0}, ReadonlyIndexes: []uint8{1, 2}},
+	})
+	if !message.IsVersioned() || message.GetVersion() != MessageVersionV0 {
+		t.Fatalf("SetAddressTableLookups Err ==> Got version %d", message.GetVersion())
+	}
+	data, err := message.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary Failed: %s", err)
+	}
+	if string(data) != string(want) {
+		t.Fatalf("MarshalV0 Err ==> Got %v, Want: %v", data, want)
+	}
+	// round trip
+	b64, err := message.ToBase64()
+	if err != nil {
+		t.Fatalf("ToBase64 Failed: %s", err)
+	}
+	var decoded Message
+	if err = decoded.UnmarshalBase64(b64); err != nil {
+		t.Fatalf("UnmarshalBase64 Failed: %s", err)
+	}
+	if decoded.GetVersion() != MessageVersionV0 || len(decoded.AddressTableLookups()) != 1 || decoded.GetTableIDs()[0] != table {
+		t.Errorf("UnmarshalBase64 Err ==> Got version %d, lookups %+v", decoded.GetVersion(), decoded.AddressTableLookups())
+	}
+	if again, _ := decoded.MarshalV0(); string(again) != string(want) {
+		t.Errorf("MarshalV0 round trip Err ==> Got %v, Want: %v", again, want)
+	}
+
+	// a signed v0 transaction round trip, the signature covers the version prefix
+	tx := Transaction{Message: message}
+	raw, err := tx.Sign([]crypto.Account{payer})
+	if err != nil {
+		t.Fatalf("Sign Failed: %s", err)
+	}
+	var signed Transaction
+	if err = signed.UnmarshalWithDecoder(encodbin.NewBinDecoder(raw)); err != nil {
+		t.Fatalf("UnmarshalWithDecoder Failed: %s", err)
+	}
+	if _, err = signed.VerifySignatures(); err != nil {
+		t.Errorf("VerifySignatures Failed: %s", err)
+	}
+	if !signed.Message.IsVersioned() || len(signed.Message.AddressTableLookups()) != 1 {
+		t.Errorf("signed transaction Err ==> Got version %d", signed.Message.GetVersion())
+	}
+}
+
+func TestBlockTransactionToTransaction(t *testing.T) {
+	var (
+		payer     = mustAccount(t)
+		table     = mustAccount(t).Address
+		blockHash = common.Base58ToHash("EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N")
+	)
+	legacy := []byte{1}
+	legacy = append(legacy, make([]byte, 64)...)
+	legacy = append(legacy, 1, 0, 1, 2)
+	legacy = append(legacy, payer.Address[:]...)
+	legacy = append(legacy, base.MemoProgramID[:]...)
+	legacy = append(legacy, blockHash[:]...)
+	legacy = append(legacy, 1, 1, 1, 0, 4, 'm', 'e', 'm', 'o')
+	// the same message as v0, with a lookup
+	v0 := append([]byte{1}, make([]byte, 64)...)
+	v0 = append(v0, 0x80)
+	v0 = append(v0, legacy[65:]...)
+	v0 = append(v0, 1)
+	v0 = append(v0, table[:]...)
+	v0 = append(v0, 1, 0, 1, 1)
+
+	for name, raw := range map[string][]byte{"legacy": legacy, "v0": v0} {
+		var bt BlockTransaction
+		resp := fmt.Sprintf(`{"slot": 1, "transaction": ["%s", "base64"]}`, base64.StdEncoding.EncodeToString(raw))
+		if err := json.Unmarshal([]byte(resp), &bt); err != nil {
+			t.Fatalf("Unmarshal %s BlockTransaction Failed: %s", name, err)
+		}
+		if name == "v0" {
+			// the loaded addresses are not copied
+			bt.Transaction.Message.SetAddressTables(map[common.Address][]common.Address{table: {mustAccount(t).Address, mustAccount(t).Address}})
+			if err := bt.Transaction.Message.ResolveLookups(); err != nil || len(bt.Transaction.Message.AccountKeys) != 4 {
+				t.Fatalf("ResolveLookups Failed: %v", err)
+			}
+		}
+		tx, err := bt.ToTransaction()
+		if err != nil {
+			t.Fatalf("ToTransaction %s Failed: %s", name, err)
+		}
+		if len(tx.Message.AccountKeys) != 2 {
+			t.Errorf("%s AccountKeys Err ==> Got %v, Want: the 2 static keys", name, tx.Message.AccountKeys)
+		}
+		data, err := tx.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary %s Failed: %s", name, err)
+		}
+		if !bytes.Equal(data, raw) {
+			t.Errorf("%s round trip Err ==> Got %v, Want: %v", name, data, raw)
+		}
+		// the copy is independent of the fetched transaction
+		tx.Message.Instructions[0].Data[0] = 'M'
+		if err = tx.PartialSign([]crypto.Account{payer}); err != nil {
+			t.Fatalf("PartialSign %s Failed: %s", name, err)
+		}
+		if missing, err := tx.VerifySignatures(); err != nil || len(missing) != 0 {
+			t.Errorf("VerifySignatures %s Err ==> Got %v, %v", name, missing, err)
+		}
+		if bt.Transaction.Message.Instructions[0].Data[0] != 'm' || bt.Transaction.Signatures[0] == tx.Signatures[0] {
+			t.Errorf("%s ToTransaction shares data with the fetched transaction", name)
+		}
+	}
+	// signatures only block transactions have no content
+	if _, err := (BlockTransaction{}).ToTransaction(); err == nil {
+		t.Errorf("ToTransaction Err ==> Got nil, Want: transaction content not available")
+	}
+}
+
+func TestBlockTransactionVersion(t *testing.T) {
+	var (
+		payer     = mustAccount(t)
+		table     = mustAccount(t).Address
+		blockHash = common.Base58ToHash("EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N")
+	)
+	legacy := []byte{1}
+	legacy = append(legacy, make([]byte, 64)...)
+	legacy = append(legacy, 1, 0, 1, 2)
+	legacy = append(legacy, payer.Address[:]...)
+	legacy = append(legacy, base.MemoProgramID[:]...)
+	legacy = append(legacy, blockHash[:]...)
+	legacy = append(legacy, 1, 1, 1, 0, 4, 'm', 'e', 'm', 'o')
+	v0 := append([]byte{1}, make([]byte, 64)...)
+	v0 = append(v0, 0x80)
+	v0 = append(v0, legacy[65:]...)
+	v0 = append(v0, 1)
+	v0 = append(v0, table[:]...)
+	v0 = append(v0, 1, 0, 1, 1)
+
+	tests := []struct {
+		name string
+		resp string
+		want TxVersion
+	}{
+		{"v0", fmt.Sprintf(`{"transaction": ["%s", "base64"], "version": 0}`, base64.StdEncoding.EncodeToString(v0)), 0},
+		{"legacy", fmt.Sprintf(`{"transaction": ["%s", "base64"], "version": "legacy"}`, base64.StdEncoding.EncodeToString(legacy)), LegacyTransactionVersion},
+		// the version field is omitted without maxSupportedTransactionVersion, derived from the message
+		{"v0 no version", fmt.Sprintf(`{"transaction": ["%s", "base64"]}`, base64.StdEncoding.EncodeToString(v0)), 0},
+		{"legacy no version", fmt.Sprintf(`{"transaction": ["%s", "base64"]}`, base64.StdEncoding.EncodeToString(legacy)), LegacyTransactionVersion},
+		// json encoding, the version field is read
+		{"json", fmt.Sprintf(`{"transaction": {"signatures": [], "message": {"accountKeys": ["%s"], "instructions": [], "recentBlockhash": "%s"}}, "version": "legacy"}`, payer.Address, blockHash), LegacyTransactionVersion},
+		{"signatures only", `{"slot": 1}`, LegacyTransactionVersion},
+	}
+	for _, tt := range tests {
+		var bt BlockTransaction
+		if err := json.Unmarshal([]byte(tt.resp), &bt); err != nil {
+			t.Fatalf("Unmarshal %s Failed: %s", tt.name, err)
+		}
+		if bt.Version != tt.want {
+			t.Errorf("%s Version Err ==> Got %s, Want: %s", tt.name, bt.Version, tt.want)
+		}
+	}
+	if LegacyTransactionVersion.String() != "legacy" || TxVersion(0).String() != "0" {
+		t.Errorf("TxVersion.String Err ==> Got %s %s", LegacyTransactionVersion, TxVersion(0))
+	}
+}
+
+func TestMessageComputeBudget(t *testing.T) {
+	var (
+		payer     = mustAccount(t)
+		blockHash = common.Base58ToHash("EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N")
+	)
+	memo := &testInstruction{programID: base.MemoProgramID, accounts: []*base.AccountMeta{base.Meta(payer.Address).SIGNER()}, data: []byte("memo")}
+	tx, err := NewTransactionBuilder().
+		AddInstruction(computebudget.NewSetComputeUnitLimitInstruction(300_000).Build(), memo).
+		AddInstruction(computebudget.NewSetComputeUnitPriceInstruction(3).Build()).
+		SetRecentBlockhash(blockHash).
+		SetFeePayer(payer.Address).
+		Build()
+	if err != nil {
+		t.Fatalf("Build Failed: %s", err)
+	}
+	if limit, price := tx.Message.ComputeBudget(); limit != 300_000 || price != 3 {
+		t.Errorf("ComputeBudget Err ==> Got %d %d, Want: 300000 3", limit, price)
+	}
+	if limit, price := tx.Message.WithoutComputeUnitPrice().ComputeBudget(); limit != 300_000 || price != 0 {
+		t.Errorf("WithoutComputeUnitPrice Err ==> Got %d %d, Want: 300000 0", limit, price)
+	}
+	// 900000 micro-lamports rounded up
+	if fee := PriorityFee(300_000, 3); fee != 1 {
+		t.Errorf("PriorityFee Err ==> Got %d, Want: 1", fee)
+	}
+	if fee := PriorityFee(MaxComputeUnitLimit, 1_000_000); fee != MaxComputeUnitLimit {
+		t.Errorf("PriorityFee Err ==> Got %d, Want: %d", fee, MaxComputeUnitLimit)
+	}
+}
+
+// TestNewTransactionCompiledKeysOrder checks the compiled message against @solana/web3.js 1.x
+// TransactionMessage.compileToLegacyMessage: CompiledKeys.compile registers the fee payer, then
+// each instruction's program id and accounts, merging the flags of every occurrence, and
+// getMessageComponents groups them as writable signers, readonly signers, writable and readonly
+// non-signers, keeping the first-seen order within a group. The legacy Transaction.compileMessage
+// also sorts each group by pubkey, that order is not the one expected here.
+// The expected messages were serialized by a standalone port of these two functions and of
+// Message.serialize, not by web3.js itself which was not available when they were generated.
+func TestNewTransactionCompiledKeysOrder(t *testing.T) {
+	var (
+		payer     = common.StrToAddress("F8HCC3DyoR6KN9SSK9NL1V6weRgsEvp8hjL26EnTxNTF")
+		a         = common.StrToAddress("BZYExy8yxFZF6jTp4h7X98dPLBcbQDFhvHXPdTjDb2ag")
+		b         = common.StrToAddress("6vG61wtqP7aRgabnECQ2pYBHToJEmPtafvQrxYwmqsAL")
+		c         = common.StrToAddress("9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM")
+		d         = common.StrToAddress("HJPjoWUrhoZzkNfRpHuieeFk9WcZWjwy6PBjZ81ngndJ")
+		memo      = common.StrToAddress("MemoSq4gqABAXKb96qnH8TysNcWxMyWCqXgDLGmfcHr")
+		blockHash = common.Base58ToHash("EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N")
+	)
+	ix := func(programID common.Address, data []byte, accounts ...*base.AccountMeta) Instruction {
+		return &testInstruction{programID: programID, accounts: accounts, data: data}
+	}
+	tests := []struct {
+		name         string
+		instructions []Instruction
+		header       MessageHeader
+		keys         []common.Address
+		message      string
+	}{
+		{
+			name: "fee payer first",
+			instructions: []Instruction{
+				ix(base.SystemProgramID, []byte{2, 0, 0, 0, 100, 0, 0, 0, 0, 0, 0, 0}, base.Meta(a).WRITE().SIGNER(), base.Meta(b).WRITE()),
+			},
+			header:  MessageHeader{NumRequiredSignatures: 2, NumReadonlySignedAccounts: 0, NumReadonlyUnsignedAccounts: 1},
+			keys:    []common.Address{payer, a, b, base.SystemProgramID},
+			message: "AgABBNHg/faaZ6Gv5H+zb7q6EUMe+pgu0C9G6fqU47j39oX+nOrHemDmwZclCGUOT0pA3QQJWLa8kGnOtUj8o4WrfZVX70aeuFbIUDpVxaFwUzm7OyApDFSfT13/ZfhqJ6LnZQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAzEkOkozS44c7s0P8ldozF5ymD02/RsLDbpEpnVXU5rkBAwIBAgwCAAAAZAAAAAAAAAA=",
+		},
+		{
+			name: "fee payer in instruction",
+			instructions: []Instruction{
+				ix(memo, []byte("hi"), base.Meta(a).SIGNER(), base.Meta(payer)),
+			},
+			header:  MessageHeader{NumRequiredSignatures: 2, NumReadonlySignedAccounts: 1, NumReadonlyUnsignedAccounts: 1},
+			keys:    []common.Address{payer, a, memo},
+			message: "AgEBA9Hg/faaZ6Gv5H+zb7q6EUMe+pgu0C9G6fqU47j39oX+nOrHemDmwZclCGUOT0pA3QQJWLa8kGnOtUj8o4WrfZUFSlNamSkhBk0k6HFg2jh8fDW13bySu4HkH6hAQQVEjcxJDpKM0uOHO7ND/JXaMxecpg9Nv0bCw26RKZ1V1Oa5AQICAQACaGk=",
+		},
+		{
+			// a readonly signer made writable by a later instruction moves to the writable signers
+			name: "duplicate accounts",
+			instructions: []Instruction{
+				ix(memo, []byte{1}, base.Meta(b).SIGNER(), base.Meta(a).SIGNER(), base.Meta(c)),
+				ix(base.SystemProgramID, []byte{2}, base.Meta(a).WRITE(), base.Meta(c).WRITE(), base.Meta(b)),
+			},
+			header:  MessageHeader{NumRequiredSignatures: 3, NumReadonlySignedAccounts: 1, NumReadonlyUnsignedAccounts: 2},
+			keys:    []common.Address{payer, a, b, c, memo, base.SystemProgramID},
+			message: "AwECBtHg/faaZ6Gv5H+zb7q6EUMe+pgu0C9G6fqU47j39oX+nOrHemDmwZclCGUOT0pA3QQJWLa8kGnOtUj8o4WrfZVX70aeuFbIUDpVxaFwUzm7OyApDFSfT13/ZfhqJ6LnZX6MCIdgv94d3c8ywX8gm4JC7lKq8TH6zYjQ6ixtCwbyBUpTWpkpIQZNJOhxYNo4fHw1td28kruB5B+oQEEFRI0AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAMxJDpKM0uOHO7ND/JXaMxecpg9Nv0bCw26RKZ1V1Oa5AgQDAgEDAQEFAwEDAgEC",
+		},
+		{
+			name: "program id as account",
+			instructions: []Instruction{
+				ix(base.TokenProgramID, []byte{3}, base.Meta(a).WRITE(), base.Meta(base.TokenProgramID)),
+				ix(memo, []byte{}, base.Meta(base.SystemProgramID), base.Meta(memo)),
+			},
+			header:  MessageHeader{NumRequiredSignatures: 1, NumReadonlySignedAccounts: 0, NumReadonlyUnsignedAccounts: 3},
+			keys:    []common.Address{payer, a, base.TokenProgramID, memo, base.SystemProgramID},
+			message: "AQADBdHg/faaZ6Gv5H+zb7q6EUMe+pgu0C9G6fqU47j39oX+nOrHemDmwZclCGUOT0pA3QQJWLa8kGnOtUj8o4WrfZUG3fbh12Whk9nL4UbO63msHLSF7V9bN5E6jPWFfv8AqQVKU1qZKSEGTSTocWDaOHx8NbXdvJK7geQfqEBBBUSNAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAADMSQ6SjNLjhzuzQ/yV2jMXnKYPTb9GwsNukSmdVdTmuQICAgECAQMDAgQDAA==",
+		},
+		{
+			name: "readonly and writable",
+			instructions: []Instruction{
+				ix(memo, []byte{5}, base.Meta(d), base.Meta(c)),
+				ix(memo, []byte{6, 7}, base.Meta(c).WRITE(), base.Meta(a).SIGNER(), base.Meta(d), base.Meta(b).WRITE().SIGNER()),
+			},
+			header:  MessageHeader{NumRequiredSignatures: 3, NumReadonlySignedAccounts: 1, NumReadonlyUnsignedAccounts: 2},
+			keys:    []common.Address{payer, b, a, c, memo, d},
+			message: "AwECBtHg/faaZ6Gv5H+zb7q6EUMe+pgu0C9G6fqU47j39oX+V+9GnrhWyFA6VcWhcFM5uzsgKQxUn09d/2X4aiei52Wc6sd6YObBlyUIZQ5PSkDdBAlYtryQac61SPyjhat9lX6MCIdgv94d3c8ywX8gm4JC7lKq8TH6zYjQ6ixtCwbyBUpTWpkpIQZNJOhxYNo4fHw1td28kruB5B+oQEEFRI3yL5MQp9RFVK38fpgddAeEPkj5Nf7TAl/qe/yaQ/yJNcxJDpKM0uOHO7ND/JXaMxecpg9Nv0bCw26RKZ1V1Oa5AgQCBQMBBQQEAwIFAQIGBw==",
+		},
+	}
+	for _, tt := range tests {
+		tx, err := NewTransaction(tt.instructions, blockHash, payer)
+		if err != nil {
+			t.Fatalf("%s: NewTransaction Failed: %s", tt.name, err)
+		}
+		if tx.Message.Header != tt.header {
+			t.Errorf("%s: header Err ==> Got %+v, Want: %+v", tt.name, tx.Message.Header, tt.header)
+		}
+		if !reflect.DeepEqual(tx.Message.AccountKeys, tt.keys) {
+			t.Errorf("%s: account keys Err ==> Got %v, Want: %v", tt.name, tx.Message.AccountKeys, tt.keys)
+		}
+		if message, err := tx.Message.ToBase64(); err != nil || message != tt.message {
+			t.Errorf("%s: message Err ==> Got %s %v, Want: %s", tt.name, message, err, tt.message)
+		}
+		// the instructions still refer to their accounts
+		for i, inst := range tt.instructions {
+			compiled := tx.Message.Instructions[i]
+			if tx.Message.AccountKeys[compiled.ProgramIDIndex] != inst.ProgramID() || len(compiled.Accounts) != len(inst.Accounts()) {
+				t.Errorf("%s: instruction %d Err ==> Got %+v", tt.name, i, compiled)
+				continue
+			}
+			for j, meta := range inst.Accounts() {
+				if tx.Message.AccountKeys[compiled.Accounts[j]] != meta.PublicKey {
+					t.Errorf("%s: instruction %d account %d Err ==> Got %s, Want: %s", tt.name, i, j, tx.Message.AccountKeys[compiled.Accounts[j]], meta.PublicKey)
+				}
+			}
+		}
+	}
+}
+
+func TestRpcPrioritizationFees(t *testing.T) {
+	var fees RpcPrioritizationFees
+	for i, fee := range []uint64{500, 0, 100, 10000, 300, 200, 100, 400, 700, 1000, 50} {
+		fees = append(fees, RpcPrioritizationFee{Slot: uint64(i), PrioritizationFee: fee})
+	}
+	// sorted: 0 50 100 100 200 300 400 500 700 1000 10000
+	tests := []struct {
+		percentile float64
+		want       uint64
+	}{
+		{-1, 0}, {0, 0}, {10, 50}, {25, 100}, {50, 300}, {74, 500}, {75, 700}, {90, 1000}, {100, 10000}, {200, 10000},
+	}
+	for _, tt := range tests {
+		if got := fees.Percentile(tt.percentile); got != tt.want {
+			t.Errorf("Percentile(%v) Err ==> Got %d, Want: %d", tt.percentile, got, tt.want)
+		}
+	}
+	if fees.Median() != 300 || fees.Max() != 10000 || fees.Average() != 1213 {
+		t.Errorf("RpcPrioritizationFees Err ==> Got median %d max %d average %d, Want: 300 10000 1213", fees.Median(), fees.Max(), fees.Average())
+	}
+	// the upper median of an even count
+	if median := fees[:4].Median(); median != 500 {
+		t.Errorf("Median Err ==> Got %d, Want: 500", median)
+	}
+	fees = nil
+	if fees.Percentile(50) != 0 || fees.Median() != 0 || fees.Max() != 0 || fees.Average() != 0 {
+		t.Errorf("RpcPrioritizationFees Err ==> Got non zero aggregates of no fees")
+	}
+}
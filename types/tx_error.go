@@ -0,0 +1,64 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// InstructionError the transaction error of a failed instruction,
+// encoded by the rpc like {"InstructionError":[0,{"Custom":1}]}
+type InstructionError struct {
+	// Index of the failed instruction in the transaction
+	Index uint8
+	// The instruction error, a string like "InvalidArgument" or an object like {"Custom":1}
+	Err json.RawMessage
+}
+
+// ParseInstructionError parses a transaction error, ok is false when it's not an InstructionError
+func ParseInstructionError(txErr json.RawMessage) (*InstructionError, bool) {
+	var wrapper struct {
+		InstructionError []json.RawMessage `json:"InstructionError"`
+	}
+	if err := json.Unmarshal(txErr, &wrapper); err != nil || len(wrapper.InstructionError) != 2 {
+		return nil, false
+	}
+	var index uint8
+	if err := json.Unmarshal(wrapper.InstructionError[0], &index); err != nil {
+		return nil, false
+	}
+	return &InstructionError{Index: index, Err: wrapper.InstructionError[1]}, true
+}
+
+// Name returns the name of the instruction error: "Custom", "InvalidArgument", "BorshIoError"...
+func (e *InstructionError) Name() string {
+	var name string
+	if json.Unmarshal(e.Err, &name) == nil {
+		return name
+	}
+	var obj map[string]json.RawMessage
+	if json.Unmarshal(e.Err, &obj) == nil {
+		for key := range obj {
+			return key
+		}
+	}
+	return string(bytes.TrimSpace(e.Err))
+}
+
+// CustomCode returns the program error code of a Custom instruction error
+func (e *InstructionError) CustomCode() (uint32, bool) {
+	var custom struct {
+		Custom *uint32 `json:"Custom"`
+	}
+	if json.Unmarshal(e.Err, &custom) != nil || custom.Custom == nil {
+		return 0, false
+	}
+	return *custom.Custom, true
+}
+
+func (e *InstructionError) Error() string {
+	if code, ok := e.CustomCode(); ok {
+		return fmt.Sprintf("instruction %d failed: custom program error: %#x", e.Index, code)
+	}
+	return fmt.Sprintf("instruction %d failed: %s", e.Index, e.Name())
+}
@@ -9,6 +9,14 @@ const (
 	legacyVersion                      = `"legacy"`
 )
 
+// String returns "legacy" or the version number
+func (ver TxVersion) String() string {
+	if ver == LegacyTransactionVersion {
+		return "legacy"
+	}
+	return strconv.Itoa(int(ver))
+}
+
 func (ver *TxVersion) UnmarshalJSON(b []byte) error {
 	// Ignore null, like in the main JSON package.
 	s := string(b)
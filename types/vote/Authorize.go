@@ -0,0 +1,142 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package vote
+
+import (
+	"encoding/binary"
+	"errors"
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/types/base"
+)
+
+// Authorize Authorize a key to send votes or issue a withdrawal
+type Authorize struct {
+	// The new authority
+	NewAuthority *common.Address
+	// The kind of authority
+	VoteAuthorize *VoteAuthorize
+
+	// [0] = [WRITE] voteAccount
+	// ··········· Vote account to be updated
+	//
+	// [1] = [] clockSysvar
+	// ··········· Clock sysvar
+	//
+	// [2] = [SIGNER] authority
+	// ··········· Vote or withdraw authority
+	base.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewAuthorizeInstructionBuilder creates a new `Authorize` instruction builder.
+func NewAuthorizeInstructionBuilder() *Authorize {
+	nd := &Authorize{
+		AccountMetaSlice: make([]*base.AccountMeta, 3),
+	}
+	nd.AccountMetaSlice[1] = base.Meta(base.SysVarClockPubkey)
+	return nd
+}
+
+// SetNewAuthority sets the "new_authority" parameter.
+// The new authority.
+func (auth *Authorize) SetNewAuthority(newAuthority common.Address) *Authorize {
+	auth.NewAuthority = &newAuthority
+	return auth
+}
+
+// SetVoteAuthorize sets the "vote_authorize" parameter.
+// The kind of authority.
+func (auth *Authorize) SetVoteAuthorize(voteAuthorize VoteAuthorize) *Authorize {
+	auth.VoteAuthorize = &voteAuthorize
+	return auth
+}
+
+// SetVoteAccount sets the "voteAccount" account.
+// Vote account to be updated.
+func (auth *Authorize) SetVoteAccount(voteAccount common.Address) *Authorize {
+	auth.AccountMetaSlice[0] = base.Meta(voteAccount).WRITE()
+	return auth
+}
+
+// GetVoteAccount gets the "voteAccount" account.
+func (auth *Authorize) GetVoteAccount() *base.AccountMeta {
+	return auth.AccountMetaSlice[0]
+}
+
+// SetAuthorityAccount sets the "authority" account.
+// Vote or withdraw authority.
+func (auth *Authorize) SetAuthorityAccount(authority common.Address) *Authorize {
+	auth.AccountMetaSlice[2] = base.Meta(authority).SIGNER()
+	return auth
+}
+
+// GetAuthorityAccount gets the "authority" account.
+func (auth *Authorize) GetAuthorityAccount() *base.AccountMeta {
+	return auth.AccountMetaSlice[2]
+}
+
+func (auth Authorize) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{BaseVariant: encodbin.BaseVariant{
+		Impl:   auth,
+		TypeID: encodbin.TypeIDFromUint32(Instruction_Authorize, binary.LittleEndian),
+	}}, opts)
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (auth Authorize) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
+	if err := auth.Validate(); err != nil {
+		return nil, err
+	}
+	return auth.Build(opts...), nil
+}
+
+func (auth *Authorize) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if auth.NewAuthority == nil {
+			return errors.New("NewAuthority parameter is not set")
+		}
+		if auth.VoteAuthorize == nil {
+			return errors.New("VoteAuthorize parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if auth.AccountMetaSlice[0] == nil {
+			return &base.MissingAccountError{Account: "VoteAccount"}
+		}
+		if auth.AccountMetaSlice[2] == nil {
+			return &base.MissingAccountError{Account: "Authority"}
+		}
+	}
+	return nil
+}
+
+func (auth Authorize) MarshalWithEncoder(encoder *encodbin.Encoder) (err error) {
+	// Serialize `NewAuthority` param:
+	if err = encoder.Encode(*auth.NewAuthority); err != nil {
+		return err
+	}
+	// Serialize `VoteAuthorize` param:
+	return encoder.WriteUint32(uint32(*auth.VoteAuthorize), binary.LittleEndian)
+}
+
+// NewAuthorizeInstruction declares a new Authorize instruction with the provided parameters and accounts.
+func NewAuthorizeInstruction(
+	// Parameters:
+	newAuthority common.Address,
+	voteAuthorize VoteAuthorize,
+	// Accounts:
+	voteAccount common.Address,
+	authority common.Address,
+) *Authorize {
+	return NewAuthorizeInstructionBuilder().
+		SetNewAuthority(newAuthority).
+		SetVoteAuthorize(voteAuthorize).
+		SetVoteAccount(voteAccount).
+		SetAuthorityAccount(authority)
+}
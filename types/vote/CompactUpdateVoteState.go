@@ -0,0 +1,251 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package vote
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/types/base"
+	"math"
+)
+
+// CompactUpdateVoteState Update the onchain vote state for the signer, in the compact encoding:
+// the root slot (math.MaxUint64 when none), the lockouts as a short_vec of varint slot offsets
+// from the previous slot and u8 confirmation counts, the bank hash and the optional timestamp
+type CompactUpdateVoteState struct {
+	// The lockouts of the tower, oldest first, the Latency is not encoded
+	Lockouts []Lockout
+	// The root slot of the tower, optional
+	Root *uint64
+	// The bank hash of the last voted slot
+	Hash *common.Hash
+	// The processing timestamp of the last slot, optional
+	Timestamp *int64
+
+	// [0] = [WRITE] voteAccount
+	// ··········· Vote account to vote with
+	//
+	// [1] = [SIGNER] voteAuthority
+	// ··········· Vote authority
+	base.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewCompactUpdateVoteStateInstructionBuilder creates a new `CompactUpdateVoteState` instruction builder.
+func NewCompactUpdateVoteStateInstructionBuilder() *CompactUpdateVoteState {
+	nd := &CompactUpdateVoteState{
+		AccountMetaSlice: make([]*base.AccountMeta, 2),
+	}
+	return nd
+}
+
+// SetLockouts sets the "lockouts" parameter.
+// The lockouts of the tower, oldest first.
+func (upd *CompactUpdateVoteState) SetLockouts(lockouts ...Lockout) *CompactUpdateVoteState {
+	upd.Lockouts = lockouts
+	return upd
+}
+
+// SetRoot sets the "root" parameter.
+// The root slot of the tower.
+func (upd *CompactUpdateVoteState) SetRoot(root uint64) *CompactUpdateVoteState {
+	upd.Root = &root
+	return upd
+}
+
+// SetHash sets the "hash" parameter.
+// The bank hash of the last voted slot.
+func (upd *CompactUpdateVoteState) SetHash(hash common.Hash) *CompactUpdateVoteState {
+	upd.Hash = &hash
+	return upd
+}
+
+// SetTimestamp sets the "timestamp" parameter.
+// The processing timestamp of the last slot.
+func (upd *CompactUpdateVoteState) SetTimestamp(timestamp int64) *CompactUpdateVoteState {
+	upd.Timestamp = &timestamp
+	return upd
+}
+
+// SetVoteAccount sets the "voteAccount" account.
+// Vote account to vote with.
+func (upd *CompactUpdateVoteState) SetVoteAccount(voteAccount common.Address) *CompactUpdateVoteState {
+	upd.AccountMetaSlice[0] = base.Meta(voteAccount).WRITE()
+	return upd
+}
+
+// GetVoteAccount gets the "voteAccount" account.
+func (upd *CompactUpdateVoteState) GetVoteAccount() *base.AccountMeta {
+	return upd.AccountMetaSlice[0]
+}
+
+// SetVoteAuthorityAccount sets the "voteAuthority" account.
+// Vote authority.
+func (upd *CompactUpdateVoteState) SetVoteAuthorityAccount(voteAuthority common.Address) *CompactUpdateVoteState {
+	upd.AccountMetaSlice[1] = base.Meta(voteAuthority).SIGNER()
+	return upd
+}
+
+// GetVoteAuthorityAccount gets the "voteAuthority" account.
+func (upd *CompactUpdateVoteState) GetVoteAuthorityAccount() *base.AccountMeta {
+	return upd.AccountMetaSlice[1]
+}
+
+func (upd CompactUpdateVoteState) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{BaseVariant: encodbin.BaseVariant{
+		Impl:   upd,
+		TypeID: encodbin.TypeIDFromUint32(Instruction_CompactUpdateVoteState, binary.LittleEndian),
+	}}, opts)
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (upd CompactUpdateVoteState) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
+	if err := upd.Validate(); err != nil {
+		return nil, err
+	}
+	return upd.Build(opts...), nil
+}
+
+func (upd *CompactUpdateVoteState) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if len(upd.Lockouts) == 0 {
+			return errors.New("Lockouts parameter is not set")
+		}
+		if upd.Hash == nil {
+			return errors.New("Hash parameter is not set")
+		}
+		if upd.Root != nil && *upd.Root == math.MaxUint64 {
+			return errors.New("Root parameter is the reserved none root")
+		}
+		prevSlot := uint64(0)
+		if upd.Root != nil {
+			prevSlot = *upd.Root
+		}
+		for _, lockout := range upd.Lockouts {
+			if lockout.Slot < prevSlot {
+				return fmt.Errorf("Lockouts parameter slot %d is before %d", lockout.Slot, prevSlot)
+			}
+			if lockout.ConfirmationCount > math.MaxUint8 {
+				return fmt.Errorf("Lockouts parameter confirmation count %d is over 255", lockout.ConfirmationCount)
+			}
+			prevSlot = lockout.Slot
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if upd.AccountMetaSlice[0] == nil {
+			return &base.MissingAccountError{Account: "VoteAccount"}
+		}
+		if upd.AccountMetaSlice[1] == nil {
+			return &base.MissingAccountError{Account: "VoteAuthority"}
+		}
+	}
+	return nil
+}
+
+func (upd CompactUpdateVoteState) MarshalWithEncoder(encoder *encodbin.Encoder) (err error) {
+	// Serialize `Root` param, math.MaxUint64 when none:
+	root, prevSlot := uint64(math.MaxUint64), uint64(0)
+	if upd.Root != nil {
+		root, prevSlot = *upd.Root, *upd.Root
+	}
+	if err = encoder.WriteUint64(root, binary.LittleEndian); err != nil {
+		return err
+	}
+	// Serialize `Lockouts` param, as a short_vec of lockout offsets:
+	if err = encoder.WriteCompactU16Length(len(upd.Lockouts)); err != nil {
+		return err
+	}
+	for _, lockout := range upd.Lockouts {
+		if lockout.Slot < prevSlot || lockout.ConfirmationCount > math.MaxUint8 {
+			return fmt.Errorf("invalid lockout: slot %d confirmation count %d", lockout.Slot, lockout.ConfirmationCount)
+		}
+		offset := make([]byte, binary.MaxVarintLen64)
+		if err = encoder.WriteBytes(offset[:binary.PutUvarint(offset, lockout.Slot-prevSlot)], false); err != nil {
+			return err
+		}
+		if err = encoder.WriteUint8(uint8(lockout.ConfirmationCount)); err != nil {
+			return err
+		}
+		prevSlot = lockout.Slot
+	}
+	// Serialize `Hash` and `Timestamp` params:
+	return writeHashAndTimestamp(encoder, *upd.Hash, upd.Timestamp)
+}
+
+func (upd *CompactUpdateVoteState) UnmarshalWithDecoder(decoder *encodbin.Decoder) (err error) {
+	// Deserialize `Root`:
+	root, err := decoder.ReadUint64(binary.LittleEndian)
+	if err != nil {
+		return err
+	}
+	upd.Root = nil
+	slot := uint64(0)
+	if root != math.MaxUint64 {
+		upd.Root, slot = &root, root
+	}
+	// Deserialize `Lockouts`:
+	length, err := decoder.ReadCompactU16Length()
+	if err != nil {
+		return err
+	}
+	// at least a byte of offset and a byte of confirmation count each
+	if length > decoder.Remaining()/2 {
+		return fmt.Errorf("invalid length: %d", length)
+	}
+	upd.Lockouts = make([]Lockout, length)
+	for i := range upd.Lockouts {
+		offset, err := decoder.ReadUvarint64()
+		if err != nil {
+			return err
+		}
+		if slot+offset < slot {
+			return fmt.Errorf("lockout offset %d overflows slot %d", offset, slot)
+		}
+		slot += offset
+		confirmationCount, err := decoder.ReadUint8()
+		if err != nil {
+			return err
+		}
+		upd.Lockouts[i] = Lockout{Slot: slot, ConfirmationCount: uint32(confirmationCount)}
+	}
+	// Deserialize `Hash` and `Timestamp`:
+	upd.Hash, upd.Timestamp, err = readHashAndTimestamp(decoder)
+	return err
+}
+
+// VotedSlots Returns the slots of the lockouts, oldest first
+func (upd *CompactUpdateVoteState) VotedSlots() []uint64 {
+	return lockoutSlots(upd.Lockouts)
+}
+
+// VotedHash Returns the bank hash of the last voted slot
+func (upd *CompactUpdateVoteState) VotedHash() common.Hash {
+	if upd.Hash == nil {
+		return common.Hash{}
+	}
+	return *upd.Hash
+}
+
+// NewCompactUpdateVoteStateInstruction declares a new CompactUpdateVoteState instruction with the provided parameters and accounts.
+func NewCompactUpdateVoteStateInstruction(
+	// Parameters:
+	lockouts []Lockout,
+	hash common.Hash,
+	// Accounts:
+	voteAccount common.Address,
+	voteAuthority common.Address,
+) *CompactUpdateVoteState {
+	return NewCompactUpdateVoteStateInstructionBuilder().
+		SetLockouts(lockouts...).
+		SetHash(hash).
+		SetVoteAccount(voteAccount).
+		SetVoteAuthorityAccount(voteAuthority)
+}
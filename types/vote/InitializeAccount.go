@@ -0,0 +1,166 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package vote
+
+import (
+	"encoding/binary"
+	"errors"
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/types/base"
+)
+
+// InitializeAccount Initialize a vote account
+type InitializeAccount struct {
+	// The validator identity
+	NodePubkey *common.Address
+	// The authority allowed to vote
+	AuthorizedVoter *common.Address
+	// The authority allowed to withdraw
+	AuthorizedWithdrawer *common.Address
+	// The commission percentage, 0-100
+	Commission *uint8
+
+	// [0] = [WRITE] voteAccount
+	// ··········· Uninitialized vote account
+	//
+	// [1] = [] rentSysvar
+	// ··········· Rent sysvar
+	//
+	// [2] = [] clockSysvar
+	// ··········· Clock sysvar
+	//
+	// [3] = [SIGNER] node
+	// ··········· New validator identity (node_pubkey)
+	base.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewInitializeAccountInstructionBuilder creates a new `InitializeAccount` instruction builder.
+func NewInitializeAccountInstructionBuilder() *InitializeAccount {
+	nd := &InitializeAccount{
+		AccountMetaSlice: make([]*base.AccountMeta, 4),
+	}
+	nd.AccountMetaSlice[1] = base.Meta(base.SysVarRentPubkey)
+	nd.AccountMetaSlice[2] = base.Meta(base.SysVarClockPubkey)
+	return nd
+}
+
+// SetNodePubkey sets the "node_pubkey" parameter and the node account.
+// The validator identity.
+func (initAcc *InitializeAccount) SetNodePubkey(node common.Address) *InitializeAccount {
+	initAcc.NodePubkey = &node
+	initAcc.AccountMetaSlice[3] = base.Meta(node).SIGNER()
+	return initAcc
+}
+
+// SetAuthorizedVoter sets the "authorized_voter" parameter.
+// The authority allowed to vote.
+func (initAcc *InitializeAccount) SetAuthorizedVoter(voter common.Address) *InitializeAccount {
+	initAcc.AuthorizedVoter = &voter
+	return initAcc
+}
+
+// SetAuthorizedWithdrawer sets the "authorized_withdrawer" parameter.
+// The authority allowed to withdraw.
+func (initAcc *InitializeAccount) SetAuthorizedWithdrawer(withdrawer common.Address) *InitializeAccount {
+	initAcc.AuthorizedWithdrawer = &withdrawer
+	return initAcc
+}
+
+// SetCommission sets the "commission" parameter.
+// The commission percentage, 0-100.
+func (initAcc *InitializeAccount) SetCommission(commission uint8) *InitializeAccount {
+	initAcc.Commission = &commission
+	return initAcc
+}
+
+// SetVoteAccount sets the "voteAccount" account.
+// Uninitialized vote account.
+func (initAcc *InitializeAccount) SetVoteAccount(voteAccount common.Address) *InitializeAccount {
+	initAcc.AccountMetaSlice[0] = base.Meta(voteAccount).WRITE()
+	return initAcc
+}
+
+// GetVoteAccount gets the "voteAccount" account.
+func (initAcc *InitializeAccount) GetVoteAccount() *base.AccountMeta {
+	return initAcc.AccountMetaSlice[0]
+}
+
+func (initAcc InitializeAccount) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{BaseVariant: encodbin.BaseVariant{
+		Impl:   initAcc,
+		TypeID: encodbin.TypeIDFromUint32(Instruction_InitializeAccount, binary.LittleEndian),
+	}}, opts)
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (initAcc InitializeAccount) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
+	if err := initAcc.Validate(); err != nil {
+		return nil, err
+	}
+	return initAcc.Build(opts...), nil
+}
+
+func (initAcc *InitializeAccount) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if initAcc.NodePubkey == nil {
+			return errors.New("NodePubkey parameter is not set")
+		}
+		if initAcc.AuthorizedVoter == nil {
+			return errors.New("AuthorizedVoter parameter is not set")
+		}
+		if initAcc.AuthorizedWithdrawer == nil {
+			return errors.New("AuthorizedWithdrawer parameter is not set")
+		}
+		if initAcc.Commission == nil {
+			return errors.New("Commission parameter is not set")
+		}
+		if *initAcc.Commission > 100 {
+			return errors.New("Commission parameter is over 100")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if initAcc.AccountMetaSlice[0] == nil {
+			return &base.MissingAccountError{Account: "VoteAccount"}
+		}
+	}
+	return nil
+}
+
+func (initAcc InitializeAccount) MarshalWithEncoder(encoder *encodbin.Encoder) (err error) {
+	// Serialize `VoteInit` param:
+	if err = encoder.Encode(*initAcc.NodePubkey); err != nil {
+		return err
+	}
+	if err = encoder.Encode(*initAcc.AuthorizedVoter); err != nil {
+		return err
+	}
+	if err = encoder.Encode(*initAcc.AuthorizedWithdrawer); err != nil {
+		return err
+	}
+	return encoder.WriteUint8(*initAcc.Commission)
+}
+
+// NewInitializeAccountInstruction declares a new InitializeAccount instruction with the provided parameters and accounts.
+func NewInitializeAccountInstruction(
+	// Parameters:
+	node common.Address,
+	authorizedVoter common.Address,
+	authorizedWithdrawer common.Address,
+	commission uint8,
+	// Accounts:
+	voteAccount common.Address,
+) *InitializeAccount {
+	return NewInitializeAccountInstructionBuilder().
+		SetNodePubkey(node).
+		SetAuthorizedVoter(authorizedVoter).
+		SetAuthorizedWithdrawer(authorizedWithdrawer).
+		SetCommission(commission).
+		SetVoteAccount(voteAccount)
+}
@@ -0,0 +1,90 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package vote
+
+import (
+	"encoding/binary"
+	"errors"
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/types/base"
+)
+
+// TowerSync Sync the onchain vote state with the local tower, the vote instruction of the validators
+// once the tower sync feature is active. Its data is the data of a CompactUpdateVoteState followed
+// by the id of the last voted block
+type TowerSync struct {
+	CompactUpdateVoteState
+	// The id of the last voted block
+	BlockID *common.Hash
+}
+
+// NewTowerSyncInstructionBuilder creates a new `TowerSync` instruction builder.
+func NewTowerSyncInstructionBuilder() *TowerSync {
+	return &TowerSync{CompactUpdateVoteState: *NewCompactUpdateVoteStateInstructionBuilder()}
+}
+
+// SetBlockID sets the "blockID" parameter.
+// The id of the last voted block.
+func (ts *TowerSync) SetBlockID(blockID common.Hash) *TowerSync {
+	ts.BlockID = &blockID
+	return ts
+}
+
+func (ts TowerSync) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{BaseVariant: encodbin.BaseVariant{
+		Impl:   ts,
+		TypeID: encodbin.TypeIDFromUint32(Instruction_TowerSync, binary.LittleEndian),
+	}}, opts)
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (ts TowerSync) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
+	if err := ts.Validate(); err != nil {
+		return nil, err
+	}
+	return ts.Build(opts...), nil
+}
+
+func (ts *TowerSync) Validate() error {
+	if ts.BlockID == nil {
+		return errors.New("BlockID parameter is not set")
+	}
+	return ts.CompactUpdateVoteState.Validate()
+}
+
+func (ts TowerSync) MarshalWithEncoder(encoder *encodbin.Encoder) error {
+	if err := ts.CompactUpdateVoteState.MarshalWithEncoder(encoder); err != nil {
+		return err
+	}
+	return encoder.Encode(*ts.BlockID)
+}
+
+func (ts *TowerSync) UnmarshalWithDecoder(decoder *encodbin.Decoder) error {
+	if err := ts.CompactUpdateVoteState.UnmarshalWithDecoder(decoder); err != nil {
+		return err
+	}
+	ts.BlockID = new(common.Hash)
+	return decoder.Decode(ts.BlockID)
+}
+
+// NewTowerSyncInstruction declares a new TowerSync instruction with the provided parameters and accounts.
+func NewTowerSyncInstruction(
+	// Parameters:
+	lockouts []Lockout,
+	hash common.Hash,
+	blockID common.Hash,
+	// Accounts:
+	voteAccount common.Address,
+	voteAuthority common.Address,
+) *TowerSync {
+	ts := NewTowerSyncInstructionBuilder().SetBlockID(blockID)
+	ts.SetLockouts(lockouts...).
+		SetHash(hash).
+		SetVoteAccount(voteAccount).
+		SetVoteAuthorityAccount(voteAuthority)
+	return ts
+}
@@ -0,0 +1,123 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package vote
+
+import (
+	"encoding/binary"
+	"errors"
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/types/base"
+)
+
+// UpdateCommission Update the commission for the vote account
+type UpdateCommission struct {
+	// The commission percentage, 0-100
+	Commission *uint8
+
+	// [0] = [WRITE] voteAccount
+	// ··········· Vote account to be updated
+	//
+	// [1] = [SIGNER] withdrawAuthority
+	// ··········· Withdraw authority
+	base.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewUpdateCommissionInstructionBuilder creates a new `UpdateCommission` instruction builder.
+func NewUpdateCommissionInstructionBuilder() *UpdateCommission {
+	nd := &UpdateCommission{
+		AccountMetaSlice: make([]*base.AccountMeta, 2),
+	}
+	return nd
+}
+
+// SetCommission sets the "commission" parameter.
+// The commission percentage, 0-100.
+func (upd *UpdateCommission) SetCommission(commission uint8) *UpdateCommission {
+	upd.Commission = &commission
+	return upd
+}
+
+// SetVoteAccount sets the "voteAccount" account.
+// Vote account to be updated.
+func (upd *UpdateCommission) SetVoteAccount(voteAccount common.Address) *UpdateCommission {
+	upd.AccountMetaSlice[0] = base.Meta(voteAccount).WRITE()
+	return upd
+}
+
+// GetVoteAccount gets the "voteAccount" account.
+func (upd *UpdateCommission) GetVoteAccount() *base.AccountMeta {
+	return upd.AccountMetaSlice[0]
+}
+
+// SetWithdrawAuthorityAccount sets the "withdrawAuthority" account.
+// Withdraw authority.
+func (upd *UpdateCommission) SetWithdrawAuthorityAccount(withdrawAuthority common.Address) *UpdateCommission {
+	upd.AccountMetaSlice[1] = base.Meta(withdrawAuthority).SIGNER()
+	return upd
+}
+
+// GetWithdrawAuthorityAccount gets the "withdrawAuthority" account.
+func (upd *UpdateCommission) GetWithdrawAuthorityAccount() *base.AccountMeta {
+	return upd.AccountMetaSlice[1]
+}
+
+func (upd UpdateCommission) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{BaseVariant: encodbin.BaseVariant{
+		Impl:   upd,
+		TypeID: encodbin.TypeIDFromUint32(Instruction_UpdateCommission, binary.LittleEndian),
+	}}, opts)
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (upd UpdateCommission) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
+	if err := upd.Validate(); err != nil {
+		return nil, err
+	}
+	return upd.Build(opts...), nil
+}
+
+func (upd *UpdateCommission) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if upd.Commission == nil {
+			return errors.New("Commission parameter is not set")
+		}
+		if *upd.Commission > 100 {
+			return errors.New("Commission parameter is over 100")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if upd.AccountMetaSlice[0] == nil {
+			return &base.MissingAccountError{Account: "VoteAccount"}
+		}
+		if upd.AccountMetaSlice[1] == nil {
+			return &base.MissingAccountError{Account: "WithdrawAuthority"}
+		}
+	}
+	return nil
+}
+
+func (upd UpdateCommission) MarshalWithEncoder(encoder *encodbin.Encoder) error {
+	// Serialize `Commission` param:
+	return encoder.WriteUint8(*upd.Commission)
+}
+
+// NewUpdateCommissionInstruction declares a new UpdateCommission instruction with the provided parameters and accounts.
+func NewUpdateCommissionInstruction(
+	// Parameters:
+	commission uint8,
+	// Accounts:
+	voteAccount common.Address,
+	withdrawAuthority common.Address,
+) *UpdateCommission {
+	return NewUpdateCommissionInstructionBuilder().
+		SetCommission(commission).
+		SetVoteAccount(voteAccount).
+		SetWithdrawAuthorityAccount(withdrawAuthority)
+}
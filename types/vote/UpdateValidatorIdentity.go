@@ -0,0 +1,119 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package vote
+
+import (
+	"encoding/binary"
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/types/base"
+)
+
+// UpdateValidatorIdentity Update the vote account's validator identity (node_pubkey)
+type UpdateValidatorIdentity struct {
+	// [0] = [WRITE] voteAccount
+	// ··········· Vote account to be updated with the given authority public key
+	//
+	// [1] = [SIGNER] node
+	// ··········· New validator identity (node_pubkey)
+	//
+	// [2] = [SIGNER] withdrawAuthority
+	// ··········· Withdraw authority
+	base.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewUpdateValidatorIdentityInstructionBuilder creates a new `UpdateValidatorIdentity` instruction builder.
+func NewUpdateValidatorIdentityInstructionBuilder() *UpdateValidatorIdentity {
+	nd := &UpdateValidatorIdentity{
+		AccountMetaSlice: make([]*base.AccountMeta, 3),
+	}
+	return nd
+}
+
+// SetVoteAccount sets the "voteAccount" account.
+// Vote account to be updated with the given authority public key.
+func (upd *UpdateValidatorIdentity) SetVoteAccount(voteAccount common.Address) *UpdateValidatorIdentity {
+	upd.AccountMetaSlice[0] = base.Meta(voteAccount).WRITE()
+	return upd
+}
+
+// GetVoteAccount gets the "voteAccount" account.
+func (upd *UpdateValidatorIdentity) GetVoteAccount() *base.AccountMeta {
+	return upd.AccountMetaSlice[0]
+}
+
+// SetNodeAccount sets the "node" account.
+// New validator identity (node_pubkey).
+func (upd *UpdateValidatorIdentity) SetNodeAccount(node common.Address) *UpdateValidatorIdentity {
+	upd.AccountMetaSlice[1] = base.Meta(node).SIGNER()
+	return upd
+}
+
+// GetNodeAccount gets the "node" account.
+func (upd *UpdateValidatorIdentity) GetNodeAccount() *base.AccountMeta {
+	return upd.AccountMetaSlice[1]
+}
+
+// SetWithdrawAuthorityAccount sets the "withdrawAuthority" account.
+// Withdraw authority.
+func (upd *UpdateValidatorIdentity) SetWithdrawAuthorityAccount(withdrawAuthority common.Address) *UpdateValidatorIdentity {
+	upd.AccountMetaSlice[2] = base.Meta(withdrawAuthority).SIGNER()
+	return upd
+}
+
+// GetWithdrawAuthorityAccount gets the "withdrawAuthority" account.
+func (upd *UpdateValidatorIdentity) GetWithdrawAuthorityAccount() *base.AccountMeta {
+	return upd.AccountMetaSlice[2]
+}
+
+func (upd UpdateValidatorIdentity) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{BaseVariant: encodbin.BaseVariant{
+		Impl:   upd,
+		TypeID: encodbin.TypeIDFromUint32(Instruction_UpdateValidatorIdentity, binary.LittleEndian),
+	}}, opts)
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (upd UpdateValidatorIdentity) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
+	if err := upd.Validate(); err != nil {
+		return nil, err
+	}
+	return upd.Build(opts...), nil
+}
+
+func (upd *UpdateValidatorIdentity) Validate() error {
+	// Check whether all (required) accounts are set:
+	{
+		if upd.AccountMetaSlice[0] == nil {
+			return &base.MissingAccountError{Account: "VoteAccount"}
+		}
+		if upd.AccountMetaSlice[1] == nil {
+			return &base.MissingAccountError{Account: "Node"}
+		}
+		if upd.AccountMetaSlice[2] == nil {
+			return &base.MissingAccountError{Account: "WithdrawAuthority"}
+		}
+	}
+	return nil
+}
+
+func (upd UpdateValidatorIdentity) MarshalWithEncoder(_ *encodbin.Encoder) error {
+	// no parameters
+	return nil
+}
+
+// NewUpdateValidatorIdentityInstruction declares a new UpdateValidatorIdentity instruction with the provided accounts.
+func NewUpdateValidatorIdentityInstruction(
+	// Accounts:
+	voteAccount common.Address,
+	node common.Address,
+	withdrawAuthority common.Address,
+) *UpdateValidatorIdentity {
+	return NewUpdateValidatorIdentityInstructionBuilder().
+		SetVoteAccount(voteAccount).
+		SetNodeAccount(node).
+		SetWithdrawAuthorityAccount(withdrawAuthority)
+}
@@ -0,0 +1,264 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package vote
+
+import (
+	"encoding/binary"
+	"errors"
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/types/base"
+)
+
+// UpdateVoteState Update the onchain vote state for the signer
+type UpdateVoteState struct {
+	// The lockouts of the tower, oldest first, the Latency is not encoded
+	Lockouts []Lockout
+	// The root slot of the tower, optional
+	Root *uint64
+	// The bank hash of the last voted slot
+	Hash *common.Hash
+	// The processing timestamp of the last slot, optional
+	Timestamp *int64
+
+	// [0] = [WRITE] voteAccount
+	// ··········· Vote account to vote with
+	//
+	// [1] = [SIGNER] voteAuthority
+	// ··········· Vote authority
+	base.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewUpdateVoteStateInstructionBuilder creates a new `UpdateVoteState` instruction builder.
+func NewUpdateVoteStateInstructionBuilder() *UpdateVoteState {
+	nd := &UpdateVoteState{
+		AccountMetaSlice: make([]*base.AccountMeta, 2),
+	}
+	return nd
+}
+
+// SetLockouts sets the "lockouts" parameter.
+// The lockouts of the tower, oldest first.
+func (upd *UpdateVoteState) SetLockouts(lockouts ...Lockout) *UpdateVoteState {
+	upd.Lockouts = lockouts
+	return upd
+}
+
+// SetRoot sets the "root" parameter.
+// The root slot of the tower.
+func (upd *UpdateVoteState) SetRoot(root uint64) *UpdateVoteState {
+	upd.Root = &root
+	return upd
+}
+
+// SetHash sets the "hash" parameter.
+// The bank hash of the last voted slot.
+func (upd *UpdateVoteState) SetHash(hash common.Hash) *UpdateVoteState {
+	upd.Hash = &hash
+	return upd
+}
+
+// SetTimestamp sets the "timestamp" parameter.
+// The processing timestamp of the last slot.
+func (upd *UpdateVoteState) SetTimestamp(timestamp int64) *UpdateVoteState {
+	upd.Timestamp = &timestamp
+	return upd
+}
+
+// SetVoteAccount sets the "voteAccount" account.
+// Vote account to vote with.
+func (upd *UpdateVoteState) SetVoteAccount(voteAccount common.Address) *UpdateVoteState {
+	upd.AccountMetaSlice[0] = base.Meta(voteAccount).WRITE()
+	return upd
+}
+
+// GetVoteAccount gets the "voteAccount" account.
+func (upd *UpdateVoteState) GetVoteAccount() *base.AccountMeta {
+	return upd.AccountMetaSlice[0]
+}
+
+// SetVoteAuthorityAccount sets the "voteAuthority" account.
+// Vote authority.
+func (upd *UpdateVoteState) SetVoteAuthorityAccount(voteAuthority common.Address) *UpdateVoteState {
+	upd.AccountMetaSlice[1] = base.Meta(voteAuthority).SIGNER()
+	return upd
+}
+
+// GetVoteAuthorityAccount gets the "voteAuthority" account.
+func (upd *UpdateVoteState) GetVoteAuthorityAccount() *base.AccountMeta {
+	return upd.AccountMetaSlice[1]
+}
+
+func (upd UpdateVoteState) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{BaseVariant: encodbin.BaseVariant{
+		Impl:   upd,
+		TypeID: encodbin.TypeIDFromUint32(Instruction_UpdateVoteState, binary.LittleEndian),
+	}}, opts)
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (upd UpdateVoteState) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
+	if err := upd.Validate(); err != nil {
+		return nil, err
+	}
+	return upd.Build(opts...), nil
+}
+
+func (upd *UpdateVoteState) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if len(upd.Lockouts) == 0 {
+			return errors.New("Lockouts parameter is not set")
+		}
+		if upd.Hash == nil {
+			return errors.New("Hash parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if upd.AccountMetaSlice[0] == nil {
+			return &base.MissingAccountError{Account: "VoteAccount"}
+		}
+		if upd.AccountMetaSlice[1] == nil {
+			return &base.MissingAccountError{Account: "VoteAuthority"}
+		}
+	}
+	return nil
+}
+
+func (upd UpdateVoteState) MarshalWithEncoder(encoder *encodbin.Encoder) (err error) {
+	// Serialize `Lockouts` param, as a bincode VecDeque<Lockout>:
+	if err = encoder.WriteUint64(uint64(len(upd.Lockouts)), binary.LittleEndian); err != nil {
+		return err
+	}
+	for _, lockout := range upd.Lockouts {
+		if err = encoder.WriteUint64(lockout.Slot, binary.LittleEndian); err != nil {
+			return err
+		}
+		if err = encoder.WriteUint32(lockout.ConfirmationCount, binary.LittleEndian); err != nil {
+			return err
+		}
+	}
+	// Serialize `Root` param:
+	if err = encoder.WriteOption(upd.Root != nil, encodbin.OptionKindBorsh); err != nil {
+		return err
+	}
+	if upd.Root != nil {
+		if err = encoder.WriteUint64(*upd.Root, binary.LittleEndian); err != nil {
+			return err
+		}
+	}
+	// Serialize `Hash` and `Timestamp` params:
+	return writeHashAndTimestamp(encoder, *upd.Hash, upd.Timestamp)
+}
+
+func (upd *UpdateVoteState) UnmarshalWithDecoder(decoder *encodbin.Decoder) (err error) {
+	// Deserialize `Lockouts`:
+	length, err := readLength(decoder, 12)
+	if err != nil {
+		return err
+	}
+	upd.Lockouts = make([]Lockout, length)
+	for i := range upd.Lockouts {
+		if upd.Lockouts[i].Slot, err = decoder.ReadUint64(binary.LittleEndian); err != nil {
+			return err
+		}
+		if upd.Lockouts[i].ConfirmationCount, err = decoder.ReadUint32(binary.LittleEndian); err != nil {
+			return err
+		}
+	}
+	// Deserialize `Root`:
+	present, err := decoder.ReadOption(encodbin.OptionKindBorsh)
+	if err != nil {
+		return err
+	}
+	if present {
+		root, err := decoder.ReadUint64(binary.LittleEndian)
+		if err != nil {
+			return err
+		}
+		upd.Root = &root
+	}
+	// Deserialize `Hash` and `Timestamp`:
+	upd.Hash, upd.Timestamp, err = readHashAndTimestamp(decoder)
+	return err
+}
+
+// VotedSlots Returns the slots of the lockouts, oldest first
+func (upd *UpdateVoteState) VotedSlots() []uint64 {
+	return lockoutSlots(upd.Lockouts)
+}
+
+// VotedHash Returns the bank hash of the last voted slot
+func (upd *UpdateVoteState) VotedHash() common.Hash {
+	if upd.Hash == nil {
+		return common.Hash{}
+	}
+	return *upd.Hash
+}
+
+// NewUpdateVoteStateInstruction declares a new UpdateVoteState instruction with the provided parameters and accounts.
+func NewUpdateVoteStateInstruction(
+	// Parameters:
+	lockouts []Lockout,
+	hash common.Hash,
+	// Accounts:
+	voteAccount common.Address,
+	voteAuthority common.Address,
+) *UpdateVoteState {
+	return NewUpdateVoteStateInstructionBuilder().
+		SetLockouts(lockouts...).
+		SetHash(hash).
+		SetVoteAccount(voteAccount).
+		SetVoteAuthorityAccount(voteAuthority)
+}
+
+// lockoutSlots Returns the slots of the lockouts
+func lockoutSlots(lockouts []Lockout) []uint64 {
+	slots := make([]uint64, len(lockouts))
+	for i, lockout := range lockouts {
+		slots[i] = lockout.Slot
+	}
+	return slots
+}
+
+// writeHashAndTimestamp write the bank hash and the optional timestamp ending the vote instructions
+func writeHashAndTimestamp(encoder *encodbin.Encoder, hash common.Hash, timestamp *int64) (err error) {
+	if err = encoder.Encode(hash); err != nil {
+		return err
+	}
+	if err = encoder.WriteOption(timestamp != nil, encodbin.OptionKindBorsh); err != nil {
+		return err
+	}
+	if timestamp != nil {
+		return encoder.WriteInt64(*timestamp, binary.LittleEndian)
+	}
+	return nil
+}
+
+// readHashAndTimestamp read the bank hash and the optional timestamp ending the vote instructions
+func readHashAndTimestamp(decoder *encodbin.Decoder) (*common.Hash, *int64, error) {
+	hash := new(common.Hash)
+	if err := decoder.Decode(hash); err != nil {
+		return nil, nil, err
+	}
+	timestamp, err := readTimestamp(decoder)
+	return hash, timestamp, err
+}
+
+// readTimestamp read an optional timestamp
+func readTimestamp(decoder *encodbin.Decoder) (*int64, error) {
+	present, err := decoder.ReadOption(encodbin.OptionKindBorsh)
+	if err != nil || !present {
+		return nil, err
+	}
+	timestamp, err := decoder.ReadInt64(binary.LittleEndian)
+	if err != nil {
+		return nil, err
+	}
+	return &timestamp, nil
+}
@@ -0,0 +1,200 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package vote
+
+import (
+	"encoding/binary"
+	"errors"
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/types/base"
+)
+
+// Vote A Vote instruction with recent votes
+type Vote struct {
+	// The voted slots, oldest first
+	Slots []uint64
+	// The bank hash of the last voted slot
+	Hash *common.Hash
+	// The processing timestamp of the last slot, optional
+	Timestamp *int64
+
+	// [0] = [WRITE] voteAccount
+	// ··········· Vote account to vote with
+	//
+	// [1] = [] slotHashesSysvar
+	// ··········· Slot hashes sysvar
+	//
+	// [2] = [] clockSysvar
+	// ··········· Clock sysvar
+	//
+	// [3] = [SIGNER] voteAuthority
+	// ··········· Vote authority
+	base.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewVoteInstructionBuilder creates a new `Vote` instruction builder.
+func NewVoteInstructionBuilder() *Vote {
+	nd := &Vote{
+		AccountMetaSlice: make([]*base.AccountMeta, 4),
+	}
+	nd.AccountMetaSlice[1] = base.Meta(base.SysVarSlotHashesPubkey)
+	nd.AccountMetaSlice[2] = base.Meta(base.SysVarClockPubkey)
+	return nd
+}
+
+// SetSlots sets the "slots" parameter.
+// The voted slots, oldest first.
+func (v *Vote) SetSlots(slots ...uint64) *Vote {
+	v.Slots = slots
+	return v
+}
+
+// SetHash sets the "hash" parameter.
+// The bank hash of the last voted slot.
+func (v *Vote) SetHash(hash common.Hash) *Vote {
+	v.Hash = &hash
+	return v
+}
+
+// SetTimestamp sets the "timestamp" parameter.
+// The processing timestamp of the last slot.
+func (v *Vote) SetTimestamp(timestamp int64) *Vote {
+	v.Timestamp = &timestamp
+	return v
+}
+
+// SetVoteAccount sets the "voteAccount" account.
+// Vote account to vote with.
+func (v *Vote) SetVoteAccount(voteAccount common.Address) *Vote {
+	v.AccountMetaSlice[0] = base.Meta(voteAccount).WRITE()
+	return v
+}
+
+// GetVoteAccount gets the "voteAccount" account.
+func (v *Vote) GetVoteAccount() *base.AccountMeta {
+	return v.AccountMetaSlice[0]
+}
+
+// SetVoteAuthorityAccount sets the "voteAuthority" account.
+// Vote authority.
+func (v *Vote) SetVoteAuthorityAccount(voteAuthority common.Address) *Vote {
+	v.AccountMetaSlice[3] = base.Meta(voteAuthority).SIGNER()
+	return v
+}
+
+// GetVoteAuthorityAccount gets the "voteAuthority" account.
+func (v *Vote) GetVoteAuthorityAccount() *base.AccountMeta {
+	return v.AccountMetaSlice[3]
+}
+
+func (v Vote) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{BaseVariant: encodbin.BaseVariant{
+		Impl:   v,
+		TypeID: encodbin.TypeIDFromUint32(Instruction_Vote, binary.LittleEndian),
+	}}, opts)
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (v Vote) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
+	if err := v.Validate(); err != nil {
+		return nil, err
+	}
+	return v.Build(opts...), nil
+}
+
+func (v *Vote) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if len(v.Slots) == 0 {
+			return errors.New("Slots parameter is not set")
+		}
+		if v.Hash == nil {
+			return errors.New("Hash parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if v.AccountMetaSlice[0] == nil {
+			return &base.MissingAccountError{Account: "VoteAccount"}
+		}
+		if v.AccountMetaSlice[3] == nil {
+			return &base.MissingAccountError{Account: "VoteAuthority"}
+		}
+	}
+	return nil
+}
+
+func (v Vote) MarshalWithEncoder(encoder *encodbin.Encoder) (err error) {
+	// Serialize `Slots` param, as a bincode Vec<u64>:
+	if err = encoder.WriteUint64(uint64(len(v.Slots)), binary.LittleEndian); err != nil {
+		return err
+	}
+	for _, slot := range v.Slots {
+		if err = encoder.WriteUint64(slot, binary.LittleEndian); err != nil {
+			return err
+		}
+	}
+	// Serialize `Hash` param:
+	if err = encoder.Encode(*v.Hash); err != nil {
+		return err
+	}
+	// Serialize `Timestamp` param:
+	if err = encoder.WriteOption(v.Timestamp != nil, encodbin.OptionKindBorsh); err != nil {
+		return err
+	}
+	if v.Timestamp != nil {
+		return encoder.WriteInt64(*v.Timestamp, binary.LittleEndian)
+	}
+	return nil
+}
+
+func (v *Vote) UnmarshalWithDecoder(decoder *encodbin.Decoder) (err error) {
+	// Deserialize `Slots`:
+	length, err := readLength(decoder, 8)
+	if err != nil {
+		return err
+	}
+	v.Slots = make([]uint64, length)
+	for i := range v.Slots {
+		if v.Slots[i], err = decoder.ReadUint64(binary.LittleEndian); err != nil {
+			return err
+		}
+	}
+	// Deserialize `Hash` and `Timestamp`:
+	v.Hash, v.Timestamp, err = readHashAndTimestamp(decoder)
+	return err
+}
+
+// VotedSlots Returns the voted slots, oldest first
+func (v *Vote) VotedSlots() []uint64 {
+	return v.Slots
+}
+
+// VotedHash Returns the bank hash of the last voted slot
+func (v *Vote) VotedHash() common.Hash {
+	if v.Hash == nil {
+		return common.Hash{}
+	}
+	return *v.Hash
+}
+
+// NewVoteInstruction declares a new Vote instruction with the provided parameters and accounts.
+func NewVoteInstruction(
+	// Parameters:
+	slots []uint64,
+	hash common.Hash,
+	// Accounts:
+	voteAccount common.Address,
+	voteAuthority common.Address,
+) *Vote {
+	return NewVoteInstructionBuilder().
+		SetSlots(slots...).
+		SetHash(hash).
+		SetVoteAccount(voteAccount).
+		SetVoteAuthorityAccount(voteAuthority)
+}
@@ -0,0 +1,140 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package vote
+
+import (
+	"encoding/binary"
+	"errors"
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/types/base"
+)
+
+// Withdraw Withdraw some amount of funds
+type Withdraw struct {
+	// The lamports to withdraw
+	Lamports *uint64
+
+	// [0] = [WRITE] voteAccount
+	// ··········· Vote account to withdraw from
+	//
+	// [1] = [WRITE] recipient
+	// ··········· Recipient account
+	//
+	// [2] = [SIGNER] withdrawAuthority
+	// ··········· Withdraw authority
+	base.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewWithdrawInstructionBuilder creates a new `Withdraw` instruction builder.
+func NewWithdrawInstructionBuilder() *Withdraw {
+	nd := &Withdraw{
+		AccountMetaSlice: make([]*base.AccountMeta, 3),
+	}
+	return nd
+}
+
+// SetLamports sets the "lamports" parameter.
+// The lamports to withdraw.
+func (wd *Withdraw) SetLamports(lamports uint64) *Withdraw {
+	wd.Lamports = &lamports
+	return wd
+}
+
+// SetVoteAccount sets the "voteAccount" account.
+// Vote account to withdraw from.
+func (wd *Withdraw) SetVoteAccount(voteAccount common.Address) *Withdraw {
+	wd.AccountMetaSlice[0] = base.Meta(voteAccount).WRITE()
+	return wd
+}
+
+// GetVoteAccount gets the "voteAccount" account.
+func (wd *Withdraw) GetVoteAccount() *base.AccountMeta {
+	return wd.AccountMetaSlice[0]
+}
+
+// SetRecipientAccount sets the "recipient" account.
+// Recipient account.
+func (wd *Withdraw) SetRecipientAccount(recipient common.Address) *Withdraw {
+	wd.AccountMetaSlice[1] = base.Meta(recipient).WRITE()
+	return wd
+}
+
+// GetRecipientAccount gets the "recipient" account.
+func (wd *Withdraw) GetRecipientAccount() *base.AccountMeta {
+	return wd.AccountMetaSlice[1]
+}
+
+// SetWithdrawAuthorityAccount sets the "withdrawAuthority" account.
+// Withdraw authority.
+func (wd *Withdraw) SetWithdrawAuthorityAccount(withdrawAuthority common.Address) *Withdraw {
+	wd.AccountMetaSlice[2] = base.Meta(withdrawAuthority).SIGNER()
+	return wd
+}
+
+// GetWithdrawAuthorityAccount gets the "withdrawAuthority" account.
+func (wd *Withdraw) GetWithdrawAuthorityAccount() *base.AccountMeta {
+	return wd.AccountMetaSlice[2]
+}
+
+func (wd Withdraw) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{BaseVariant: encodbin.BaseVariant{
+		Impl:   wd,
+		TypeID: encodbin.TypeIDFromUint32(Instruction_Withdraw, binary.LittleEndian),
+	}}, opts)
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (wd Withdraw) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
+	if err := wd.Validate(); err != nil {
+		return nil, err
+	}
+	return wd.Build(opts...), nil
+}
+
+func (wd *Withdraw) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if wd.Lamports == nil {
+			return errors.New("Lamports parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if wd.AccountMetaSlice[0] == nil {
+			return &base.MissingAccountError{Account: "VoteAccount"}
+		}
+		if wd.AccountMetaSlice[1] == nil {
+			return &base.MissingAccountError{Account: "Recipient"}
+		}
+		if wd.AccountMetaSlice[2] == nil {
+			return &base.MissingAccountError{Account: "WithdrawAuthority"}
+		}
+	}
+	return nil
+}
+
+func (wd Withdraw) MarshalWithEncoder(encoder *encodbin.Encoder) (err error) {
+	// Serialize `Lamports` param:
+	return encoder.WriteUint64(*wd.Lamports, binary.LittleEndian)
+}
+
+// NewWithdrawInstruction declares a new Withdraw instruction with the provided parameters and accounts.
+func NewWithdrawInstruction(
+	// Parameters:
+	lamports uint64,
+	// Accounts:
+	voteAccount common.Address,
+	recipient common.Address,
+	withdrawAuthority common.Address,
+) *Withdraw {
+	return NewWithdrawInstructionBuilder().
+		SetLamports(lamports).
+		SetVoteAccount(voteAccount).
+		SetRecipientAccount(recipient).
+		SetWithdrawAuthorityAccount(withdrawAuthority)
+}
@@ -0,0 +1,165 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package vote
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/types"
+	"github.com/cielu/go-solana/types/base"
+)
+
+const (
+	// Initialize a vote account
+	Instruction_InitializeAccount uint32 = iota
+
+	// Authorize a key to send votes or issue a withdrawal
+	Instruction_Authorize
+
+	// A Vote instruction with recent votes
+	Instruction_Vote
+
+	// Withdraw some amount of funds
+	Instruction_Withdraw
+
+	// Update the vote account's validator identity (node_pubkey)
+	Instruction_UpdateValidatorIdentity
+
+	// Update the commission for the vote account
+	Instruction_UpdateCommission
+
+	// A Vote instruction with recent votes and a switching proof hash
+	Instruction_VoteSwitch
+
+	// Authorize a key to send votes or issue a withdrawal, the new authority signs
+	Instruction_AuthorizeChecked
+
+	// Update the onchain vote state for the signer
+	Instruction_UpdateVoteState
+
+	// Update the onchain vote state for the signer along with a switching proof
+	Instruction_UpdateVoteStateSwitch
+
+	// Authorize a key derived from a seed to send votes or issue a withdrawal
+	Instruction_AuthorizeWithSeed
+
+	// Authorize a key derived from a seed, the new authority signs
+	Instruction_AuthorizeCheckedWithSeed
+
+	// Update the onchain vote state for the signer, in the compact encoding
+	Instruction_CompactUpdateVoteState
+
+	// Update the onchain vote state for the signer along with a switching proof, in the compact encoding
+	Instruction_CompactUpdateVoteStateSwitch
+
+	// Sync the onchain vote state with the local tower
+	Instruction_TowerSync
+
+	// Sync the onchain vote state with the local tower along with a switching proof
+	Instruction_TowerSyncSwitch
+)
+
+// VoteAuthorize the kind of authority
+type VoteAuthorize uint32
+
+const (
+	// VoteAuthorizeVoter authority to send votes
+	VoteAuthorizeVoter VoteAuthorize = iota
+	// VoteAuthorizeWithdrawer authority to withdraw and update the vote account
+	VoteAuthorizeWithdrawer
+)
+
+type Instruction struct {
+	encodbin.BaseVariant
+	// programID overrides base.VoteProgramID when set, see base.WithProgramID
+	programID common.Address
+}
+
+// withBuildOptions applies the options of Build to the instruction
+func withBuildOptions(inst *Instruction, opts []base.BuildOption) *Instruction {
+	inst.programID = base.NewBuildOptions(opts...).ProgramID
+	return inst
+}
+
+func (inst *Instruction) ProgramID() common.Address {
+	if !inst.programID.IsEmpty() {
+		return inst.programID
+	}
+	return base.VoteProgramID
+}
+
+func (inst *Instruction) Accounts() (out []*base.AccountMeta) {
+	return inst.Impl.(base.AccountsGettable).GetAccounts()
+}
+
+func (inst *Instruction) Data() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := encodbin.NewBinEncoder(buf).Encode(inst); err != nil {
+		return nil, fmt.Errorf("unable to encode instruction: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (inst *Instruction) MarshalWithEncoder(encoder *encodbin.Encoder) error {
+	err := encoder.WriteUint32(inst.TypeID.Uint32(), binary.LittleEndian)
+	if err != nil {
+		return fmt.Errorf("unable to write variant type: %w", err)
+	}
+	return encoder.Encode(inst.Impl)
+}
+
+// VoteInstruction a decoded vote instruction, Vote, UpdateVoteState, CompactUpdateVoteState or TowerSync,
+// or their switch variant
+type VoteInstruction interface {
+	// VotedSlots Returns the voted slots, oldest first
+	VotedSlots() []uint64
+	// VotedHash Returns the bank hash of the last voted slot
+	VotedHash() common.Hash
+}
+
+func init() {
+	types.RegisterInstructionDecoder(base.VoteProgramID, DecodeInstruction)
+}
+
+// DecodeInstruction decodes the accounts and data of a vote program instruction into its
+// typed instruction, a *Vote, *UpdateVoteState, *CompactUpdateVoteState or *TowerSync, or their
+// switch variant, see VoteInstruction
+func DecodeInstruction(accounts []*base.AccountMeta, data []byte) (interface{}, error) {
+	decoder := encodbin.NewBinDecoder(data)
+	typeID, err := decoder.ReadUint32(binary.LittleEndian)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read instruction type: %w", err)
+	}
+	var inst base.AccountsSettable
+	switch typeID {
+	case Instruction_Vote:
+		inst = new(Vote)
+	case Instruction_UpdateVoteState:
+		inst = new(UpdateVoteState)
+	case Instruction_CompactUpdateVoteState:
+		inst = new(CompactUpdateVoteState)
+	case Instruction_VoteSwitch:
+		inst = new(VoteSwitch)
+	case Instruction_UpdateVoteStateSwitch:
+		inst = new(UpdateVoteStateSwitch)
+	case Instruction_CompactUpdateVoteStateSwitch:
+		inst = new(CompactUpdateVoteStateSwitch)
+	case Instruction_TowerSync:
+		inst = new(TowerSync)
+	case Instruction_TowerSyncSwitch:
+		inst = new(TowerSyncSwitch)
+	default:
+		return nil, fmt.Errorf("unsupported vote instruction: %d", typeID)
+	}
+	if err = decoder.Decode(inst); err != nil {
+		return nil, fmt.Errorf("unable to decode vote instruction %d: %w", typeID, err)
+	}
+	if err = inst.SetAccounts(accounts); err != nil {
+		return nil, err
+	}
+	return inst, nil
+}
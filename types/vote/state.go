@@ -0,0 +1,218 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package vote
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/pkg/encodbin"
+)
+
+const (
+	// VoteStateVersionV0_23_5 the legacy layout, not supported by DecodeVoteState
+	VoteStateVersionV0_23_5 uint32 = iota
+	// VoteStateVersionV1_14_11 votes are stored without latency
+	VoteStateVersionV1_14_11
+	// VoteStateVersionCurrent votes are stored with latency
+	VoteStateVersionCurrent
+)
+
+// MaxPriorVoters the size of the prior voters circular buffer
+const MaxPriorVoters = 32
+
+// Lockout a vote and the number of confirmations it received
+type Lockout struct {
+	// only set by the current version
+	Latency           uint8
+	Slot              uint64
+	ConfirmationCount uint32
+}
+
+// AuthorizedVoter the voter authorized for an epoch
+type AuthorizedVoter struct {
+	Epoch  uint64
+	Pubkey common.Address
+}
+
+// PriorVoter a voter authorized from epoch Start to End
+type PriorVoter struct {
+	Pubkey common.Address
+	Start  uint64
+	End    uint64
+}
+
+// EpochCredits the credits earned in an epoch
+type EpochCredits struct {
+	Epoch           uint64
+	Credits         uint64
+	PreviousCredits uint64
+}
+
+// BlockTimestamp the last timestamp a validator voted with
+type BlockTimestamp struct {
+	Slot      uint64
+	Timestamp int64
+}
+
+// VoteState the state of a vote program account
+type VoteState struct {
+	Version              uint32
+	NodePubkey           common.Address
+	AuthorizedWithdrawer common.Address
+	Commission           uint8
+	Votes                []Lockout
+	RootSlot             *uint64
+	AuthorizedVoters     []AuthorizedVoter
+	PriorVoters          []PriorVoter
+	EpochCredits         []EpochCredits
+	LastTimestamp        BlockTimestamp
+}
+
+// DecodeVoteState decode the data of a vote program account
+func DecodeVoteState(data []byte) (state VoteState, err error) {
+	decoder := encodbin.NewBinDecoder(data)
+	if state.Version, err = decoder.ReadUint32(binary.LittleEndian); err != nil {
+		return state, fmt.Errorf("unable to read vote state version: %w", err)
+	}
+	if state.Version != VoteStateVersionV1_14_11 && state.Version != VoteStateVersionCurrent {
+		return state, fmt.Errorf("unsupported vote state version: %d", state.Version)
+	}
+	if err = decoder.Decode(&state.NodePubkey); err != nil {
+		return state, fmt.Errorf("unable to read node pubkey: %w", err)
+	}
+	if err = decoder.Decode(&state.AuthorizedWithdrawer); err != nil {
+		return state, fmt.Errorf("unable to read authorized withdrawer: %w", err)
+	}
+	if state.Commission, err = decoder.ReadUint8(); err != nil {
+		return state, fmt.Errorf("unable to read commission: %w", err)
+	}
+	if state.Votes, err = decodeVotes(decoder, state.Version == VoteStateVersionCurrent); err != nil {
+		return state, fmt.Errorf("unable to read votes: %w", err)
+	}
+	present, err := decoder.ReadOption(encodbin.OptionKindBorsh)
+	if err != nil {
+		return state, fmt.Errorf("unable to read root slot: %w", err)
+	}
+	if present {
+		rootSlot, err := decoder.ReadUint64(binary.LittleEndian)
+		if err != nil {
+			return state, fmt.Errorf("unable to read root slot: %w", err)
+		}
+		state.RootSlot = &rootSlot
+	}
+	if state.AuthorizedVoters, err = decodeAuthorizedVoters(decoder); err != nil {
+		return state, fmt.Errorf("unable to read authorized voters: %w", err)
+	}
+	if state.PriorVoters, err = decodePriorVoters(decoder); err != nil {
+		return state, fmt.Errorf("unable to read prior voters: %w", err)
+	}
+	if state.EpochCredits, err = decodeEpochCredits(decoder); err != nil {
+		return state, fmt.Errorf("unable to read epoch credits: %w", err)
+	}
+	if err = decoder.Decode(&state.LastTimestamp); err != nil {
+		return state, fmt.Errorf("unable to read last timestamp: %w", err)
+	}
+	return state, nil
+}
+
+// readLength read a bincode u64 length and check it against the remaining data
+func readLength(decoder *encodbin.Decoder, itemSize int) (int, error) {
+	length, err := decoder.ReadUint64(binary.LittleEndian)
+	if err != nil {
+		return 0, err
+	}
+	if length > uint64(decoder.Remaining()/itemSize) {
+		return 0, fmt.Errorf("invalid length: %d", length)
+	}
+	return int(length), nil
+}
+
+func decodeVotes(decoder *encodbin.Decoder, withLatency bool) ([]Lockout, error) {
+	itemSize := 12
+	if withLatency {
+		itemSize++
+	}
+	length, err := readLength(decoder, itemSize)
+	if err != nil {
+		return nil, err
+	}
+	votes := make([]Lockout, length)
+	for i := range votes {
+		if withLatency {
+			if votes[i].Latency, err = decoder.ReadUint8(); err != nil {
+				return nil, err
+			}
+		}
+		if votes[i].Slot, err = decoder.ReadUint64(binary.LittleEndian); err != nil {
+			return nil, err
+		}
+		if votes[i].ConfirmationCount, err = decoder.ReadUint32(binary.LittleEndian); err != nil {
+			return nil, err
+		}
+	}
+	return votes, nil
+}
+
+func decodeAuthorizedVoters(decoder *encodbin.Decoder) ([]AuthorizedVoter, error) {
+	length, err := readLength(decoder, 40)
+	if err != nil {
+		return nil, err
+	}
+	voters := make([]AuthorizedVoter, length)
+	for i := range voters {
+		if err = decoder.Decode(&voters[i]); err != nil {
+			return nil, err
+		}
+	}
+	return voters, nil
+}
+
+// decodePriorVoters read the circular buffer and return the non-empty entries, oldest first
+func decodePriorVoters(decoder *encodbin.Decoder) ([]PriorVoter, error) {
+	var buf [MaxPriorVoters]PriorVoter
+	for i := range buf {
+		if err := decoder.Decode(&buf[i]); err != nil {
+			return nil, err
+		}
+	}
+	idx, err := decoder.ReadUint64(binary.LittleEndian)
+	if err != nil {
+		return nil, err
+	}
+	isEmpty, err := decoder.ReadBool()
+	if err != nil {
+		return nil, err
+	}
+	if isEmpty {
+		return nil, nil
+	}
+	if idx >= MaxPriorVoters {
+		return nil, fmt.Errorf("invalid prior voters index: %d", idx)
+	}
+	var voters []PriorVoter
+	for i := uint64(1); i <= MaxPriorVoters; i++ {
+		voter := buf[(idx+i)%MaxPriorVoters]
+		if voter.Pubkey.IsEmpty() && voter.Start == 0 && voter.End == 0 {
+			continue
+		}
+		voters = append(voters, voter)
+	}
+	return voters, nil
+}
+
+func decodeEpochCredits(decoder *encodbin.Decoder) ([]EpochCredits, error) {
+	length, err := readLength(decoder, 24)
+	if err != nil {
+		return nil, err
+	}
+	credits := make([]EpochCredits, length)
+	for i := range credits {
+		if err = decoder.Decode(&credits[i]); err != nil {
+			return nil, err
+		}
+	}
+	return credits, nil
+}
@@ -0,0 +1,199 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package vote
+
+import (
+	"encoding/binary"
+	"errors"
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/types/base"
+)
+
+// The switch variants of the vote instructions, sent by a validator switching its votes to another
+// fork. Their data is the data of the vote instruction followed by the switching proof hash
+
+// VoteSwitch A Vote instruction, with the hash of the switching proof
+type VoteSwitch struct {
+	Vote
+	// The hash of the switching proof
+	ProofHash *common.Hash
+}
+
+func (sw VoteSwitch) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{BaseVariant: encodbin.BaseVariant{
+		Impl:   sw,
+		TypeID: encodbin.TypeIDFromUint32(Instruction_VoteSwitch, binary.LittleEndian),
+	}}, opts)
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (sw VoteSwitch) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
+	if err := sw.Validate(); err != nil {
+		return nil, err
+	}
+	return sw.Build(opts...), nil
+}
+
+func (sw *VoteSwitch) Validate() error {
+	if sw.ProofHash == nil {
+		return errors.New("ProofHash parameter is not set")
+	}
+	return sw.Vote.Validate()
+}
+
+func (sw VoteSwitch) MarshalWithEncoder(encoder *encodbin.Encoder) error {
+	if err := sw.Vote.MarshalWithEncoder(encoder); err != nil {
+		return err
+	}
+	return encoder.Encode(*sw.ProofHash)
+}
+
+func (sw *VoteSwitch) UnmarshalWithDecoder(decoder *encodbin.Decoder) error {
+	if err := sw.Vote.UnmarshalWithDecoder(decoder); err != nil {
+		return err
+	}
+	sw.ProofHash = new(common.Hash)
+	return decoder.Decode(sw.ProofHash)
+}
+
+// UpdateVoteStateSwitch An UpdateVoteState instruction, with the hash of the switching proof
+type UpdateVoteStateSwitch struct {
+	UpdateVoteState
+	// The hash of the switching proof
+	ProofHash *common.Hash
+}
+
+func (sw UpdateVoteStateSwitch) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{BaseVariant: encodbin.BaseVariant{
+		Impl:   sw,
+		TypeID: encodbin.TypeIDFromUint32(Instruction_UpdateVoteStateSwitch, binary.LittleEndian),
+	}}, opts)
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (sw UpdateVoteStateSwitch) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
+	if err := sw.Validate(); err != nil {
+		return nil, err
+	}
+	return sw.Build(opts...), nil
+}
+
+func (sw *UpdateVoteStateSwitch) Validate() error {
+	if sw.ProofHash == nil {
+		return errors.New("ProofHash parameter is not set")
+	}
+	return sw.UpdateVoteState.Validate()
+}
+
+func (sw UpdateVoteStateSwitch) MarshalWithEncoder(encoder *encodbin.Encoder) error {
+	if err := sw.UpdateVoteState.MarshalWithEncoder(encoder); err != nil {
+		return err
+	}
+	return encoder.Encode(*sw.ProofHash)
+}
+
+func (sw *UpdateVoteStateSwitch) UnmarshalWithDecoder(decoder *encodbin.Decoder) error {
+	if err := sw.UpdateVoteState.UnmarshalWithDecoder(decoder); err != nil {
+		return err
+	}
+	sw.ProofHash = new(common.Hash)
+	return decoder.Decode(sw.ProofHash)
+}
+
+// CompactUpdateVoteStateSwitch A CompactUpdateVoteState instruction, with the hash of the switching proof
+type CompactUpdateVoteStateSwitch struct {
+	CompactUpdateVoteState
+	// The hash of the switching proof
+	ProofHash *common.Hash
+}
+
+func (sw CompactUpdateVoteStateSwitch) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{BaseVariant: encodbin.BaseVariant{
+		Impl:   sw,
+		TypeID: encodbin.TypeIDFromUint32(Instruction_CompactUpdateVoteStateSwitch, binary.LittleEndian),
+	}}, opts)
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (sw CompactUpdateVoteStateSwitch) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
+	if err := sw.Validate(); err != nil {
+		return nil, err
+	}
+	return sw.Build(opts...), nil
+}
+
+func (sw *CompactUpdateVoteStateSwitch) Validate() error {
+	if sw.ProofHash == nil {
+		return errors.New("ProofHash parameter is not set")
+	}
+	return sw.CompactUpdateVoteState.Validate()
+}
+
+func (sw CompactUpdateVoteStateSwitch) MarshalWithEncoder(encoder *encodbin.Encoder) error {
+	if err := sw.CompactUpdateVoteState.MarshalWithEncoder(encoder); err != nil {
+		return err
+	}
+	return encoder.Encode(*sw.ProofHash)
+}
+
+func (sw *CompactUpdateVoteStateSwitch) UnmarshalWithDecoder(decoder *encodbin.Decoder) error {
+	if err := sw.CompactUpdateVoteState.UnmarshalWithDecoder(decoder); err != nil {
+		return err
+	}
+	sw.ProofHash = new(common.Hash)
+	return decoder.Decode(sw.ProofHash)
+}
+
+// TowerSyncSwitch A TowerSync instruction, with the hash of the switching proof
+type TowerSyncSwitch struct {
+	TowerSync
+	// The hash of the switching proof
+	ProofHash *common.Hash
+}
+
+func (sw TowerSyncSwitch) Build(opts ...base.BuildOption) *Instruction {
+	return withBuildOptions(&Instruction{BaseVariant: encodbin.BaseVariant{
+		Impl:   sw,
+		TypeID: encodbin.TypeIDFromUint32(Instruction_TowerSyncSwitch, binary.LittleEndian),
+	}}, opts)
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (sw TowerSyncSwitch) ValidateAndBuild(opts ...base.BuildOption) (*Instruction, error) {
+	if err := sw.Validate(); err != nil {
+		return nil, err
+	}
+	return sw.Build(opts...), nil
+}
+
+func (sw *TowerSyncSwitch) Validate() error {
+	if sw.ProofHash == nil {
+		return errors.New("ProofHash parameter is not set")
+	}
+	return sw.TowerSync.Validate()
+}
+
+func (sw TowerSyncSwitch) MarshalWithEncoder(encoder *encodbin.Encoder) error {
+	if err := sw.TowerSync.MarshalWithEncoder(encoder); err != nil {
+		return err
+	}
+	return encoder.Encode(*sw.ProofHash)
+}
+
+func (sw *TowerSyncSwitch) UnmarshalWithDecoder(decoder *encodbin.Decoder) error {
+	if err := sw.TowerSync.UnmarshalWithDecoder(decoder); err != nil {
+		return err
+	}
+	sw.ProofHash = new(common.Hash)
+	return decoder.Decode(sw.ProofHash)
+}
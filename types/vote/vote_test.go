@@ -0,0 +1,292 @@
+package vote
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"testing"
+
+	"github.com/cielu/go-solana/common"
+	"github.com/cielu/go-solana/pkg/encodbin"
+	"github.com/cielu/go-solana/types"
+	"github.com/cielu/go-solana/types/base"
+)
+
+func TestUpdateCommissionEncode(t *testing.T) {
+	var (
+		voteAccount = common.StrToAddress("CertusDeBmqN8ZawdkxK5kFGMwBXdudvWHYwtNgNhvLu")
+		withdrawer  = common.StrToAddress("F8HCC3DyoR6KN9SSK9NL1V6weRgsEvp8hjL26EnTxNTF")
+	)
+	inst, err := NewUpdateCommissionInstruction(7, voteAccount, withdrawer).ValidateAndBuild()
+	if err != nil {
+		t.Fatalf("ValidateAndBuild Failed: %s", err)
+	}
+	if inst.ProgramID() != base.VoteProgramID {
+		t.Errorf("ProgramID Err ==> Got %s, Want: %s", inst.ProgramID(), base.VoteProgramID)
+	}
+	data, err := inst.Data()
+	if err != nil {
+		t.Fatalf("Data Failed: %s", err)
+	}
+	if want := []byte{5, 0, 0, 0, 7}; !bytes.Equal(data, want) {
+		t.Errorf("Data Err ==> Got %v, Want: %v", data, want)
+	}
+	accounts := inst.Accounts()
+	if len(accounts) != 2 || !accounts[0].IsWritable || !accounts[1].IsSigner || accounts[1].PublicKey != withdrawer {
+		t.Errorf("Accounts Err ==> Got %v", accounts)
+	}
+	if _, err = NewUpdateCommissionInstruction(101, voteAccount, withdrawer).ValidateAndBuild(); err == nil {
+		t.Errorf("ValidateAndBuild with commission over 100 should fail")
+	}
+}
+
+// voteAccountBlob encode a VoteState (current version) following the on-chain layout
+func voteAccountBlob(t *testing.T, node, withdrawer, voter common.Address) []byte {
+	buf := new(bytes.Buffer)
+	enc := encodbin.NewBinEncoder(buf)
+	le := binary.LittleEndian
+	write := func(err error) {
+		if err != nil {
+			t.Fatalf("encode vote account Failed: %s", err)
+		}
+	}
+	write(enc.WriteUint32(VoteStateVersionCurrent, le))
+	write(enc.Encode(node))
+	write(enc.Encode(withdrawer))
+	write(enc.WriteUint8(10))
+	// votes
+	write(enc.WriteUint64(2, le))
+	for i, slot := range []uint64{301_000_001, 301_000_002} {
+		write(enc.WriteUint8(1))
+		write(enc.WriteUint64(slot, le))
+		write(enc.WriteUint32(uint32(2-i), le))
+	}
+	// root slot
+	write(enc.WriteOption(true, encodbin.OptionKindBorsh))
+	write(enc.WriteUint64(301_000_000, le))
+	// authorized voters
+	write(enc.WriteUint64(1, le))
+	write(enc.WriteUint64(696, le))
+	write(enc.Encode(voter))
+	// prior voters, one entry at index 0
+	write(enc.Encode(withdrawer))
+	write(enc.WriteUint64(600, le))
+	write(enc.WriteUint64(650, le))
+	write(enc.WriteBytes(make([]byte, 48*(MaxPriorVoters-1)), false))
+	write(enc.WriteUint64(0, le))
+	write(enc.WriteBool(false))
+	// epoch credits
+	write(enc.WriteUint64(1, le))
+	write(enc.WriteUint64(695, le))
+	write(enc.WriteUint64(1_500, le))
+	write(enc.WriteUint64(1_000, le))
+	// last timestamp
+	write(enc.WriteUint64(301_000_002, le))
+	write(enc.WriteInt64(1_730_000_000, le))
+	// accounts are allocated with VoteState::size_of, trailing zeroes
+	write(enc.WriteBytes(make([]byte, 64), false))
+	return buf.Bytes()
+}
+
+func TestDecodeVoteState(t *testing.T) {
+	var (
+		node       = common.StrToAddress("CertusDeBmqN8ZawdkxK5kFGMwBXdudvWHYwtNgNhvLu")
+		withdrawer = common.StrToAddress("F8HCC3DyoR6KN9SSK9NL1V6weRgsEvp8hjL26EnTxNTF")
+		voter      = common.StrToAddress("BZYExy8yxFZF6jTp4h7X98dPLBcbQDFhvHXPdTjDb2ag")
+	)
+	state, err := DecodeVoteState(voteAccountBlob(t, node, withdrawer, voter))
+	if err != nil {
+		t.Fatalf("DecodeVoteState Failed: %s", err)
+	}
+	if state.NodePubkey != node || state.AuthorizedWithdrawer != withdrawer || state.Commission != 10 {
+		t.Errorf("VoteState Err ==> Got %+v", state)
+	}
+	if len(state.Votes) != 2 || state.Votes[1].Slot != 301_000_002 || state.Votes[0].ConfirmationCount != 2 || state.Votes[0].Latency != 1 {
+		t.Errorf("Votes Err ==> Got %+v", state.Votes)
+	}
+	if state.RootSlot == nil || *state.RootSlot != 301_000_000 {
+		t.Errorf("RootSlot Err ==> Got %v, Want: %d", state.RootSlot, 301_000_000)
+	}
+	if len(state.AuthorizedVoters) != 1 || state.AuthorizedVoters[0] != (AuthorizedVoter{Epoch: 696, Pubkey: voter}) {
+		t.Errorf("AuthorizedVoters Err ==> Got %+v", state.AuthorizedVoters)
+	}
+	if len(state.PriorVoters) != 1 || state.PriorVoters[0] != (PriorVoter{Pubkey: withdrawer, Start: 600, End: 650}) {
+		t.Errorf("PriorVoters Err ==> Got %+v", state.PriorVoters)
+	}
+	if len(state.EpochCredits) != 1 || state.EpochCredits[0] != (EpochCredits{Epoch: 695, Credits: 1_500, PreviousCredits: 1_000}) {
+		t.Errorf("EpochCredits Err ==> Got %+v", state.EpochCredits)
+	}
+	if state.LastTimestamp != (BlockTimestamp{Slot: 301_000_002, Timestamp: 1_730_000_000}) {
+		t.Errorf("LastTimestamp Err ==> Got %+v", state.LastTimestamp)
+	}
+	// legacy layout
+	if _, err = DecodeVoteState([]byte{0, 0, 0, 0}); err == nil {
+		t.Errorf("DecodeVoteState with version 0 should fail")
+	}
+}
+
+// compactVoteData a CompactUpdateVoteState instruction data, following the on-chain layout
+func compactVoteData(hash common.Hash) []byte {
+	u64 := func(v uint64) []byte {
+		b := make([]byte, 8)
+		binary.LittleEndian.PutUint64(b, v)
+		return b
+	}
+	// root
+	data := append([]byte{12, 0, 0, 0}, u64(301_000_000)...)
+	// short_vec of lockout offsets: (varint offset, u8 confirmation count)
+	data = append(data, 3, 0x81, 0x01, 31, 1, 2, 2, 1)
+	data = append(data, hash[:]...)
+	// timestamp
+	data = append(data, 1)
+	return append(data, u64(1_729_000_000)...)
+}
+
+func TestDecodeVoteInstruction(t *testing.T) {
+	var (
+		voteAccount = common.StrToAddress("CertusDeBmqN8ZawdkxK5kFGMwBXdudvWHYwtNgNhvLu")
+		authority   = common.StrToAddress("F8HCC3DyoR6KN9SSK9NL1V6weRgsEvp8hjL26EnTxNTF")
+		hash        = common.Hash{1, 2, 3, 4, 5, 6, 7, 8, 9}
+		accounts    = []*base.AccountMeta{base.Meta(voteAccount).WRITE(), base.Meta(authority).SIGNER()}
+		wantSlots   = []uint64{301_000_129, 301_000_130, 301_000_132}
+	)
+	decoded, err := types.DecodeInstruction(base.VoteProgramID, compactVoteData(hash), accounts)
+	if err != nil {
+		t.Fatalf("DecodeInstruction Failed: %s", err)
+	}
+	compact, ok := decoded.(*CompactUpdateVoteState)
+	if !ok {
+		t.Fatalf("DecodeInstruction Err ==> Got %T, Want: *CompactUpdateVoteState", decoded)
+	}
+	if !reflect.DeepEqual(compact.VotedSlots(), wantSlots) {
+		t.Errorf("VotedSlots Err ==> Got %v, Want: %v", compact.VotedSlots(), wantSlots)
+	}
+	if compact.VotedHash() != hash {
+		t.Errorf("VotedHash Err ==> Got %s, Want: %s", compact.VotedHash(), hash)
+	}
+	if compact.Root == nil || *compact.Root != 301_000_000 || compact.Timestamp == nil || *compact.Timestamp != 1_729_000_000 {
+		t.Errorf("Root/Timestamp Err ==> Got %v/%v", compact.Root, compact.Timestamp)
+	}
+	if compact.Lockouts[0].ConfirmationCount != 31 || compact.GetVoteAccount().PublicKey != voteAccount {
+		t.Errorf("Lockouts/Accounts Err ==> Got %v/%v", compact.Lockouts, compact.GetVoteAccount())
+	}
+	// re-encode the decoded instruction
+	inst, err := compact.ValidateAndBuild()
+	if err != nil {
+		t.Fatalf("ValidateAndBuild Failed: %s", err)
+	}
+	if data, _ := inst.Data(); !bytes.Equal(data, compactVoteData(hash)) {
+		t.Errorf("Data Err ==> Got %v, Want: %v", data, compactVoteData(hash))
+	}
+
+	// Vote
+	inst, err = NewVoteInstruction(wantSlots, hash, voteAccount, authority).ValidateAndBuild()
+	if err != nil {
+		t.Fatalf("ValidateAndBuild Failed: %s", err)
+	}
+	data, err := inst.Data()
+	if err != nil {
+		t.Fatalf("Data Failed: %s", err)
+	}
+	if decoded, err = DecodeInstruction(inst.Accounts(), data); err != nil {
+		t.Fatalf("DecodeInstruction Failed: %s", err)
+	}
+	if vote, ok := decoded.(VoteInstruction); !ok || !reflect.DeepEqual(vote.VotedSlots(), wantSlots) || vote.VotedHash() != hash {
+		t.Errorf("Vote Err ==> Got %v", decoded)
+	}
+
+	// UpdateVoteState
+	lockouts := []Lockout{{Slot: wantSlots[0], ConfirmationCount: 3}, {Slot: wantSlots[1], ConfirmationCount: 2}, {Slot: wantSlots[2], ConfirmationCount: 1}}
+	inst, err = NewUpdateVoteStateInstruction(lockouts, hash, voteAccount, authority).SetRoot(301_000_000).ValidateAndBuild()
+	if err != nil {
+		t.Fatalf("ValidateAndBuild Failed: %s", err)
+	}
+	if data, err = inst.Data(); err != nil {
+		t.Fatalf("Data Failed: %s", err)
+	}
+	if decoded, err = DecodeInstruction(inst.Accounts(), data); err != nil {
+		t.Fatalf("DecodeInstruction Failed: %s", err)
+	}
+	update, ok := decoded.(*UpdateVoteState)
+	if !ok || !reflect.DeepEqual(update.Lockouts, lockouts) || *update.Root != 301_000_000 || update.Timestamp != nil {
+		t.Errorf("UpdateVoteState Err ==> Got %v", decoded)
+	}
+
+	// the switch variants end with the switching proof hash
+	proof := common.Hash{9, 8, 7}
+	switchData := append(compactVoteData(hash), proof[:]...)
+	switchData[0] = byte(Instruction_CompactUpdateVoteStateSwitch)
+	if decoded, err = DecodeInstruction(accounts, switchData); err != nil {
+		t.Fatalf("DecodeInstruction Failed: %s", err)
+	}
+	compactSwitch, ok := decoded.(*CompactUpdateVoteStateSwitch)
+	if !ok || compactSwitch.ProofHash == nil || *compactSwitch.ProofHash != proof ||
+		!reflect.DeepEqual(compactSwitch.VotedSlots(), wantSlots) || compactSwitch.GetVoteAccount().PublicKey != voteAccount {
+		t.Fatalf("CompactUpdateVoteStateSwitch Err ==> Got %+v", decoded)
+	}
+	if inst, err = compactSwitch.ValidateAndBuild(); err != nil {
+		t.Fatalf("ValidateAndBuild Failed: %s", err)
+	}
+	if data, _ = inst.Data(); !bytes.Equal(data, switchData) {
+		t.Errorf("Data Err ==> Got %v, Want: %v", data, switchData)
+	}
+	switches := []struct {
+		want  reflect.Type
+		build func(...base.BuildOption) (*Instruction, error)
+	}{
+		{reflect.TypeOf(&VoteSwitch{}), VoteSwitch{Vote: *NewVoteInstruction(wantSlots, hash, voteAccount, authority), ProofHash: &proof}.ValidateAndBuild},
+		{reflect.TypeOf(&UpdateVoteStateSwitch{}), UpdateVoteStateSwitch{UpdateVoteState: *NewUpdateVoteStateInstruction(lockouts, hash, voteAccount, authority), ProofHash: &proof}.ValidateAndBuild},
+	}
+	for _, sw := range switches {
+		if inst, err = sw.build(); err != nil {
+			t.Fatalf("ValidateAndBuild Failed: %s", err)
+		}
+		data, _ = inst.Data()
+		if decoded, err = DecodeInstruction(inst.Accounts(), data); err != nil {
+			t.Fatalf("DecodeInstruction Failed: %s", err)
+		}
+		if vote, ok := decoded.(VoteInstruction); !ok || reflect.TypeOf(decoded) != sw.want ||
+			!reflect.DeepEqual(vote.VotedSlots(), wantSlots) || vote.VotedHash() != hash {
+			t.Errorf("%s Err ==> Got %+v", sw.want, decoded)
+		}
+	}
+	if _, err = (VoteSwitch{Vote: *NewVoteInstruction(wantSlots, hash, voteAccount, authority)}).ValidateAndBuild(); err == nil {
+		t.Errorf("ValidateAndBuild without proof hash should fail")
+	}
+
+	// TowerSync is the compact encoding followed by the block id
+	blockID := common.Hash{7, 7, 7}
+	towerData := append(compactVoteData(hash), blockID[:]...)
+	towerData[0] = byte(Instruction_TowerSync)
+	if decoded, err = types.DecodeInstruction(base.VoteProgramID, towerData, accounts); err != nil {
+		t.Fatalf("DecodeInstruction Failed: %s", err)
+	}
+	tower, ok := decoded.(*TowerSync)
+	if !ok || tower.BlockID == nil || *tower.BlockID != blockID || !reflect.DeepEqual(tower.VotedSlots(), wantSlots) ||
+		tower.VotedHash() != hash || *tower.Root != 301_000_000 || *tower.Timestamp != 1_729_000_000 {
+		t.Fatalf("TowerSync Err ==> Got %+v", decoded)
+	}
+	if inst, err = tower.ValidateAndBuild(); err != nil {
+		t.Fatalf("ValidateAndBuild Failed: %s", err)
+	}
+	if data, _ = inst.Data(); !bytes.Equal(data, towerData) {
+		t.Errorf("Data Err ==> Got %v, Want: %v", data, towerData)
+	}
+	towerSwitch := TowerSyncSwitch{TowerSync: *NewTowerSyncInstruction(lockouts, hash, blockID, voteAccount, authority), ProofHash: &proof}
+	if inst, err = towerSwitch.ValidateAndBuild(); err != nil {
+		t.Fatalf("ValidateAndBuild Failed: %s", err)
+	}
+	data, _ = inst.Data()
+	if decoded, err = DecodeInstruction(inst.Accounts(), data); err != nil {
+		t.Fatalf("DecodeInstruction Failed: %s", err)
+	}
+	if got, ok := decoded.(*TowerSyncSwitch); !ok || *got.BlockID != blockID || *got.ProofHash != proof || !reflect.DeepEqual(got.Lockouts, lockouts) {
+		t.Errorf("TowerSyncSwitch Err ==> Got %+v", decoded)
+	}
+	if _, err = DecodeInstruction(accounts, []byte{7, 0, 0, 0}); err == nil {
+		t.Errorf("DecodeInstruction of an AuthorizeChecked should fail")
+	}
+	if _, err = NewCompactUpdateVoteStateInstruction([]Lockout{{Slot: 5}, {Slot: 4}}, hash, voteAccount, authority).ValidateAndBuild(); err == nil {
+		t.Errorf("ValidateAndBuild with decreasing slots should fail")
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2024 The go-solana Authors
+// This file is part of the go-solana library.
+
+package types
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cielu/go-solana/common"
+)
+
+// EstimatedAPY estimate the annual yield (0.07 == 7%) of the vote account.
+// The per-epoch rate is the credits earned in the most recent epoch
+// ([epoch, credits, previousCredits]) relative to the activated stake,
+// reduced by the commission and compounded over totalEpochsPerYear.
+func (v VoteAccount) EstimatedAPY(totalEpochsPerYear float64) float64 {
+	if v.ActivatedStake == 0 || len(v.EpochCredits) == 0 || totalEpochsPerYear <= 0 {
+		return 0
+	}
+	// credits earned in the latest epoch
+	earned := float64(v.EpochCreditsDelta())
+	if earned == 0 {
+		return 0
+	}
+	// commission is a percentage (0-100) owed to the vote account
+	commission := math.Min(float64(v.Commission), 100)
+	// per epoch rate after commission
+	epochRate := earned / float64(v.ActivatedStake) * (100 - commission) / 100
+	// compound per epoch
+	return math.Pow(1+epochRate, totalEpochsPerYear) - 1
+}
+
+// EpochCreditsDelta Returns the credits earned in the most recent epoch of EpochCredits
+// ([epoch, credits, previousCredits]), 0 without epoch credits
+func (v VoteAccount) EpochCreditsDelta() uint64 {
+	if len(v.EpochCredits) == 0 {
+		return 0
+	}
+	latest := v.EpochCredits[len(v.EpochCredits)-1]
+	if len(latest) < 3 || latest[1] <= latest[2] {
+		return 0
+	}
+	return latest[1] - latest[2]
+}
+
+// SortByStake sorts the current and the delinquent vote accounts by activated stake, highest first
+func (va *RpcVoteAccounts) SortByStake() {
+	for _, accounts := range [][]VoteAccount{va.Current, va.Delinquent} {
+		sort.SliceStable(accounts, func(i, j int) bool {
+			return accounts[i].ActivatedStake > accounts[j].ActivatedStake
+		})
+	}
+}
+
+// FilterByCommission Returns the vote accounts with a commission of at most max percent
+func (va RpcVoteAccounts) FilterByCommission(max uint8) RpcVoteAccounts {
+	filter := func(accounts []VoteAccount) (out []VoteAccount) {
+		for _, account := range accounts {
+			if account.Commission <= max {
+				out = append(out, account)
+			}
+		}
+		return out
+	}
+	return RpcVoteAccounts{Current: filter(va.Current), Delinquent: filter(va.Delinquent)}
+}
+
+// TotalActivatedStake Returns the activated stake in lamports of the current and the delinquent vote accounts
+func (va RpcVoteAccounts) TotalActivatedStake() uint64 {
+	var total uint64
+	for _, accounts := range [][]VoteAccount{va.Current, va.Delinquent} {
+		for _, account := range accounts {
+			total += account.ActivatedStake
+		}
+	}
+	return total
+}
+
+// EpochCreditsDeltas Returns the credits earned in the most recent epoch by vote account, see EpochCreditsDelta
+func (va RpcVoteAccounts) EpochCreditsDeltas() map[common.Address]uint64 {
+	deltas := make(map[common.Address]uint64, len(va.Current)+len(va.Delinquent))
+	for _, accounts := range [][]VoteAccount{va.Current, va.Delinquent} {
+		for _, account := range accounts {
+			deltas[account.VotePubkey] = account.EpochCreditsDelta()
+		}
+	}
+	return deltas
+}
@@ -0,0 +1,132 @@
+package types
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/cielu/go-solana/common"
+)
+
+func TestVoteAccountEstimatedAPY(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		account VoteAccount
+		epochs  float64
+		want    float64
+	}{
+		{
+			name: "no commission",
+			account: VoteAccount{
+				ActivatedStake: 1_000_000,
+				EpochCredits:   [][]uint64{{600, 1000, 0}, {601, 1500, 1000}},
+			},
+			epochs: 182,
+			want:   math.Pow(1.0005, 182) - 1,
+		},
+		{
+			name: "10% commission",
+			account: VoteAccount{
+				ActivatedStake: 1_000_000,
+				Commission:     10,
+				EpochCredits:   [][]uint64{{600, 1000, 0}, {601, 1500, 1000}},
+			},
+			epochs: 182,
+			want:   math.Pow(1.00045, 182) - 1,
+		},
+		{
+			name: "full commission",
+			account: VoteAccount{
+				ActivatedStake: 1_000_000,
+				Commission:     100,
+				EpochCredits:   [][]uint64{{601, 1500, 1000}},
+			},
+			epochs: 182,
+			want:   0,
+		},
+		{
+			name:    "no credits",
+			account: VoteAccount{ActivatedStake: 1_000_000},
+			epochs:  182,
+			want:    0,
+		},
+		{
+			name: "no stake",
+			account: VoteAccount{
+				EpochCredits: [][]uint64{{601, 1500, 1000}},
+			},
+			epochs: 182,
+			want:   0,
+		},
+	}
+
+	for _, test := range tests {
+		got := test.account.EstimatedAPY(test.epochs)
+		if math.Abs(got-test.want) > 1e-12 {
+			t.Errorf("%s: EstimatedAPY Err ==> Got %v, Want: %v", test.name, got, test.want)
+		}
+	}
+}
+
+// voteAccountsPayload a getVoteAccounts result written in the shape of the rpc response,
+// its stakes, credits and slots are made up and not captured from a node
+const voteAccountsPayload = `{
+	"current": [
+		{"activatedStake": 1200000000000000, "commission": 0, "epochCredits": [[700, 2750000, 2000000], [701, 3110000, 2750000]], "epochVoteAccount": true,
+		 "lastVote": 302400123, "nodePubkey": "HEL1USMZKAL2odpNBj2oCjffnFGaYwmbGmyewGv1e2TU", "rootSlot": 302400092, "votePubkey": "he1iusunGwqrNtafDtLdhsUQDFvo13z9sUa36PauBtk"},
+		{"activatedStake": 350000000000000, "commission": 10, "epochCredits": [[700, 1900000, 1550000], [701, 2240000, 1900000]], "epochVoteAccount": true,
+		 "lastVote": 302400120, "nodePubkey": "dv1ZAGvdsz5hHLwWXsVnM94hWf1pjbKVau1QVkaMJ92", "rootSlot": 302400089, "votePubkey": "dv2eQHeP4RFrJZ6UeiZWoc3XTtmtZCUKxxCApCDcRNV"},
+		{"activatedStake": 4800000000000000, "commission": 5, "epochCredits": [[701, 355000, 0]], "epochVoteAccount": true,
+		 "lastVote": 302400122, "nodePubkey": "7Np41oeYqPefeNQEHSv1UDhYrehxin3NStELsSKCT4K2", "rootSlot": 302400091, "votePubkey": "CertusDeBmqN8ZawdkxK5kFGMwBXdudvWHYwtNgNhvLu"}
+	],
+	"delinquent": [
+		{"activatedStake": 90000000000000, "commission": 100, "epochCredits": [[699, 800000, 500000]], "epochVoteAccount": true,
+		 "lastVote": 302390000, "nodePubkey": "GdnSyH3YtwcxFvQrVVJMm1JhTS4QVX7MFsX56uJLUfiZ", "rootSlot": 302389968, "votePubkey": "3N7s9zXMZ4QqvHQR15t5GNHyqc89KduzMP7423eWiD5g"}
+	]
+}`
+
+func TestRpcVoteAccounts(t *testing.T) {
+	var accounts RpcVoteAccounts
+	if err := json.Unmarshal([]byte(voteAccountsPayload), &accounts); err != nil {
+		t.Fatalf("Unmarshal Failed: %s", err)
+	}
+	if total := accounts.TotalActivatedStake(); total != 6440000000000000 {
+		t.Errorf("TotalActivatedStake Err ==> Got %d, Want: 6440000000000000", total)
+	}
+
+	accounts.SortByStake()
+	wantOrder := []string{"CertusDeBmqN8ZawdkxK5kFGMwBXdudvWHYwtNgNhvLu", "he1iusunGwqrNtafDtLdhsUQDFvo13z9sUa36PauBtk", "dv2eQHeP4RFrJZ6UeiZWoc3XTtmtZCUKxxCApCDcRNV"}
+	for i, want := range wantOrder {
+		if accounts.Current[i].VotePubkey.String() != want {
+			t.Errorf("SortByStake[%d] Err ==> Got %s, Want: %s", i, accounts.Current[i].VotePubkey, want)
+		}
+	}
+
+	filtered := accounts.FilterByCommission(5)
+	if len(filtered.Current) != 2 || len(filtered.Delinquent) != 0 || filtered.Current[0].Commission != 5 || filtered.Current[1].Commission != 0 {
+		t.Errorf("FilterByCommission Err ==> Got %+v", filtered)
+	}
+	if len(accounts.Current) != 3 {
+		t.Errorf("FilterByCommission Err ==> Got %d current accounts, Want: 3 unchanged", len(accounts.Current))
+	}
+
+	deltas := accounts.EpochCreditsDeltas()
+	wantDeltas := map[string]uint64{
+		"he1iusunGwqrNtafDtLdhsUQDFvo13z9sUa36PauBtk":  360000,
+		"dv2eQHeP4RFrJZ6UeiZWoc3XTtmtZCUKxxCApCDcRNV":  340000,
+		"CertusDeBmqN8ZawdkxK5kFGMwBXdudvWHYwtNgNhvLu": 355000,
+		"3N7s9zXMZ4QqvHQR15t5GNHyqc89KduzMP7423eWiD5g": 300000,
+	}
+	if len(deltas) != len(wantDeltas) {
+		t.Errorf("EpochCreditsDeltas Err ==> Got %v", deltas)
+	}
+	for vote, want := range wantDeltas {
+		if got := deltas[common.StrToAddress(vote)]; got != want {
+			t.Errorf("EpochCreditsDeltas[%s] Err ==> Got %d, Want: %d", vote, got, want)
+		}
+	}
+	if delta := (VoteAccount{EpochCredits: [][]uint64{{701, 10, 20}}}).EpochCreditsDelta(); delta != 0 {
+		t.Errorf("EpochCreditsDelta Err ==> Got %d, Want: 0", delta)
+	}
+}